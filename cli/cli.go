@@ -13,7 +13,10 @@ import (
 
 func init() {
 	RootCmd.AddCommand(initiator.StartDKG)
+	RootCmd.AddCommand(initiator.StartReshare)
+	RootCmd.AddCommand(initiator.SignBeaconMessage)
 	RootCmd.AddCommand(operator.StartDKGOperator)
+	RootCmd.AddCommand(operator.Admin)
 	RootCmd.AddCommand(initiator.HealthCheck)
 	RootCmd.AddCommand(verify.Verify)
 }
@@ -32,6 +35,8 @@ func Execute(appName, version string) {
 	RootCmd.Version = version
 	initiator.HealthCheck.Version = version
 	initiator.StartDKG.Version = version
+	initiator.StartReshare.Version = version
+	initiator.SignBeaconMessage.Version = version
 	operator.StartDKGOperator.Version = version
 	if err := RootCmd.Execute(); err != nil {
 		log.Fatal("failed to execute root command", zap.Error(err))
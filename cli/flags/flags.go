@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -9,6 +10,7 @@ import (
 // Flag names.
 const (
 	withdrawAddress   = "withdrawAddress"
+	withdrawPubKey    = "withdrawPubKey"
 	operatorIDs       = "operatorIDs"
 	operatorsInfo     = "operatorsInfo"
 	operatorsInfoPath = "operatorsInfoPath"
@@ -29,6 +31,34 @@ const (
 	clientCACertPath  = "clientCACertPath"
 	serverTLSCertPath = "serverTLSCertPath"
 	serverTLSKeyPath  = "serverTLSKeyPath"
+	newOperatorIDs    = "newOperatorIDs"
+	keysharesFilePath = "keysharesFilePath"
+	proofsFilePath    = "proofsFilePath"
+	signatures        = "signatures"
+	ethEndpointURL    = "ethEndpointURL"
+	thresholdTolerant = "thresholdTolerant"
+	storeShares       = "storeShares"
+	resume            = "resume"
+	compounding       = "compounding"
+	amount            = "amount"
+	signExit          = "signExit"
+	exitEpoch         = "exitEpoch"
+	exitIndex         = "exitValidatorIndex"
+	beaconMessage     = "beaconMessage"
+	validatorIndex    = "validatorIndex"
+	dkgPhaseTimeout   = "dkgPhaseTimeout"
+	fetchNonce        = "fetchNonce"
+	operatorsInfoURL  = "operatorsInfoURL"
+	initiatorKey      = "initiatorPrivKey"
+	initiatorKeyPass  = "initiatorPrivKeyPassword"
+	trustedInitiators = "trustedInitiators"
+	signInit          = "signInit"
+	ceremonyID        = "ceremonyID"
+	requireOwnerSig   = "requireOwnerSignature"
+	policy            = "policy"
+	adminAddress      = "adminAddress"
+	adminTokenPath    = "adminTokenPath"
+	adminURL          = "adminURL"
 )
 
 // WithdrawAddressFlag  adds withdraw address flag to the command
@@ -36,11 +66,36 @@ func WithdrawAddressFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, withdrawAddress, "", "Withdrawal address", false)
 }
 
+// WithdrawPubKeyFlag adds BLS withdrawal public key flag to the command
+func WithdrawPubKeyFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, withdrawPubKey, "", "BLS withdrawal public key for 0x00 withdrawal credentials, used instead of withdrawal address", false)
+}
+
 // operatorIDsFlag adds operators IDs flag to the command
 func OperatorIDsFlag(c *cobra.Command) {
 	AddPersistentStringSliceFlag(c, operatorIDs, []string{"1", "2", "3"}, "Operator IDs", false)
 }
 
+// NewOperatorIDsFlag adds new operators IDs flag to the command
+func NewOperatorIDsFlag(c *cobra.Command) {
+	AddPersistentStringSliceFlag(c, newOperatorIDs, []string{}, "New operator IDs for resharing ceremony", false)
+}
+
+// KeysharesFilePathFlag adds path to keyshares file of the previous ceremony flag to the command
+func KeysharesFilePathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, keysharesFilePath, "", "Path to keyshares json file of the previous ceremony", false)
+}
+
+// ProofsFilePathFlag adds path to proofs file of the previous ceremony flag to the command
+func ProofsFilePathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, proofsFilePath, "", "Path to proofs json file of the previous ceremony", false)
+}
+
+// SignaturesFlag adds owner signature of the reshare message flag to the command
+func SignaturesFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, signatures, "", "Hex encoded owner signature of the reshare, sign request or init message hash", false)
+}
+
 // OperatorsInfoFlag  adds path to operators' ifo file flag to the command
 func OperatorsInfoFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, operatorsInfo, "", "Raw JSON string operators' public keys, IDs and IPs file e.g. `{ 1: { publicKey: XXX, id: 1, ip: 10.0.0.1:3033 }`", false)
@@ -51,6 +106,11 @@ func OperatorsInfoPathFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, operatorsInfoPath, "", "Path to a file containing operators' public keys, IDs and IPs file e.g. { 1: { publicKey: XXX, id: 1, ip: 10.0.0.1:3033 }", false)
 }
 
+// OperatorsInfoURLFlag adds SSV API endpoint to fetch operators info flag to the command
+func OperatorsInfoURLFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, operatorsInfoURL, "", "SSV API endpoint to fetch operators' public keys, IDs and IPs e.g. https://api.ssv.network/api/v4/mainnet", false)
+}
+
 // OwnerAddressFlag  adds owner address flag to the command
 func OwnerAddressFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, owner, "", "Owner address", false)
@@ -76,6 +136,56 @@ func PrivateKeyPassFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, privKeyPassword, "", "Password to decrypt initiator`s Private Key file", false)
 }
 
+// InitiatorPrivateKeyFlag adds path to encrypted initiator identity key flag to the command
+func InitiatorPrivateKeyFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, initiatorKey, "", "Path to encrypted initiator RSA private key file used as a persistent initiator identity, a new key is generated for each ceremony if not set. Failed ceremonies are journaled and can be resumed only if set", false)
+}
+
+// InitiatorPrivateKeyPassFlag adds path to password of initiator identity key flag to the command
+func InitiatorPrivateKeyPassFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, initiatorKeyPass, "", "Path to password file to decrypt initiator RSA private key file", false)
+}
+
+// TrustedInitiatorsFlag adds path to the list of trusted initiators flag to the command
+func TrustedInitiatorsFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, trustedInitiators, "", "Path to a JSON file with a list of base64 encoded RSA public keys of initiators allowed to start ceremonies, any initiator is allowed if not set", false)
+}
+
+// SignInitFlag adds flag to sign init message by the owner to the command
+func SignInitFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, signInit, false, "Send init message signed by the owner, the owner signature of the init message hash is provided with signatures flag", false)
+}
+
+// CeremonyIDFlag adds flag of the ceremony ID the init message was signed for by the owner to the command
+func CeremonyIDFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, ceremonyID, "", "Hex encoded ID of the ceremony logged together with the init message hash signed by the owner, provided with signatures flag", false)
+}
+
+// RequireOwnerSignatureFlag adds flag to require owner signature of init messages to the command
+func RequireOwnerSignatureFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, requireOwnerSig, false, "Refuse init messages which aren't signed by the owner, requires ethEndpointURL", false)
+}
+
+// PolicyFlag adds path to the operator policy file flag to the command
+func PolicyFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, policy, "", "Path to a JSON file with the policy deciding which DKG ceremonies the operator joins, any ceremony is joined if not set", false)
+}
+
+// AdminAddressFlag adds listening address of the operator admin API flag to the command
+func AdminAddressFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, adminAddress, "127.0.0.1:3031", "Listening address of the operator admin API, the admin API is served over plain HTTP", false)
+}
+
+// AdminTokenPathFlag adds path to the admin API token flag to the command
+func AdminTokenPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, adminTokenPath, "", "Path to a file with the bearer token of the operator admin API", false)
+}
+
+// AdminURLFlag adds operator admin API endpoint flag to the command
+func AdminURLFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, adminURL, "http://127.0.0.1:3031", "Endpoint of the operator admin API", false)
+}
+
 // OperatorPortFlag  adds operator listening port flag to the command
 func OperatorPortFlag(c *cobra.Command) {
 	AddPersistentIntFlag(c, operatorPort, 3030, "Operator Private Key hex", false)
@@ -126,16 +236,81 @@ func ServerTLSKeyPath(c *cobra.Command) {
 	AddPersistentStringFlag(c, serverTLSKeyPath, "/ssl/tls.key", "Path to server TLS private key", false)
 }
 
+// EthEndpointURLFlag adds ethereum node endpoint flag to the command
+func EthEndpointURLFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, ethEndpointURL, "", "Ethereum node endpoint to verify owner signatures at operator or fetch owner nonce at initiator", false)
+}
+
+// FetchNonceFlag adds flag to fetch owner nonce from the SSV network contract to the command
+func FetchNonceFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, fetchNonce, false, "Fetch owner nonce from ValidatorAdded events of the SSV network contract instead of nonce flag, requires ethEndpointURL", false)
+}
+
 // ValidatorsFlag add number of validators to create flag to the command
 func ValidatorsFlag(c *cobra.Command) {
 	AddPersistentIntFlag(c, validators, 1, "Number of validators", false)
 }
 
+// StoreSharesFlag adds flag to store key shares as EIP-2335 keystores at operator to the command
+func StoreSharesFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, storeShares, false, "Store key shares created at ceremonies as EIP-2335 keystores encrypted with private key password", false)
+}
+
+// ThresholdTolerantFlag adds threshold tolerant DKG mode flag to the command
+func ThresholdTolerantFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, thresholdTolerant, false, "Finish DKG ceremony if at least threshold operators, and no less than 4, are responsive, excluding the rest and resharing key shares to a registrable cluster. Requires at least 7 operators", false)
+}
+
+// ResumeFlag adds flag to resume a failed DKG ceremony by its ID to the command
+func ResumeFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, resume, "", "ID of a failed DKG ceremony to resume from the last acknowledged phase, requires the --initiatorPrivKey the ceremony was started with", false)
+}
+
+// CompoundingFlag adds compounding 0x02 withdrawal credentials flag to the command
+func CompoundingFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, compounding, false, "Use compounding 0x02 withdrawal credentials instead of 0x01", false)
+}
+
+// AmountFlag adds deposit amount flag to the command
+func AmountFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, amount, 32000000000, "Deposit amount in Gwei: up to 32 ETH for 0x00 and 0x01 and up to 2048 ETH for 0x02 withdrawal credentials", false)
+}
+
+// SignExitFlag adds flag to pre-sign a voluntary exit of each validator to the command
+func SignExitFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, signExit, false, "Pre-sign a voluntary exit of each validator during the ceremony", false)
+}
+
+// ExitEpochFlag adds voluntary exit epoch flag to the command
+func ExitEpochFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, exitEpoch, 0, "Earliest epoch the pre-signed voluntary exit can be processed at", false)
+}
+
+// ExitValidatorIndexFlag adds validator index of the voluntary exit flag to the command
+func ExitValidatorIndexFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, exitIndex, 0, "Beacon chain index expected for the first validator, incremented for each next one", false)
+}
+
+// BeaconMessageFlag adds type of the beacon message to sign flag to the command
+func BeaconMessageFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, beaconMessage, "exit", "Beacon message to sign with key shares: exit or blsToExecutionChange", false)
+}
+
+// ValidatorIndexFlag adds beacon chain index of the validator flag to the command
+func ValidatorIndexFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, validatorIndex, 0, "Beacon chain index of the validator", false)
+}
+
 // OperatorIDFlag add operator ID flag to the command
 func OperatorIDFlag(c *cobra.Command) {
 	AddPersistentIntFlag(c, operatorID, 0, "Operator ID", false)
 }
 
+// DKGPhaseTimeoutFlag adds fallback timeout of DKG protocol phases flag to the command
+func DKGPhaseTimeoutFlag(c *cobra.Command) {
+	AddPersistentDurationFlag(c, dkgPhaseTimeout, 0, "Fallback timeout of DKG protocol phases, phases move on as soon as all messages are received (default: operator default of 10s)", false)
+}
+
 // AddPersistentStringFlag adds a string flag to the command
 func AddPersistentStringFlag(c *cobra.Command, flag, value, description string, isRequired bool) {
 	req := ""
@@ -164,6 +339,34 @@ func AddPersistentIntFlag(c *cobra.Command, flag string, value uint64, descripti
 	}
 }
 
+// AddPersistentDurationFlag adds a duration flag to the command
+func AddPersistentDurationFlag(c *cobra.Command, flag string, value time.Duration, description string, isRequired bool) {
+	req := ""
+	if isRequired {
+		req = " (required)"
+	}
+
+	c.PersistentFlags().Duration(flag, value, fmt.Sprintf("%s%s", description, req))
+
+	if isRequired {
+		_ = c.MarkPersistentFlagRequired(flag)
+	}
+}
+
+// AddPersistentBoolFlag adds a bool flag to the command
+func AddPersistentBoolFlag(c *cobra.Command, flag string, value bool, description string, isRequired bool) {
+	req := ""
+	if isRequired {
+		req = " (required)"
+	}
+
+	c.PersistentFlags().Bool(flag, value, fmt.Sprintf("%s%s", description, req))
+
+	if isRequired {
+		_ = c.MarkPersistentFlagRequired(flag)
+	}
+}
+
 // AddPersistentStringArrayFlag adds a string slice flag to the command
 func AddPersistentStringSliceFlag(c *cobra.Command, flag string, value []string, description string, isRequired bool) {
 	req := ""
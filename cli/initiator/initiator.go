@@ -5,8 +5,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"path/filepath"
+	"strings"
 
-	"github.com/sourcegraph/conc/pool"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
@@ -15,11 +17,7 @@ import (
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
-)
-
-const (
-	// maxConcurrency is the maximum number of DKG inits to run concurrently.
-	maxConcurrency = 20
+	"github.com/bloxapp/ssv-dkg/spec"
 )
 
 func init() {
@@ -53,77 +51,125 @@ var StartDKG = &cobra.Command{
 			}
 		}()
 		logger.Info("🪛 Initiator`s", zap.String("Version", cmd.Version))
-		// Load operators TODO: add more sources.
-		operatorIDs, err := cli_utils.StingSliceToUintArray(cli_utils.OperatorIDs)
-		if err != nil {
-			logger.Fatal("😥 Failed to load participants: ", zap.Error(err))
-		}
 		opMap, err := cli_utils.LoadOperators(logger)
 		if err != nil {
 			logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
 		}
-		logger.Info("🔑 opening initiator RSA private key file")
+		privateKey, err := cli_utils.LoadInitiatorPrivateKey(logger)
+		if err != nil {
+			logger.Fatal("😥 Failed to load initiator private key: ", zap.Error(err))
+		}
+		ctx, stop := cli_utils.SignalContext(cmd)
+		defer stop()
+		ceremony := &initiator.Ceremony{
+			Operators:         opMap,
+			Logger:            logger,
+			Version:           cmd.Version,
+			ClientCACerts:     cli_utils.ClientCACertPath,
+			Journal:           initiator.NewJournalStore(filepath.Join(cli_utils.OutputPath, "journal")),
+			ThresholdTolerant: cli_utils.ThresholdTolerant,
+			DKGPhaseTimeout:   cli_utils.DKGPhaseTimeout,
+			PrivateKey:        privateKey,
+		}
+		if cli_utils.Resume != "" {
+			resumeDKG(ctx, logger, ceremony)
+			return nil
+		}
+		operatorIDs, err := cli_utils.StingSliceToUintArray(cli_utils.OperatorIDs)
+		if err != nil {
+			logger.Fatal("😥 Failed to load participants: ", zap.Error(err))
+		}
 		ethnetwork := e2m_core.MainNetwork
 		if cli_utils.Network != "now_test_network" {
 			ethnetwork = e2m_core.NetworkFromString(cli_utils.Network)
 		}
-		// start the ceremony
-		ctx := context.Background()
-		pool := pool.NewWithResults[*Result]().WithContext(ctx).WithFirstError().WithMaxGoroutines(maxConcurrency)
-		for i := 0; i < int(cli_utils.Validators); i++ {
-			i := i
-			pool.Go(func(ctx context.Context) (*Result, error) {
-				// Create new DKG initiator
-				dkgInitiator, err := initiator.New(opMap.Clone(), logger, cmd.Version, cli_utils.ClientCACertPath)
-				if err != nil {
-					return nil, err
-				}
-				// Create a new ID.
-				id := crypto.NewID()
-				nonce := cli_utils.Nonce + uint64(i)
-				// Perform the ceremony.
-				depositData, keyShares, proofs, err := dkgInitiator.StartDKG(id, cli_utils.WithdrawAddress.Bytes(), operatorIDs, ethnetwork, cli_utils.OwnerAddress, nonce)
-				if err != nil {
-					return nil, err
-				}
-				logger.Debug("DKG ceremony completed",
-					zap.String("id", hex.EncodeToString(id[:])),
-					zap.Uint64("nonce", nonce),
-					zap.String("pubkey", depositData.PubKey),
-				)
-				return &Result{
-					id:          id,
-					depositData: depositData,
-					keyShares:   keyShares,
-					nonce:       nonce,
-					proof:       proofs,
-				}, nil
-			})
-		}
-		results, err := pool.Wait()
-		if err != nil {
+		if cli_utils.FetchNonce {
+			cli_utils.Nonce, err = fetchOwnerNonce(ctx, ethnetwork)
+			if err != nil {
+				logger.Fatal("😥 Failed to fetch owner nonce: ", zap.Error(err))
+			}
+			logger.Info("🔢 Fetched owner nonce from the SSV network contract", zap.Uint64("nonce", cli_utils.Nonce))
+		}
+		var exit *initiator.ExitRequest
+		if cli_utils.SignExit {
+			exit = &initiator.ExitRequest{
+				Epoch:          cli_utils.ExitEpoch,
+				ValidatorIndex: cli_utils.ExitValidatorIndex,
+			}
+		}
+		req := initiator.BatchRequest{
+			OperatorIDs:      operatorIDs,
+			Validators:       int(cli_utils.Validators),
+			Owner:            cli_utils.OwnerAddress,
+			Nonce:            cli_utils.Nonce,
+			WithdrawAddress:  cli_utils.WithdrawAddress,
+			WithdrawPubKey:   cli_utils.WithdrawPubKey,
+			WithdrawalPrefix: cli_utils.WithdrawalPrefix,
+			Amount:           cli_utils.Amount,
+			Network:          ethnetwork,
+			Exit:             exit,
+		}
+		if cli_utils.SignInit && !setOwnerSigner(logger, ceremony, &req) {
+			return nil
+		}
+		// start the ceremonies
+		res, err := ceremony.RunBatch(ctx, req)
+		if err != nil && len(res.Ceremonies) == 0 {
+			logFailedCeremonies(logger, res.Failed)
 			logger.Fatal("😥 Failed to initiate DKG ceremony: ", zap.Error(err))
 		}
-		var depositDataArr []*wire.DepositDataCLI
-		var keySharesArr []*wire.KeySharesCLI
-		var proofs [][]*wire.SignedProof
-		for _, res := range results {
-			depositDataArr = append(depositDataArr, res.depositData)
-			keySharesArr = append(keySharesArr, res.keyShares)
-			proofs = append(proofs, res.proof)
+		for _, c := range res.Ceremonies {
+			logger.Debug("DKG ceremony completed",
+				zap.String("id", hex.EncodeToString(c.ID[:])),
+				zap.Uint64("nonce", c.Nonce),
+				zap.String("pubkey", c.DepositData.PubKey),
+			)
+			if len(c.ExcludedOperators) > 0 {
+				logger.Warn("⚠️ Operators were excluded from the ceremony, key shares were reshared to the remaining operators",
+					zap.String("pubkey", c.DepositData.PubKey),
+					zap.Uint64s("excluded operator IDs", c.ExcludedOperators),
+					zap.Uint64s("operator IDs", c.KeyShares.Shares[0].Payload.OperatorIDs),
+				)
+			}
+		}
+		if err != nil {
+			// results of finished ceremonies are saved separately, the failed ones are resumed or run again later
+			for _, part := range res.ByCeremony() {
+				first := part.Ceremonies[0]
+				if err := cli_utils.WriteResults(
+					logger,
+					part.DepositData(),
+					part.KeyShares(),
+					part.Proofs(),
+					part.VoluntaryExits(),
+					true,
+					len(part.Ceremonies),
+					first.Owner,
+					first.Nonce,
+					first.WithdrawalCredentials,
+					first.Amount,
+					cli_utils.OutputPath,
+				); err != nil {
+					logger.Error("Could not save results", zap.String("id", hex.EncodeToString(first.ID[:])), zap.Error(err))
+				}
+			}
+			logFailedCeremonies(logger, res.Failed)
+			logger.Fatal("😥 Some DKG ceremonies of the batch failed, results of the finished ones are saved", zap.Error(err))
 		}
 		// Save results
 		logger.Info("🎯 All data is validated.")
 		if err := cli_utils.WriteResults(
 			logger,
-			depositDataArr,
-			keySharesArr,
-			proofs,
+			res.DepositData(),
+			res.KeyShares(),
+			res.Proofs(),
+			res.VoluntaryExits(),
 			false,
 			int(cli_utils.Validators),
 			cli_utils.OwnerAddress,
 			cli_utils.Nonce,
-			cli_utils.WithdrawAddress,
+			cli_utils.WithdrawalCredentials,
+			cli_utils.Amount,
 			cli_utils.OutputPath,
 		); err != nil {
 			logger.Fatal("Could not save results", zap.Error(err))
@@ -133,10 +179,104 @@ var StartDKG = &cobra.Command{
 	},
 }
 
-type Result struct {
-	id          [24]byte
-	nonce       uint64
-	depositData *wire.DepositDataCLI
-	keyShares   *wire.KeySharesCLI
-	proof       []*wire.SignedProof
+// fetchOwnerNonce reads the current owner nonce from the SSV network contract of the network
+func fetchOwnerNonce(ctx context.Context, network e2m_core.Network) (uint64, error) {
+	contract, err := initiator.SSVNetworkContractOf(network)
+	if err != nil {
+		return 0, err
+	}
+	client, err := ethclient.DialContext(ctx, cli_utils.EthEndpointURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ethereum node: %w", err)
+	}
+	defer client.Close()
+	return initiator.FetchOwnerNonce(ctx, client, contract, cli_utils.OwnerAddress)
+}
+
+// setOwnerSigner sets the owner signature provided with signatures flag to be sent with the init message of the ceremony
+// with ID provided by ceremonyID flag. If the signature isn't provided, a new ceremony ID and the hash to be signed by
+// the owner are logged and false is returned.
+func setOwnerSigner(logger *zap.Logger, ceremony *initiator.Ceremony, req *initiator.BatchRequest) bool {
+	inits, err := ceremony.InitMessages(*req)
+	if err != nil {
+		logger.Fatal("😥 Failed to construct init message: ", zap.Error(err))
+	}
+	if len(inits) != 1 {
+		logger.Fatal("😥 Init message signed by the owner is supported for a single ceremony", zap.Int("ceremonies", len(inits)))
+	}
+	if len(cli_utils.Signatures) == 0 {
+		id := crypto.NewID()
+		hash, err := spec.InitSigningRoot(id, inits[0])
+		if err != nil {
+			logger.Fatal("😥 Failed to compute init message hash: ", zap.Error(err))
+		}
+		logger.Info("✍️ Init message should be signed by the owner, please provide the signature of the hash with --signatures flag and the ceremony ID with --ceremonyID flag",
+			zap.String("hash", "0x"+hex.EncodeToString(hash[:])),
+			zap.String("ceremony ID", "0x"+hex.EncodeToString(id[:])),
+		)
+		return false
+	}
+	hash, err := spec.InitSigningRoot(cli_utils.CeremonyID, inits[0])
+	if err != nil {
+		logger.Fatal("😥 Failed to compute init message hash: ", zap.Error(err))
+	}
+	req.ID = cli_utils.CeremonyID
+	signature := cli_utils.Signatures
+	ceremony.OwnerSigner = func(id [24]byte, init *wire.Init) ([]byte, error) {
+		initHash, err := spec.InitSigningRoot(id, init)
+		if err != nil {
+			return nil, err
+		}
+		if initHash != hash {
+			return nil, fmt.Errorf("init message doesn't match the message signed by the owner")
+		}
+		return signature, nil
+	}
+	return true
+}
+
+// resumeDKG continues a failed DKG ceremony from its journal and saves the results
+func resumeDKG(ctx context.Context, logger *zap.Logger, ceremony *initiator.Ceremony) {
+	idBytes, err := hex.DecodeString(strings.TrimPrefix(cli_utils.Resume, "0x"))
+	if err != nil || len(idBytes) != 24 {
+		logger.Fatal("😥 Failed to parse ID of the ceremony to resume", zap.String("resume", cli_utils.Resume))
+	}
+	var id [24]byte
+	copy(id[:], idBytes)
+	res, err := ceremony.Resume(ctx, id)
+	if err != nil {
+		logger.Fatal("😥 Failed to resume DKG ceremony: ", zap.Error(err))
+	}
+	logger.Info("🎯 All data is validated.")
+	first := res.Ceremonies[0]
+	if err := cli_utils.WriteResults(
+		logger,
+		res.DepositData(),
+		res.KeyShares(),
+		res.Proofs(),
+		res.VoluntaryExits(),
+		false,
+		len(res.Ceremonies),
+		first.Owner,
+		first.Nonce,
+		first.WithdrawalCredentials,
+		first.Amount,
+		cli_utils.OutputPath,
+	); err != nil {
+		logger.Fatal("Could not save results", zap.Error(err))
+	}
+	logger.Info("🚀 DKG ceremony completed")
+}
+
+// logFailedCeremonies lists failed ceremonies of the batch, resumable ones are continued by the resume command
+func logFailedCeremonies(logger *zap.Logger, failed []*initiator.CeremonyError) {
+	for _, f := range failed {
+		logger.Error("❌ DKG ceremony failed",
+			zap.String("id", hex.EncodeToString(f.ID[:])),
+			zap.Uint64("nonce", f.Nonce),
+			zap.Int("validators", f.Validators),
+			zap.Bool("resumable", f.Resumable),
+			zap.Error(f.Err),
+		)
+	}
 }
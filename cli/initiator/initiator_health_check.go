@@ -39,7 +39,9 @@ var HealthCheck = &cobra.Command{
 		if err != nil {
 			logger.Fatal("😥", zap.Error(err))
 		}
-		err = dkgInitiator.Ping(ips)
+		ctx, stop := cli_utils.SignalContext(cmd)
+		defer stop()
+		err = dkgInitiator.Ping(ctx, ips)
 		if err != nil {
 			logger.Fatal("😥 Error: ", zap.Error(err))
 		}
@@ -0,0 +1,127 @@
+package initiator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	cli_utils "github.com/bloxapp/ssv-dkg/cli/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func init() {
+	cli_utils.SetReshareFlags(StartReshare)
+}
+
+var StartReshare = &cobra.Command{
+	Use:   "reshare",
+	Short: "Reshares existing validator key shares to a new set of operators",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(`
+		█████╗ ██╗  ██╗ ██████╗     ██╗███╗   ██╗██╗████████╗██╗ █████╗ ████████╗ ██████╗ ██████╗
+		██╔══██╗██║ ██╔╝██╔════╝     ██║████╗  ██║██║╚══██╔══╝██║██╔══██╗╚══██╔══╝██╔═══██╗██╔══██╗
+		██║  ██║█████╔╝ ██║  ███╗    ██║██╔██╗ ██║██║   ██║   ██║███████║   ██║   ██║   ██║██████╔╝
+		██║  ██║██╔═██╗ ██║   ██║    ██║██║╚██╗██║██║   ██║   ██║██╔══██║   ██║   ██║   ██║██╔══██╗
+		██████╔╝██║  ██╗╚██████╔╝    ██║██║ ╚████║██║   ██║   ██║██║  ██║   ██║   ╚██████╔╝██║  ██║
+		╚═════╝ ╚═╝  ╚═╝ ╚═════╝     ╚═╝╚═╝  ╚═══╝╚═╝   ╚═╝   ╚═╝╚═╝  ╚═╝   ╚═╝    ╚═════╝ ╚═╝  ╚═╝`)
+		if err := cli_utils.SetViperConfig(cmd); err != nil {
+			return err
+		}
+		if err := cli_utils.BindReshareFlags(cmd); err != nil {
+			return err
+		}
+		logger, err := cli_utils.SetGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := cli_utils.Sync(logger); err != nil {
+				log.Printf("Failed to sync logger: %v", err)
+			}
+		}()
+		logger.Info("🪛 Initiator`s", zap.String("Version", cmd.Version))
+		newOperatorIDs, err := cli_utils.StingSliceToUintArray(cli_utils.NewOperatorIDs)
+		if err != nil {
+			logger.Fatal("😥 Failed to load new participants: ", zap.Error(err))
+		}
+		opMap, err := cli_utils.LoadOperators(logger)
+		if err != nil {
+			logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
+		}
+		keyshares, err := cli_utils.LoadKeyshares(cli_utils.KeysharesFilePath)
+		if err != nil {
+			logger.Fatal("😥 Failed to load keyshares of the previous ceremony: ", zap.Error(err))
+		}
+		proofs, err := cli_utils.LoadProofs(cli_utils.ProofsFilePath)
+		if err != nil {
+			logger.Fatal("😥 Failed to load proofs of the previous ceremony: ", zap.Error(err))
+		}
+		ethnetwork := e2m_core.MainNetwork
+		if cli_utils.Network != "now_test_network" {
+			ethnetwork = e2m_core.NetworkFromString(cli_utils.Network)
+		}
+		dkgInitiator, err := initiator.New(opMap.Clone(), logger, cmd.Version, cli_utils.ClientCACertPath)
+		if err != nil {
+			logger.Fatal("😥 Failed to create initiator: ", zap.Error(err))
+		}
+		privateKey, err := cli_utils.LoadInitiatorPrivateKey(logger)
+		if err != nil {
+			logger.Fatal("😥 Failed to load initiator private key: ", zap.Error(err))
+		}
+		if privateKey != nil {
+			dkgInitiator.PrivateKey = privateKey
+		}
+		dkgInitiator.DKGPhaseTimeout = cli_utils.DKGPhaseTimeout
+		reshare, err := dkgInitiator.ConstructReshareMessage(newOperatorIDs, keyshares, proofs, cli_utils.Withdrawal(), cli_utils.WithdrawalPrefix, cli_utils.Amount, ethnetwork, cli_utils.Nonce)
+		if err != nil {
+			logger.Fatal("😥 Failed to construct reshare message: ", zap.Error(err))
+		}
+		if len(cli_utils.Signatures) == 0 {
+			hash, err := reshare.SignedReshare.Reshare.HashTreeRoot()
+			if err != nil {
+				logger.Fatal("😥 Failed to compute reshare message hash: ", zap.Error(err))
+			}
+			logger.Info("✍️ Reshare message should be signed by the owner, please provide the signature of the hash with --signatures flag", zap.String("hash", "0x"+hex.EncodeToString(hash[:])))
+			return nil
+		}
+		reshare.SignedReshare.Signature = cli_utils.Signatures
+		ctx, stop := cli_utils.SignalContext(cmd)
+		defer stop()
+		id := crypto.NewID()
+		depositData, newKeyshares, newProofs, err := dkgInitiator.StartReshare(ctx, id, reshare)
+		if err != nil {
+			logger.Fatal("😥 Failed to reshare: ", zap.Error(err))
+		}
+		logger.Debug("Resharing ceremony completed",
+			zap.String("id", hex.EncodeToString(id[:])),
+			zap.Uint64("nonce", cli_utils.Nonce),
+			zap.String("pubkey", depositData.PubKey),
+		)
+		// Save results
+		logger.Info("🎯 All data is validated.")
+		if err := cli_utils.WriteResults(
+			logger,
+			[]*wire.DepositDataCLI{depositData},
+			[]*wire.KeySharesCLI{newKeyshares},
+			[][]*wire.SignedProof{newProofs},
+			nil,
+			false,
+			1,
+			cli_utils.OwnerAddress,
+			cli_utils.Nonce,
+			cli_utils.WithdrawalCredentials,
+			cli_utils.Amount,
+			cli_utils.OutputPath,
+		); err != nil {
+			logger.Fatal("Could not save results", zap.Error(err))
+		}
+		logger.Info("🚀 Resharing ceremony completed")
+		return nil
+	},
+}
@@ -0,0 +1,135 @@
+package initiator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	cli_utils "github.com/bloxapp/ssv-dkg/cli/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func init() {
+	cli_utils.SetSignFlags(SignBeaconMessage)
+}
+
+var SignBeaconMessage = &cobra.Command{
+	Use:   "sign",
+	Short: "Signs a voluntary exit or a BLS to execution change with key shares kept by operators",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(`
+		█████╗ ██╗  ██╗ ██████╗     ██╗███╗   ██╗██╗████████╗██╗ █████╗ ████████╗ ██████╗ ██████╗
+		██╔══██╗██║ ██╔╝██╔════╝     ██║████╗  ██║██║╚══██╔══╝██║██╔══██╗╚══██╔══╝██╔═══██╗██╔══██╗
+		██║  ██║█████╔╝ ██║  ███╗    ██║██╔██╗ ██║██║   ██║   ██║███████║   ██║   ██║   ██║██████╔╝
+		██║  ██║██╔═██╗ ██║   ██║    ██║██║╚██╗██║██║   ██║   ██║██╔══██║   ██║   ██║   ██║██╔══██╗
+		██████╔╝██║  ██╗╚██████╔╝    ██║██║ ╚████║██║   ██║   ██║██║  ██║   ██║   ╚██████╔╝██║  ██║
+		╚═════╝ ╚═╝  ╚═╝ ╚═════╝     ╚═╝╚═╝  ╚═══╝╚═╝   ╚═╝   ╚═╝╚═╝  ╚═╝   ╚═╝    ╚═════╝ ╚═╝  ╚═╝`)
+		if err := cli_utils.SetViperConfig(cmd); err != nil {
+			return err
+		}
+		if err := cli_utils.BindSignFlags(cmd); err != nil {
+			return err
+		}
+		logger, err := cli_utils.SetGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := cli_utils.Sync(logger); err != nil {
+				log.Printf("Failed to sync logger: %v", err)
+			}
+		}()
+		logger.Info("🪛 Initiator`s", zap.String("Version", cmd.Version))
+		opMap, err := cli_utils.LoadOperators(logger)
+		if err != nil {
+			logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
+		}
+		keyshares, err := cli_utils.LoadKeyshares(cli_utils.KeysharesFilePath)
+		if err != nil {
+			logger.Fatal("😥 Failed to load keyshares of the ceremony: ", zap.Error(err))
+		}
+		proofs, err := cli_utils.LoadProofs(cli_utils.ProofsFilePath)
+		if err != nil {
+			logger.Fatal("😥 Failed to load proofs of the ceremony: ", zap.Error(err))
+		}
+		ethnetwork := e2m_core.MainNetwork
+		if cli_utils.Network != "now_test_network" {
+			ethnetwork = e2m_core.NetworkFromString(cli_utils.Network)
+		}
+		if len(keyshares.Shares) != 1 {
+			logger.Fatal("😥 Keyshares should contain exactly one validator share")
+		}
+		validatorPK, err := hex.DecodeString(strings.TrimPrefix(keyshares.Shares[0].PublicKey, "0x"))
+		if err != nil || len(validatorPK) != phase0.PublicKeyLength {
+			logger.Fatal("😥 Failed to decode validator public key of keyshares")
+		}
+		var msg wire.SSZMarshaller
+		switch cli_utils.BeaconMessage {
+		case "exit":
+			msg = &phase0.VoluntaryExit{Epoch: cli_utils.ExitEpoch, ValidatorIndex: cli_utils.ValidatorIndex}
+		case "blsToExecutionChange":
+			change := &capella.BLSToExecutionChange{ValidatorIndex: cli_utils.ValidatorIndex, ToExecutionAddress: [20]byte(cli_utils.WithdrawAddress)}
+			copy(change.FromBLSPubkey[:], validatorPK)
+			msg = change
+		}
+		dkgInitiator, err := initiator.New(opMap.Clone(), logger, cmd.Version, cli_utils.ClientCACertPath)
+		if err != nil {
+			logger.Fatal("😥 Failed to create initiator: ", zap.Error(err))
+		}
+		privateKey, err := cli_utils.LoadInitiatorPrivateKey(logger)
+		if err != nil {
+			logger.Fatal("😥 Failed to load initiator private key: ", zap.Error(err))
+		}
+		if privateKey != nil {
+			dkgInitiator.PrivateKey = privateKey
+		}
+		signMsg, err := dkgInitiator.ConstructBlsSignMessage(keyshares, proofs, msg, ethnetwork)
+		if err != nil {
+			logger.Fatal("😥 Failed to construct sign request: ", zap.Error(err))
+		}
+		if common.Address(signMsg.SignedRequest.Request.Owner) != cli_utils.OwnerAddress {
+			logger.Fatal("😥 Owner address doesn't match keyshares")
+		}
+		if len(cli_utils.Signatures) == 0 {
+			hash, err := signMsg.SignedRequest.Request.HashTreeRoot()
+			if err != nil {
+				logger.Fatal("😥 Failed to compute sign request hash: ", zap.Error(err))
+			}
+			logger.Info("✍️ Sign request should be signed by the owner, please provide the signature of the hash with --signatures flag", zap.String("hash", "0x"+hex.EncodeToString(hash[:])))
+			return nil
+		}
+		signMsg.SignedRequest.Signature = cli_utils.Signatures
+		ctx, stop := cli_utils.SignalContext(cmd)
+		defer stop()
+		sig, err := dkgInitiator.SignBeaconMessage(ctx, crypto.NewID(), signMsg)
+		if err != nil {
+			logger.Fatal("😥 Failed to sign beacon message: ", zap.Error(err))
+		}
+		if err := os.MkdirAll(cli_utils.OutputPath, os.ModePerm); err != nil {
+			logger.Fatal("😥 Failed to create output directory: ", zap.Error(err))
+		}
+		pubKey := hex.EncodeToString(validatorPK)
+		switch m := msg.(type) {
+		case *phase0.VoluntaryExit:
+			err = cli_utils.WriteVoluntaryExit(&phase0.SignedVoluntaryExit{Message: m, Signature: sig}, pubKey, cli_utils.OutputPath)
+		case *capella.BLSToExecutionChange:
+			err = cli_utils.WriteBLSToExecutionChange(&capella.SignedBLSToExecutionChange{Message: m, Signature: sig}, pubKey, cli_utils.OutputPath)
+		}
+		if err != nil {
+			logger.Fatal("Could not save signed beacon message", zap.Error(err))
+		}
+		logger.Info("🚀 Beacon message is signed", zap.String("type", cli_utils.BeaconMessage), zap.String("output", cli_utils.OutputPath))
+		return nil
+	},
+}
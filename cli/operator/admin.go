@@ -0,0 +1,194 @@
+package operator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli_utils "github.com/bloxapp/ssv-dkg/cli/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+)
+
+func init() {
+	cli_utils.SetAdminFlags(Admin)
+	Approvals.AddCommand(ApprovalsList, ApprovalsApprove, ApprovalsReject)
+	Instances.AddCommand(InstancesList, InstancesShow, InstancesCancel)
+	Admin.AddCommand(Approvals, Instances)
+}
+
+// Admin manages a running operator through its admin API
+var Admin = &cobra.Command{
+	Use:   "operator",
+	Short: "Manages a running DKG operator through its admin API",
+}
+
+var Approvals = &cobra.Command{
+	Use:   "approvals",
+	Short: "Manages DKG ceremonies waiting for approval of the operator",
+}
+
+var ApprovalsList = &cobra.Command{
+	Use:   "list",
+	Short: "Lists DKG ceremonies waiting for approval of the operator",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+		pending, err := client.PendingCeremonies(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return printJSON(pending)
+	},
+}
+
+var ApprovalsApprove = &cobra.Command{
+	Use:   "approve <ceremony ID>",
+	Short: "Approves a DKG ceremony, the operator joins it when the initiator repeats the init message",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+		id, err := parseCeremonyID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := client.Approve(cmd.Context(), id); err != nil {
+			return err
+		}
+		fmt.Printf("✅ ceremony %s is approved\n", args[0])
+		return nil
+	},
+}
+
+var ApprovalsReject = &cobra.Command{
+	Use:   "reject <ceremony ID>",
+	Short: "Rejects a DKG ceremony waiting for approval",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+		id, err := parseCeremonyID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := client.Reject(cmd.Context(), id); err != nil {
+			return err
+		}
+		fmt.Printf("🚫 ceremony %s is rejected\n", args[0])
+		return nil
+	},
+}
+
+var Instances = &cobra.Command{
+	Use:   "instances",
+	Short: "Manages DKG and resharing instances running at the operator",
+}
+
+var InstancesList = &cobra.Command{
+	Use:   "list",
+	Short: "Lists instances running at the operator with their phase and age",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+		instances, err := client.Instances(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return printJSON(instances)
+	},
+}
+
+var InstancesShow = &cobra.Command{
+	Use:   "show <ceremony ID>",
+	Short: "Shows an instance running at the operator",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+		id, err := parseCeremonyID(args[0])
+		if err != nil {
+			return err
+		}
+		inst, err := client.Instance(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+		return printJSON(inst)
+	},
+}
+
+var InstancesCancel = &cobra.Command{
+	Use:   "cancel <ceremony ID>",
+	Short: "Cancels an instance running at the operator, the initiator gets an error at the next request",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+		id, err := parseCeremonyID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := client.CancelInstance(cmd.Context(), id); err != nil {
+			return err
+		}
+		fmt.Printf("🛑 instance %s is cancelled\n", args[0])
+		return nil
+	},
+}
+
+// adminClient creates a client of the operator admin API set by the command flags
+func adminClient(cmd *cobra.Command) (*operator.AdminClient, error) {
+	url, err := cmd.Flags().GetString("adminURL")
+	if err != nil {
+		return nil, err
+	}
+	tokenPath, err := cmd.Flags().GetString("adminTokenPath")
+	if err != nil {
+		return nil, err
+	}
+	if tokenPath == "" {
+		return nil, fmt.Errorf("😥 adminTokenPath flag is required")
+	}
+	token, err := cli_utils.ReadAdminToken(tokenPath)
+	if err != nil {
+		return nil, err
+	}
+	return operator.NewAdminClient(url, token), nil
+}
+
+// parseCeremonyID parses a hex encoded ceremony ID
+func parseCeremonyID(s string) ([24]byte, error) {
+	var id [24]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != len(id) {
+		return id, fmt.Errorf("😥 invalid ceremony ID %s", s)
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -3,12 +3,16 @@ package operator
 import (
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	cli_utils "github.com/bloxapp/ssv-dkg/cli/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
 )
 
 func init() {
@@ -47,10 +51,67 @@ var StartDKGOperator = &cobra.Command{
 		if err != nil {
 			logger.Fatal("😥 Failed to load private key: ", zap.Error(err))
 		}
-		srv, err := operator.New(privateKey, logger, []byte(cmd.Version), cli_utils.OperatorID, cli_utils.OutputPath)
+		var ethClient eip1271.ETHClient
+		if cli_utils.EthEndpointURL != "" {
+			ethClient, err = ethclient.Dial(cli_utils.EthEndpointURL)
+			if err != nil {
+				logger.Fatal("😥 Failed to connect to ethereum node: ", zap.Error(err))
+			}
+		} else {
+			logger.Warn("⚠️ Ethereum node endpoint is not set, resharing requests will be refused")
+		}
+		srv, err := operator.New(privateKey, logger, []byte(cmd.Version), cli_utils.OperatorID, cli_utils.OutputPath, ethClient)
 		if err != nil {
 			logger.Fatal("😥 Failed to create new operator instance: ", zap.Error(err))
 		}
+		if cli_utils.StoreShares {
+			password, err := os.ReadFile(filepath.Clean(cli_utils.PrivKeyPassword))
+			if err != nil {
+				logger.Fatal("😥 Failed to read password file: ", zap.Error(err))
+			}
+			keystoresDir := filepath.Join(cli_utils.OutputPath, "keystores")
+			srv.State.ShareStore = operator.NewShareStore(keystoresDir, string(password))
+			logger.Info("🔐 Key shares will be stored as EIP-2335 keystores", zap.String("path", keystoresDir))
+		}
+		if cli_utils.DKGPhaseTimeout != 0 {
+			srv.State.PhaseTimeout = cli_utils.DKGPhaseTimeout
+		}
+		if cli_utils.TrustedInitiators != "" {
+			trusted, err := cli_utils.ReadTrustedInitiatorsFile(cli_utils.TrustedInitiators)
+			if err != nil {
+				logger.Fatal("😥 Failed to load trusted initiators: ", zap.Error(err))
+			}
+			srv.State.TrustedInitiators = trusted
+			logger.Info("🔐 Only trusted initiators can start ceremonies", zap.Int("trusted initiators", len(trusted)))
+		}
+		if cli_utils.RequireOwnerSig {
+			srv.State.RequireOwnerSignature = true
+			logger.Info("🔐 Init messages should be signed by the owner")
+		}
+		if cli_utils.Policy != "" {
+			policy, err := operator.LoadPolicy(cli_utils.Policy)
+			if err != nil {
+				logger.Fatal("😥 Failed to load operator policy: ", zap.Error(err))
+			}
+			srv.State.Policy = policy
+			logger.Info("📜 DKG ceremonies are checked by the operator policy", zap.String("policy", cli_utils.Policy))
+		}
+		stateDir := filepath.Join(cli_utils.OutputPath, "state")
+		srv.State.StateStore = operator.NewFileStateStore(stateDir)
+		if err := srv.State.RestoreInstances(); err != nil {
+			logger.Fatal("😥 Failed to restore DKG instances: ", zap.Error(err))
+		}
+		if cli_utils.AdminTokenPath != "" {
+			token, err := cli_utils.ReadAdminToken(cli_utils.AdminTokenPath)
+			if err != nil {
+				logger.Fatal("😥 Failed to load admin API token: ", zap.Error(err))
+			}
+			go func() {
+				if err := srv.StartAdmin(cli_utils.AdminAddress, token); err != nil {
+					logger.Fatal("😥 Admin API failed: ", zap.Error(err))
+				}
+			}()
+		}
 		logger.Info("🚀 Starting DKG operator", zap.Uint64("at port", cli_utils.Port))
 		if err := srv.Start(uint16(cli_utils.Port), cli_utils.ServerTLSCertPath, cli_utils.ServerTLSKeyPath); err != nil {
 			log.Fatalf("Error in operator %v", err)
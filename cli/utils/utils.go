@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -16,6 +18,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -27,6 +31,7 @@ import (
 	"github.com/bloxapp/ssv-dkg/pkgs/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/validator"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
 	"github.com/bloxapp/ssv/logging"
 )
 
@@ -42,15 +47,45 @@ var (
 
 // init flags
 var (
-	OperatorsInfo     string
-	OperatorsInfoPath string
-	OperatorIDs       []string
-	WithdrawAddress   common.Address
-	Network           string
-	OwnerAddress      common.Address
-	Nonce             uint64
-	Validators        uint
-	ClientCACertPath  []string
+	OperatorsInfo         string
+	OperatorsInfoPath     string
+	OperatorsInfoURL      string
+	OperatorIDs           []string
+	WithdrawAddress       common.Address
+	WithdrawPubKey        []byte
+	WithdrawalPrefix      byte
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	Network               string
+	OwnerAddress          common.Address
+	Nonce                 uint64
+	Validators            uint
+	ClientCACertPath      []string
+	ThresholdTolerant     bool
+	Resume                string
+	SignExit              bool
+	ExitEpoch             phase0.Epoch
+	ExitValidatorIndex    phase0.ValidatorIndex
+	DKGPhaseTimeout       time.Duration
+	FetchNonce            bool
+	InitiatorPrivKey      string
+	InitiatorPrivKeyPass  string
+	SignInit              bool
+	CeremonyID            [24]byte
+)
+
+// reshare flags
+var (
+	NewOperatorIDs    []string
+	KeysharesFilePath string
+	ProofsFilePath    string
+	Signatures        []byte
+)
+
+// sign flags
+var (
+	BeaconMessage  string
+	ValidatorIndex phase0.ValidatorIndex
 )
 
 // operator flags
@@ -61,6 +96,13 @@ var (
 	OperatorID        uint64
 	ServerTLSCertPath string
 	ServerTLSKeyPath  string
+	EthEndpointURL    string
+	StoreShares       bool
+	TrustedInitiators string
+	RequireOwnerSig   bool
+	Policy            string
+	AdminAddress      string
+	AdminTokenPath    string
 )
 
 // verify flags
@@ -108,6 +150,12 @@ func SetGlobalLogger(cmd *cobra.Command, name string) (*zap.Logger, error) {
 	return logger, nil
 }
 
+// SignalContext creates a context of the command which is cancelled on Ctrl-C or SIGTERM,
+// so running ceremonies and their requests to operators are aborted
+func SignalContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+}
+
 // OpenPrivateKey reads an RSA key from file.
 // If passwordFilePath is provided, treats privKeyPath as encrypted
 func OpenPrivateKey(passwordFilePath, privKeyPath string) (*rsa.PrivateKey, error) {
@@ -130,6 +178,61 @@ func OpenPrivateKey(passwordFilePath, privKeyPath string) (*rsa.PrivateKey, erro
 	return privateKey, nil
 }
 
+// LoadInitiatorPrivateKey opens the persistent initiator identity key, nil is returned if the key isn't set
+func LoadInitiatorPrivateKey(logger *zap.Logger) (*rsa.PrivateKey, error) {
+	if InitiatorPrivKey == "" {
+		return nil, nil
+	}
+	logger.Info("🔑 opening initiator RSA private key file")
+	privateKey, err := OpenPrivateKey(InitiatorPrivKeyPass, InitiatorPrivKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := crypto.EncodeRSAPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("🪪 initiator identity", zap.String("public key", string(pubKey)))
+	return privateKey, nil
+}
+
+// ReadAdminToken reads the bearer token of the operator admin API from path
+func ReadAdminToken(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to read admin token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("admin token file is empty")
+	}
+	return token, nil
+}
+
+// ReadTrustedInitiatorsFile reads base64 encoded RSA public keys of trusted initiators from path
+func ReadTrustedInitiatorsFile(path string) ([]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("😥 Failed to read trusted initiators file: %s", err)
+	}
+	var encoded []string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("😥 Failed to parse trusted initiators file: %s", err)
+	}
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("😥 Trusted initiators file has no public keys")
+	}
+	keys := make([]*rsa.PublicKey, 0, len(encoded))
+	for _, pk := range encoded {
+		key, err := wire.ParseRSAPublicKey([]byte(pk))
+		if err != nil {
+			return nil, fmt.Errorf("😥 Failed to parse trusted initiator public key %s: %s", pk, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 // ReadOperatorsInfoFile reads operators data from path
 func ReadOperatorsInfoFile(operatorsInfoPath string, logger *zap.Logger) (wire.OperatorsCLI, error) {
 	fmt.Printf("📖 looking operators info 'operators_info.json' file: %s \n", operatorsInfoPath)
@@ -164,13 +267,69 @@ func SetInitFlags(cmd *cobra.Command) {
 	SetBaseFlags(cmd)
 	flags.OperatorsInfoFlag(cmd)
 	flags.OperatorsInfoPathFlag(cmd)
+	flags.OperatorsInfoURLFlag(cmd)
 	flags.OperatorIDsFlag(cmd)
 	flags.OwnerAddressFlag(cmd)
 	flags.NonceFlag(cmd)
 	flags.NetworkFlag(cmd)
 	flags.WithdrawAddressFlag(cmd)
+	flags.WithdrawPubKeyFlag(cmd)
+	flags.CompoundingFlag(cmd)
+	flags.AmountFlag(cmd)
 	flags.ValidatorsFlag(cmd)
 	flags.ClientCACertPathFlag(cmd)
+	flags.InitiatorPrivateKeyFlag(cmd)
+	flags.InitiatorPrivateKeyPassFlag(cmd)
+	flags.ThresholdTolerantFlag(cmd)
+	flags.ResumeFlag(cmd)
+	flags.SignExitFlag(cmd)
+	flags.ExitEpochFlag(cmd)
+	flags.ExitValidatorIndexFlag(cmd)
+	flags.DKGPhaseTimeoutFlag(cmd)
+	flags.FetchNonceFlag(cmd)
+	flags.EthEndpointURLFlag(cmd)
+	flags.SignInitFlag(cmd)
+	flags.CeremonyIDFlag(cmd)
+	flags.SignaturesFlag(cmd)
+}
+
+func SetReshareFlags(cmd *cobra.Command) {
+	SetBaseFlags(cmd)
+	flags.OperatorsInfoFlag(cmd)
+	flags.OperatorsInfoPathFlag(cmd)
+	flags.NewOperatorIDsFlag(cmd)
+	flags.KeysharesFilePathFlag(cmd)
+	flags.ProofsFilePathFlag(cmd)
+	flags.SignaturesFlag(cmd)
+	flags.OwnerAddressFlag(cmd)
+	flags.NonceFlag(cmd)
+	flags.NetworkFlag(cmd)
+	flags.WithdrawAddressFlag(cmd)
+	flags.WithdrawPubKeyFlag(cmd)
+	flags.CompoundingFlag(cmd)
+	flags.AmountFlag(cmd)
+	flags.ClientCACertPathFlag(cmd)
+	flags.InitiatorPrivateKeyFlag(cmd)
+	flags.InitiatorPrivateKeyPassFlag(cmd)
+	flags.DKGPhaseTimeoutFlag(cmd)
+}
+
+func SetSignFlags(cmd *cobra.Command) {
+	SetBaseFlags(cmd)
+	flags.OperatorsInfoFlag(cmd)
+	flags.OperatorsInfoPathFlag(cmd)
+	flags.KeysharesFilePathFlag(cmd)
+	flags.ProofsFilePathFlag(cmd)
+	flags.SignaturesFlag(cmd)
+	flags.OwnerAddressFlag(cmd)
+	flags.NetworkFlag(cmd)
+	flags.BeaconMessageFlag(cmd)
+	flags.ValidatorIndexFlag(cmd)
+	flags.ExitEpochFlag(cmd)
+	flags.WithdrawAddressFlag(cmd)
+	flags.ClientCACertPathFlag(cmd)
+	flags.InitiatorPrivateKeyFlag(cmd)
+	flags.InitiatorPrivateKeyPassFlag(cmd)
 }
 
 func SetOperatorFlags(cmd *cobra.Command) {
@@ -181,12 +340,28 @@ func SetOperatorFlags(cmd *cobra.Command) {
 	flags.OperatorIDFlag(cmd)
 	flags.ServerTLSCertPath(cmd)
 	flags.ServerTLSKeyPath(cmd)
+	flags.EthEndpointURLFlag(cmd)
+	flags.StoreSharesFlag(cmd)
+	flags.DKGPhaseTimeoutFlag(cmd)
+	flags.TrustedInitiatorsFlag(cmd)
+	flags.RequireOwnerSignatureFlag(cmd)
+	flags.PolicyFlag(cmd)
+	flags.AdminAddressFlag(cmd)
+	flags.AdminTokenPathFlag(cmd)
+}
+
+func SetAdminFlags(cmd *cobra.Command) {
+	flags.AdminURLFlag(cmd)
+	flags.AdminTokenPathFlag(cmd)
 }
 
 func SetVerifyFlags(cmd *cobra.Command) {
 	flags.AddPersistentStringFlag(cmd, "ceremonyDir", "", "Path to the ceremony directory", true)
 	flags.AddPersistentIntFlag(cmd, "validators", 1, "Number of validators", true)
-	flags.AddPersistentStringFlag(cmd, "withdrawAddress", "", "Withdrawal address", true)
+	flags.WithdrawAddressFlag(cmd)
+	flags.WithdrawPubKeyFlag(cmd)
+	flags.CompoundingFlag(cmd)
+	flags.AmountFlag(cmd)
 	flags.AddPersistentIntFlag(cmd, "nonce", 0, "Owner nonce", true)
 	flags.AddPersistentStringFlag(cmd, "owner", "", "Owner address", true)
 }
@@ -238,9 +413,6 @@ func BindInitiatorBaseFlags(cmd *cobra.Command) error {
 	if err := BindBaseFlags(cmd); err != nil {
 		return err
 	}
-	if err := viper.BindPFlag("operatorIDs", cmd.PersistentFlags().Lookup("operatorIDs")); err != nil {
-		return err
-	}
 	if err := viper.BindPFlag("operatorsInfo", cmd.PersistentFlags().Lookup("operatorsInfo")); err != nil {
 		return err
 	}
@@ -256,9 +428,11 @@ func BindInitiatorBaseFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("clientCACertPath", cmd.PersistentFlags().Lookup("clientCACertPath")); err != nil {
 		return err
 	}
-	OperatorIDs = viper.GetStringSlice("operatorIDs")
-	if len(OperatorIDs) == 0 {
-		return fmt.Errorf("😥 Operator IDs flag cant be empty")
+	if err := viper.BindPFlag("initiatorPrivKey", cmd.PersistentFlags().Lookup("initiatorPrivKey")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("initiatorPrivKeyPassword", cmd.PersistentFlags().Lookup("initiatorPrivKeyPassword")); err != nil {
+		return err
 	}
 	OperatorsInfoPath = viper.GetString("operatorsInfoPath")
 	if strings.Contains(OperatorsInfoPath, "../") {
@@ -268,16 +442,22 @@ func BindInitiatorBaseFlags(cmd *cobra.Command) error {
 	if OperatorsInfoPath != "" && OperatorsInfo != "" {
 		return fmt.Errorf("😥 operators info can be provided either as a raw JSON string, or path to a file, not both")
 	}
-	if OperatorsInfoPath == "" && OperatorsInfo == "" {
+	if OperatorsInfoURL != "" && (OperatorsInfoPath != "" || OperatorsInfo != "") {
+		return fmt.Errorf("😥 operators info can be provided either as a raw JSON string, path to a file or URL, not several")
+	}
+	if OperatorsInfoPath == "" && OperatorsInfo == "" && OperatorsInfoURL == "" {
 		return fmt.Errorf("😥 operators info should be provided either as a raw JSON string, or path to a file")
 	}
 	owner := viper.GetString("owner")
-	if owner == "" {
+	// resumed ceremony takes owner address from its journal
+	if owner == "" && Resume == "" {
 		return fmt.Errorf("😥 Failed to get owner address flag value")
 	}
-	OwnerAddress, err = utils.HexToAddress(owner)
-	if err != nil {
-		return fmt.Errorf("😥 Failed to parse owner address: %s", err)
+	if owner != "" {
+		OwnerAddress, err = utils.HexToAddress(owner)
+		if err != nil {
+			return fmt.Errorf("😥 Failed to parse owner address: %s", err)
+		}
 	}
 	Nonce = viper.GetUint64("nonce")
 	ClientCACertPath = viper.GetStringSlice("clientCACertPath")
@@ -286,15 +466,32 @@ func BindInitiatorBaseFlags(cmd *cobra.Command) error {
 			return fmt.Errorf("😥 clientCACertPath flag should not contain traversal")
 		}
 	}
+	InitiatorPrivKey = viper.GetString("initiatorPrivKey")
+	InitiatorPrivKeyPass = viper.GetString("initiatorPrivKeyPassword")
+	if (InitiatorPrivKey == "") != (InitiatorPrivKeyPass == "") {
+		return fmt.Errorf("😥 initiatorPrivKey and initiatorPrivKeyPassword flags should be provided together")
+	}
+	if strings.Contains(InitiatorPrivKey, "../") || strings.Contains(InitiatorPrivKeyPass, "../") {
+		return fmt.Errorf("😥 initiatorPrivKey and initiatorPrivKeyPassword flags should not contain traversal")
+	}
 	return nil
 }
 
 // BindInitFlags binds flags to yaml config parameters for the initial DKG
 func BindInitFlags(cmd *cobra.Command) error {
+	if err := viper.BindPFlag("resume", cmd.PersistentFlags().Lookup("resume")); err != nil {
+		return err
+	}
+	Resume = viper.GetString("resume")
+	// operators info URL is supported by init only, sources of operators info are checked with the base flags
+	if err := viper.BindPFlag("operatorsInfoURL", cmd.PersistentFlags().Lookup("operatorsInfoURL")); err != nil {
+		return err
+	}
+	OperatorsInfoURL = viper.GetString("operatorsInfoURL")
 	if err := BindInitiatorBaseFlags(cmd); err != nil {
 		return err
 	}
-	if err := viper.BindPFlag("withdrawAddress", cmd.PersistentFlags().Lookup("withdrawAddress")); err != nil {
+	if err := viper.BindPFlag("operatorIDs", cmd.PersistentFlags().Lookup("operatorIDs")); err != nil {
 		return err
 	}
 	if err := viper.BindPFlag("network", cmd.Flags().Lookup("network")); err != nil {
@@ -303,26 +500,298 @@ func BindInitFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
 		return err
 	}
-	withdrawAddr := viper.GetString("withdrawAddress")
-	if withdrawAddr == "" {
-		return fmt.Errorf("😥 Failed to get withdrawal address flag value")
+	if err := viper.BindPFlag("thresholdTolerant", cmd.PersistentFlags().Lookup("thresholdTolerant")); err != nil {
+		return err
+	}
+	ThresholdTolerant = viper.GetBool("thresholdTolerant")
+	if err := BindDKGPhaseTimeoutFlag(cmd); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("signInit", cmd.PersistentFlags().Lookup("signInit")); err != nil {
+		return err
 	}
+	if err := viper.BindPFlag("signatures", cmd.PersistentFlags().Lookup("signatures")); err != nil {
+		return err
+	}
+	SignInit = viper.GetBool("signInit")
 	var err error
-	WithdrawAddress, err = utils.HexToAddress(withdrawAddr)
+	Signatures, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("signatures"), "0x"))
 	if err != nil {
-		return fmt.Errorf("😥 Failed to parse withdraw address: %s", err.Error())
+		return fmt.Errorf("😥 Failed to parse signatures: %s", err.Error())
+	}
+	if !SignInit && len(Signatures) != 0 {
+		return fmt.Errorf("😥 signatures of init message can be set only together with signInit")
+	}
+	if err := viper.BindPFlag("ceremonyID", cmd.PersistentFlags().Lookup("ceremonyID")); err != nil {
+		return err
+	}
+	ceremonyID := viper.GetString("ceremonyID")
+	if len(Signatures) == 0 && ceremonyID != "" {
+		return fmt.Errorf("😥 ceremony ID can be set only together with signatures of init message")
+	}
+	if len(Signatures) != 0 {
+		id, err := hex.DecodeString(strings.TrimPrefix(ceremonyID, "0x"))
+		if err != nil || len(id) != len(CeremonyID) {
+			return fmt.Errorf("😥 ceremony ID the init message was signed for should be provided with ceremonyID flag")
+		}
+		copy(CeremonyID[:], id)
+	}
+	if Resume != "" {
+		if InitiatorPrivKey == "" {
+			return fmt.Errorf("😥 ceremony can be resumed only with the initiator private key it was started with, provide it with initiatorPrivKey flag")
+		}
+		if SignInit {
+			return fmt.Errorf("😥 init message of resumed ceremony is taken from its journal, signInit can't be set")
+		}
+		if ThresholdTolerant {
+			return fmt.Errorf("😥 Threshold tolerant ceremony can't be resumed")
+		}
+		if OperatorsInfoURL != "" {
+			return fmt.Errorf("😥 operators info of resumed ceremony should be provided as a raw JSON string, or path to a file")
+		}
+		// resumed ceremony parameters are taken from its journal
+		return nil
+	}
+	OperatorIDs = viper.GetStringSlice("operatorIDs")
+	if len(OperatorIDs) == 0 {
+		return fmt.Errorf("😥 Operator IDs flag cant be empty")
+	}
+	// without an operator the remaining cluster of 3 operators can't be registered at the ssv.network
+	if ThresholdTolerant && len(OperatorIDs) < 7 {
+		return fmt.Errorf("😥 Threshold tolerant ceremony requires at least 7 operators, so at least 4 of them remain if an operator is excluded")
+	}
+	if err := bindDepositFlags(cmd); err != nil {
+		return err
 	}
 	Network = viper.GetString("network")
 	if Network == "" {
 		return fmt.Errorf("😥 Failed to get fork version flag value")
 	}
 	Validators = viper.GetUint("validators")
-	if Validators > 100 || Validators == 0 {
-		return fmt.Errorf("🚨 Amount of generated validators should be 1 to 100")
+	if Validators == 0 {
+		return fmt.Errorf("🚨 Amount of generated validators should be at least 1")
 	}
+	if err := viper.BindPFlag("fetchNonce", cmd.PersistentFlags().Lookup("fetchNonce")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("ethEndpointURL", cmd.PersistentFlags().Lookup("ethEndpointURL")); err != nil {
+		return err
+	}
+	FetchNonce = viper.GetBool("fetchNonce")
+	EthEndpointURL = viper.GetString("ethEndpointURL")
+	if FetchNonce {
+		if EthEndpointURL == "" {
+			return fmt.Errorf("😥 ethEndpointURL is required to fetch owner nonce")
+		}
+		if viper.IsSet("nonce") {
+			return fmt.Errorf("😥 nonce can't be set together with fetchNonce")
+		}
+	}
+	if err := viper.BindPFlag("signExit", cmd.PersistentFlags().Lookup("signExit")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("exitEpoch", cmd.PersistentFlags().Lookup("exitEpoch")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("exitValidatorIndex", cmd.PersistentFlags().Lookup("exitValidatorIndex")); err != nil {
+		return err
+	}
+	SignExit = viper.GetBool("signExit")
+	ExitEpoch = phase0.Epoch(viper.GetUint64("exitEpoch"))
+	ExitValidatorIndex = phase0.ValidatorIndex(viper.GetUint64("exitValidatorIndex"))
 	return nil
 }
 
+// BindReshareFlags binds flags to yaml config parameters for the resharing ceremony
+func BindReshareFlags(cmd *cobra.Command) error {
+	if err := BindInitiatorBaseFlags(cmd); err != nil {
+		return err
+	}
+	if err := BindDKGPhaseTimeoutFlag(cmd); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("newOperatorIDs", cmd.PersistentFlags().Lookup("newOperatorIDs")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("keysharesFilePath", cmd.PersistentFlags().Lookup("keysharesFilePath")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("proofsFilePath", cmd.PersistentFlags().Lookup("proofsFilePath")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("signatures", cmd.PersistentFlags().Lookup("signatures")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("network", cmd.Flags().Lookup("network")); err != nil {
+		return err
+	}
+	NewOperatorIDs = viper.GetStringSlice("newOperatorIDs")
+	if len(NewOperatorIDs) == 0 {
+		return fmt.Errorf("😥 New operator IDs flag cant be empty")
+	}
+	KeysharesFilePath = viper.GetString("keysharesFilePath")
+	if KeysharesFilePath == "" {
+		return fmt.Errorf("😥 Failed to get keyshares file path flag value")
+	}
+	if strings.Contains(KeysharesFilePath, "../") {
+		return fmt.Errorf("😥 keysharesFilePath flag should not contain traversal")
+	}
+	ProofsFilePath = viper.GetString("proofsFilePath")
+	if ProofsFilePath == "" {
+		return fmt.Errorf("😥 Failed to get proofs file path flag value")
+	}
+	if strings.Contains(ProofsFilePath, "../") {
+		return fmt.Errorf("😥 proofsFilePath flag should not contain traversal")
+	}
+	var err error
+	Signatures, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("signatures"), "0x"))
+	if err != nil {
+		return fmt.Errorf("😥 Failed to parse signatures: %s", err.Error())
+	}
+	if err := bindDepositFlags(cmd); err != nil {
+		return err
+	}
+	Network = viper.GetString("network")
+	if Network == "" {
+		return fmt.Errorf("😥 Failed to get fork version flag value")
+	}
+	return nil
+}
+
+// BindSignFlags binds flags to yaml config parameters for signing a beacon message with key shares
+func BindSignFlags(cmd *cobra.Command) error {
+	if err := BindInitiatorBaseFlags(cmd); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("keysharesFilePath", cmd.PersistentFlags().Lookup("keysharesFilePath")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("proofsFilePath", cmd.PersistentFlags().Lookup("proofsFilePath")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("signatures", cmd.PersistentFlags().Lookup("signatures")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("network", cmd.Flags().Lookup("network")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("beaconMessage", cmd.PersistentFlags().Lookup("beaconMessage")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("validatorIndex", cmd.PersistentFlags().Lookup("validatorIndex")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("exitEpoch", cmd.PersistentFlags().Lookup("exitEpoch")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("withdrawAddress", cmd.PersistentFlags().Lookup("withdrawAddress")); err != nil {
+		return err
+	}
+	KeysharesFilePath = viper.GetString("keysharesFilePath")
+	if KeysharesFilePath == "" {
+		return fmt.Errorf("😥 Failed to get keyshares file path flag value")
+	}
+	if strings.Contains(KeysharesFilePath, "../") {
+		return fmt.Errorf("😥 keysharesFilePath flag should not contain traversal")
+	}
+	ProofsFilePath = viper.GetString("proofsFilePath")
+	if ProofsFilePath == "" {
+		return fmt.Errorf("😥 Failed to get proofs file path flag value")
+	}
+	if strings.Contains(ProofsFilePath, "../") {
+		return fmt.Errorf("😥 proofsFilePath flag should not contain traversal")
+	}
+	var err error
+	Signatures, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("signatures"), "0x"))
+	if err != nil {
+		return fmt.Errorf("😥 Failed to parse signatures: %s", err.Error())
+	}
+	Network = viper.GetString("network")
+	if Network == "" {
+		return fmt.Errorf("😥 Failed to get fork version flag value")
+	}
+	BeaconMessage = viper.GetString("beaconMessage")
+	ValidatorIndex = phase0.ValidatorIndex(viper.GetUint64("validatorIndex"))
+	ExitEpoch = phase0.Epoch(viper.GetUint64("exitEpoch"))
+	switch BeaconMessage {
+	case "exit":
+	case "blsToExecutionChange":
+		withdrawAddr := viper.GetString("withdrawAddress")
+		if withdrawAddr == "" {
+			return fmt.Errorf("😥 Failed to get withdrawal address flag value")
+		}
+		WithdrawAddress, err = utils.HexToAddress(withdrawAddr)
+		if err != nil {
+			return fmt.Errorf("😥 Failed to parse withdraw address: %s", err.Error())
+		}
+	default:
+		return fmt.Errorf("😥 unsupported beacon message: %s", BeaconMessage)
+	}
+	return nil
+}
+
+// bindDepositFlags binds withdrawal address or BLS withdrawal public key, withdrawal credentials type and deposit amount flags
+func bindDepositFlags(cmd *cobra.Command) error {
+	if err := viper.BindPFlag("withdrawAddress", cmd.PersistentFlags().Lookup("withdrawAddress")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("withdrawPubKey", cmd.PersistentFlags().Lookup("withdrawPubKey")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("compounding", cmd.PersistentFlags().Lookup("compounding")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("amount", cmd.PersistentFlags().Lookup("amount")); err != nil {
+		return err
+	}
+	withdrawAddr := viper.GetString("withdrawAddress")
+	withdrawPubKey := viper.GetString("withdrawPubKey")
+	var err error
+	switch {
+	case withdrawAddr != "" && withdrawPubKey != "":
+		return fmt.Errorf("😥 Only one of withdrawal address and withdrawal public key flags should be set")
+	case withdrawPubKey != "":
+		if viper.GetBool("compounding") {
+			return fmt.Errorf("😥 Compounding withdrawal credentials require a withdrawal address")
+		}
+		WithdrawPubKey, err = hex.DecodeString(strings.TrimPrefix(withdrawPubKey, "0x"))
+		if err != nil {
+			return fmt.Errorf("😥 Failed to parse withdrawal public key: %s", err)
+		}
+		WithdrawAddress = common.Address{}
+		WithdrawalPrefix = crypto.BLSWithdrawalPrefixByte
+	case withdrawAddr != "":
+		WithdrawAddress, err = utils.HexToAddress(withdrawAddr)
+		if err != nil {
+			return fmt.Errorf("😥 Failed to parse withdraw address: %s", err)
+		}
+		WithdrawPubKey = nil
+		WithdrawalPrefix = crypto.ETH1WithdrawalPrefixByte
+		if viper.GetBool("compounding") {
+			WithdrawalPrefix = crypto.CompoundingWithdrawalPrefixByte
+		}
+	default:
+		return fmt.Errorf("😥 Failed to get withdrawal address flag value")
+	}
+	WithdrawalCredentials, err = crypto.WithdrawalCredentials(WithdrawalPrefix, Withdrawal())
+	if err != nil {
+		return fmt.Errorf("😥 Wrong withdrawal credentials: %s", err)
+	}
+	Amount = phase0.Gwei(viper.GetUint64("amount"))
+	if err := crypto.ValidateDepositAmount(WithdrawalPrefix, Amount); err != nil {
+		return fmt.Errorf("😥 Wrong deposit amount: %s", err)
+	}
+	return nil
+}
+
+// Withdrawal returns the BLS withdrawal public key for 0x00 withdrawal credentials and the withdrawal address otherwise
+func Withdrawal() []byte {
+	if WithdrawalPrefix == crypto.BLSWithdrawalPrefixByte {
+		return WithdrawPubKey
+	}
+	return WithdrawAddress.Bytes()
+}
+
 // BindOperatorFlags binds flags to yaml config parameters for the operator
 func BindOperatorFlags(cmd *cobra.Command) error {
 	if err := BindBaseFlags(cmd); err != nil {
@@ -346,6 +815,27 @@ func BindOperatorFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("serverTLSKeyPath", cmd.PersistentFlags().Lookup("serverTLSKeyPath")); err != nil {
 		return err
 	}
+	if err := viper.BindPFlag("ethEndpointURL", cmd.PersistentFlags().Lookup("ethEndpointURL")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("storeShares", cmd.PersistentFlags().Lookup("storeShares")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("trustedInitiators", cmd.PersistentFlags().Lookup("trustedInitiators")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("requireOwnerSignature", cmd.PersistentFlags().Lookup("requireOwnerSignature")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("policy", cmd.PersistentFlags().Lookup("policy")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("adminAddress", cmd.PersistentFlags().Lookup("adminAddress")); err != nil {
+		return err
+	}
+	if err := viper.BindPFlag("adminTokenPath", cmd.PersistentFlags().Lookup("adminTokenPath")); err != nil {
+		return err
+	}
 	PrivKey = viper.GetString("privKey")
 	PrivKeyPassword = viper.GetString("privKeyPassword")
 	if PrivKey == "" {
@@ -376,6 +866,37 @@ func BindOperatorFlags(cmd *cobra.Command) error {
 	if strings.Contains(ServerTLSKeyPath, "../") {
 		return fmt.Errorf("😥 serverTLSKeyPath flag should not contain traversal")
 	}
+	EthEndpointURL = viper.GetString("ethEndpointURL")
+	StoreShares = viper.GetBool("storeShares")
+	TrustedInitiators = viper.GetString("trustedInitiators")
+	if strings.Contains(TrustedInitiators, "../") {
+		return fmt.Errorf("😥 trustedInitiators flag should not contain traversal")
+	}
+	RequireOwnerSig = viper.GetBool("requireOwnerSignature")
+	if RequireOwnerSig && EthEndpointURL == "" {
+		return fmt.Errorf("😥 ethEndpointURL is required to verify owner signatures of init messages")
+	}
+	Policy = viper.GetString("policy")
+	if strings.Contains(Policy, "../") {
+		return fmt.Errorf("😥 policy flag should not contain traversal")
+	}
+	AdminAddress = viper.GetString("adminAddress")
+	AdminTokenPath = viper.GetString("adminTokenPath")
+	if strings.Contains(AdminTokenPath, "../") {
+		return fmt.Errorf("😥 adminTokenPath flag should not contain traversal")
+	}
+	return BindDKGPhaseTimeoutFlag(cmd)
+}
+
+// BindDKGPhaseTimeoutFlag binds fallback timeout of DKG protocol phases to yaml config parameter
+func BindDKGPhaseTimeoutFlag(cmd *cobra.Command) error {
+	if err := viper.BindPFlag("dkgPhaseTimeout", cmd.PersistentFlags().Lookup("dkgPhaseTimeout")); err != nil {
+		return err
+	}
+	DKGPhaseTimeout = viper.GetDuration("dkgPhaseTimeout")
+	if DKGPhaseTimeout < 0 || DKGPhaseTimeout > spec.MaxPhaseTimeout {
+		return fmt.Errorf("😥 dkgPhaseTimeout should be up to %s", spec.MaxPhaseTimeout)
+	}
 	return nil
 }
 
@@ -387,9 +908,6 @@ func BindVerifyFlags(cmd *cobra.Command) error {
 	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
 		return err
 	}
-	if err := viper.BindPFlag("withdrawAddress", cmd.PersistentFlags().Lookup("withdrawAddress")); err != nil {
-		return err
-	}
 	if err := viper.BindPFlag("nonce", cmd.PersistentFlags().Lookup("nonce")); err != nil {
 		return err
 	}
@@ -413,9 +931,8 @@ func BindVerifyFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("😥 Failed to parse owner address: %s", err)
 	}
 	Nonce = viper.GetUint64("nonce")
-	WithdrawAddress, err = utils.HexToAddress(viper.GetString("withdrawAddress"))
-	if err != nil {
-		return fmt.Errorf("😥 Failed to parse withdraw address: %s", err)
+	if err := bindDepositFlags(cmd); err != nil {
+		return err
 	}
 	Validators = viper.GetUint("validators")
 	if Validators == 0 {
@@ -456,6 +973,11 @@ func LoadOperators(logger *zap.Logger) (wire.OperatorsCLI, error) {
 		if err != nil {
 			return nil, err
 		}
+	} else if OperatorsInfoURL != "" {
+		operators, err = fetchOperatorsInfo(logger)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		operators, err = ReadOperatorsInfoFile(OperatorsInfoPath, logger)
 		if err != nil {
@@ -472,16 +994,67 @@ func LoadOperators(logger *zap.Logger) (wire.OperatorsCLI, error) {
 	return operators, nil
 }
 
+// fetchOperatorsInfo fetches info of the ceremony operators from SSV API and checks their keys against the keys pinned
+// at the output folder. Operators with a changed key are refused until they are removed from the cache.
+func fetchOperatorsInfo(logger *zap.Logger) (wire.OperatorsCLI, error) {
+	ids, err := StingSliceToUintArray(OperatorIDs)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("🌐 fetching operators info", zap.String("URL", OperatorsInfoURL))
+	operators, err := initiator.FetchOperators(context.Background(), OperatorsInfoURL, ids)
+	if err != nil {
+		return nil, fmt.Errorf("😥 Failed to fetch operators info: %s", err)
+	}
+	cachePath := filepath.Join(OutputPath, "operators_info_cache.json")
+	changed, err := initiator.PinOperators(logger, cachePath, operators)
+	if err != nil {
+		return nil, fmt.Errorf("😥 Failed to check pinned operators info: %s", err)
+	}
+	if len(changed) > 0 {
+		return nil, fmt.Errorf("😥 public keys of operators %v changed since they were pinned, remove the operators from %s if the change is expected", changed, cachePath)
+	}
+	return operators, nil
+}
+
+// LoadKeyshares reads keyshares of the previous ceremony from file
+func LoadKeyshares(keysharesFilePath string) (*wire.KeySharesCLI, error) {
+	keysharesJSON, err := os.ReadFile(filepath.Clean(keysharesFilePath))
+	if err != nil {
+		return nil, fmt.Errorf("😥 Failed to read keyshares file: %s", err)
+	}
+	var keyshares wire.KeySharesCLI
+	if err := json.Unmarshal(keysharesJSON, &keyshares); err != nil {
+		return nil, fmt.Errorf("😥 Failed to load keyshares: %s", err)
+	}
+	return &keyshares, nil
+}
+
+// LoadProofs reads proofs of the previous ceremony from file
+func LoadProofs(proofsFilePath string) ([]*wire.SignedProof, error) {
+	proofsJSON, err := os.ReadFile(filepath.Clean(proofsFilePath))
+	if err != nil {
+		return nil, fmt.Errorf("😥 Failed to read proofs file: %s", err)
+	}
+	var proofs []*wire.SignedProof
+	if err := json.Unmarshal(proofsJSON, &proofs); err != nil {
+		return nil, fmt.Errorf("😥 Failed to load proofs: %s", err)
+	}
+	return proofs, nil
+}
+
 func WriteResults(
 	logger *zap.Logger,
 	depositDataArr []*wire.DepositDataCLI,
 	keySharesArr []*wire.KeySharesCLI,
 	proofs [][]*wire.SignedProof,
+	exits map[string]*phase0.SignedVoluntaryExit,
 	withRandomness bool,
 	expectedValidatorCount int,
 	expectedOwnerAddress common.Address,
 	expectedOwnerNonce uint64,
-	expectedWithdrawAddress common.Address,
+	expectedWithdrawalCredentials []byte,
+	expectedAmount phase0.Gwei,
 	outputPath string,
 ) (err error) {
 	if expectedValidatorCount == 0 {
@@ -552,7 +1125,7 @@ func WriteResults(
 	for i := 0; i < len(keySharesArr); i++ {
 		aggregatedKeyshares.Shares = append(aggregatedKeyshares.Shares, keySharesArr[i].Shares...)
 	}
-	if err := validator.ValidateResults(depositDataArr, aggregatedKeyshares, proofs, expectedValidatorCount, expectedOwnerAddress, expectedOwnerNonce, expectedWithdrawAddress); err != nil {
+	if err := validator.ValidateResults(depositDataArr, aggregatedKeyshares, proofs, expectedValidatorCount, expectedOwnerAddress, expectedOwnerNonce, expectedWithdrawalCredentials, expectedAmount); err != nil {
 		return err
 	}
 
@@ -608,6 +1181,14 @@ func WriteResults(
 			logger.Error("Failed writing proofs file: ", zap.Error(err), zap.String("path", nestedDir), zap.Any("proof", proofs[i]))
 			return fmt.Errorf("failed writing proofs file: %w", err)
 		}
+		if exit, ok := exits[depositDataArr[i].PubKey]; ok {
+			logger.Info("💾 Writing voluntary exit to file", zap.String("path", nestedDir))
+			err = WriteVoluntaryExit(exit, depositDataArr[i].PubKey, nestedDir)
+			if err != nil {
+				logger.Error("Failed writing voluntary exit file: ", zap.Error(err), zap.String("path", nestedDir))
+				return fmt.Errorf("failed writing voluntary exit file: %w", err)
+			}
+		}
 	}
 	// if there is only one Validator, do not create summary files
 	if expectedValidatorCount > 1 {
@@ -617,7 +1198,7 @@ func WriteResults(
 		}
 	}
 
-	err = validator.ValidateResultsDir(dir, expectedValidatorCount, expectedOwnerAddress, expectedOwnerNonce, expectedWithdrawAddress)
+	err = validator.ValidateResultsDir(dir, expectedValidatorCount, expectedOwnerAddress, expectedOwnerNonce, expectedWithdrawalCredentials, expectedAmount)
 	if err != nil {
 		return fmt.Errorf("failed validating results dir: %w", err)
 	}
@@ -683,6 +1264,26 @@ func WriteProofs(proofs []*wire.SignedProof, dir string) error {
 	return nil
 }
 
+// WriteVoluntaryExit writes the pre-signed voluntary exit of the validator to exit-0x<pubkey>.json
+func WriteVoluntaryExit(exit *phase0.SignedVoluntaryExit, pubKey, dir string) error {
+	finalPath := fmt.Sprintf("%s/exit-0x%s.json", dir, pubKey)
+	err := utils.WriteJSON(finalPath, exit)
+	if err != nil {
+		return fmt.Errorf("failed writing data file: %w", err)
+	}
+	return nil
+}
+
+// WriteBLSToExecutionChange writes the signed BLS to execution change of the validator to bls_to_execution_change-0x<pubkey>.json
+func WriteBLSToExecutionChange(change *capella.SignedBLSToExecutionChange, pubKey, dir string) error {
+	finalPath := fmt.Sprintf("%s/bls_to_execution_change-0x%s.json", dir, pubKey)
+	err := utils.WriteJSON(finalPath, change)
+	if err != nil {
+		return fmt.Errorf("failed writing data file: %w", err)
+	}
+	return nil
+}
+
 func createDirIfNotExist(path string) error {
 	if _, err := os.Stat(path); err != nil {
 		if os.IsNotExist(err) {
@@ -1,6 +1,7 @@
 package verify
 
 import (
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
@@ -29,7 +30,8 @@ var Verify = &cobra.Command{
 			int(cli_utils.Validators),
 			cli_utils.OwnerAddress,
 			cli_utils.Nonce,
-			cli_utils.WithdrawAddress,
+			cli_utils.WithdrawalCredentials,
+			cli_utils.Amount,
 		)
 		if err != nil {
 			log.Printf("Failed to validate ceremony directory: %v", err)
@@ -39,10 +41,10 @@ var Verify = &cobra.Command{
 		log.Printf("Ceremony is valid.")
 
 		tbl := table.New(os.Stdout)
-		tbl.SetHeaders("Directory", "Withdrawal Address", "Nonce", "Owner Address", "Validators")
+		tbl.SetHeaders("Directory", "Withdrawal Credentials", "Nonce", "Owner Address", "Validators")
 		tbl.AddRow(
 			cli_utils.CeremonyDir,
-			cli_utils.WithdrawAddress.String(),
+			"0x"+hex.EncodeToString(cli_utils.WithdrawalCredentials),
 			fmt.Sprintf("%d", cli_utils.Nonce),
 			cli_utils.OwnerAddress.String(),
 			fmt.Sprintf("%d", cli_utils.Validators),
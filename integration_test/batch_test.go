@@ -0,0 +1,328 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestCeremonyRunBatch(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	ceremony := &initiator.Ceremony{
+		Operators:     ops,
+		Logger:        zap.L().Named("integration-tests"),
+		Version:       version,
+		ClientCACerts: rootCert,
+	}
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test batch of 3 compounding validators", func(t *testing.T) {
+		res, err := ceremony.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       3,
+			Owner:            owner,
+			Nonce:            7,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.CompoundingWithdrawalPrefixByte,
+			Amount:           64000000000,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 3)
+		for i, c := range res.Ceremonies {
+			require.Equal(t, uint64(7+i), c.Nonce)
+			require.Equal(t, uint64(7+i), c.KeyShares.Shares[0].OwnerNonce)
+			sharesDataSigned, err := hex.DecodeString(c.KeyShares.Shares[0].Payload.SharesData[2:])
+			require.NoError(t, err)
+			pubkeyraw, err := hex.DecodeString(c.KeyShares.Shares[0].Payload.PublicKey[2:])
+			require.NoError(t, err)
+			err = testSharesData(ops, 4, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, uint16(c.Nonce))
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(crypto.CompoundingWithdrawalCredentials(withdraw.Bytes())), c.DepositData.WithdrawalCredentials)
+			require.EqualValues(t, 64000000000, c.DepositData.Amount)
+			err = crypto.ValidateDepositDataCLI(c.DepositData, crypto.CompoundingWithdrawalPrefixByte, withdraw, 64000000000)
+			require.NoError(t, err)
+			err = crypto.ValidateDepositDataCLI(c.DepositData, crypto.ETH1WithdrawalPrefixByte, withdraw, 64000000000)
+			require.ErrorContains(t, err, "failed to verify withdrawal address")
+		}
+		require.Len(t, res.DepositData(), 3)
+		require.Len(t, res.KeyShares(), 3)
+		require.Len(t, res.Proofs(), 3)
+	})
+	t.Run("test BLS withdrawal credentials validator", func(t *testing.T) {
+		withdrawSK := &bls.SecretKey{}
+		withdrawSK.SetByCSPRNG()
+		withdrawPubKey := withdrawSK.GetPublicKey().Serialize()
+		res, err := ceremony.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       1,
+			Owner:            owner,
+			Nonce:            10,
+			WithdrawPubKey:   withdrawPubKey,
+			WithdrawalPrefix: crypto.BLSWithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 1)
+		c := res.Ceremonies[0]
+		require.Equal(t, crypto.BLSWithdrawalCredentials(withdrawPubKey), c.WithdrawalCredentials)
+		require.Equal(t, hex.EncodeToString(crypto.BLSWithdrawalCredentials(withdrawPubKey)), c.DepositData.WithdrawalCredentials)
+		err = crypto.ValidateDepositDataCLIBLS(c.DepositData, withdrawPubKey, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+	})
+	t.Run("test batch with pre-signed voluntary exits", func(t *testing.T) {
+		res, err := ceremony.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       2,
+			Owner:            owner,
+			Nonce:            20,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+			Exit:             &initiator.ExitRequest{Epoch: 256, ValidatorIndex: 1000},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 2)
+		require.Len(t, res.VoluntaryExits(), 2)
+		forkVersion, err := crypto.ExitForkVersion(e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		for i, c := range res.Ceremonies {
+			require.NotNil(t, c.Exit)
+			require.Equal(t, phase0.Epoch(256), c.Exit.Message.Epoch)
+			require.Equal(t, phase0.ValidatorIndex(1000+i), c.Exit.Message.ValidatorIndex)
+			validatorPK, err := hex.DecodeString(c.DepositData.PubKey)
+			require.NoError(t, err)
+			err = crypto.VerifyVoluntaryExit(validatorPK, c.Exit, forkVersion, e2m_core.HoleskyNetwork.GenesisValidatorsRoot())
+			require.NoError(t, err)
+		}
+	})
+	t.Run("test batch split between multi-validator ceremonies", func(t *testing.T) {
+		split := *ceremony
+		split.ValidatorsPerCeremony = 2
+		res, err := split.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       5,
+			Owner:            owner,
+			Nonce:            30,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+			Exit:             &initiator.ExitRequest{Epoch: 256, ValidatorIndex: 2000},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 5)
+		forkVersion, err := crypto.ExitForkVersion(e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		ids := make(map[[24]byte]int)
+		for i, c := range res.Ceremonies {
+			ids[c.ID]++
+			require.Equal(t, uint64(30+i), c.Nonce)
+			sharesDataSigned, err := hex.DecodeString(c.KeyShares.Shares[0].Payload.SharesData[2:])
+			require.NoError(t, err)
+			pubkeyraw, err := hex.DecodeString(c.KeyShares.Shares[0].Payload.PublicKey[2:])
+			require.NoError(t, err)
+			err = testSharesData(ops, 4, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, uint16(c.Nonce))
+			require.NoError(t, err)
+			require.Equal(t, phase0.ValidatorIndex(2000+i), c.Exit.Message.ValidatorIndex)
+			err = crypto.VerifyVoluntaryExit(pubkeyraw, c.Exit, forkVersion, e2m_core.HoleskyNetwork.GenesisValidatorsRoot())
+			require.NoError(t, err)
+		}
+		// validators of a ceremony share its ID
+		require.Len(t, ids, 3)
+		require.Equal(t, res.Ceremonies[0].ID, res.Ceremonies[1].ID)
+		require.Equal(t, 1, ids[res.Ceremonies[4].ID])
+	})
+	t.Run("test threshold tolerant batch creates one validator per ceremony", func(t *testing.T) {
+		tolerant := *ceremony
+		tolerant.ThresholdTolerant = true
+		res, err := tolerant.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       2,
+			Owner:            owner,
+			Nonce:            40,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 2)
+		require.NotEqual(t, res.Ceremonies[0].ID, res.Ceremonies[1].ID)
+	})
+	t.Run("test bulk ceremony of 13 operators under default timeouts", func(t *testing.T) {
+		res, err := ceremony.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133},
+			Validators:       25,
+			Owner:            owner,
+			Nonce:            50,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 25)
+		for _, c := range res.Ceremonies[1:] {
+			require.Equal(t, res.Ceremonies[0].ID, c.ID)
+		}
+	})
+	t.Run("test batch keeps results of finished ceremonies", func(t *testing.T) {
+		// the operator refuses the second ceremony of the owner, the first one finishes
+		servers[0].Srv.State.Policy = &operator.Policy{MaxCeremoniesPerOwner: 1}
+		defer func() { servers[0].Srv.State.Policy = nil }()
+		partial := *ceremony
+		partial.ValidatorsPerCeremony = 1
+		partial.Concurrency = 1
+		res, err := partial.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       2,
+			Owner:            newEthAddress(t),
+			Nonce:            60,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.Error(t, err)
+		require.Len(t, res.Ceremonies, 1)
+		require.Equal(t, uint64(60), res.Ceremonies[0].Nonce)
+		require.Len(t, res.ByCeremony(), 1)
+		require.Len(t, res.Failed, 1)
+		require.Equal(t, uint64(61), res.Failed[0].Nonce)
+		require.Equal(t, 1, res.Failed[0].Validators)
+		require.NotEqual(t, [24]byte{}, res.Failed[0].ID)
+		require.False(t, res.Failed[0].Resumable)
+		var ceremonyErr *initiator.CeremonyError
+		require.ErrorAs(t, err, &ceremonyErr)
+	})
+	t.Run("test batch with unknown operator", func(t *testing.T) {
+		_, err := ceremony.RunBatch(context.Background(), initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 45},
+			Validators:       1,
+			Owner:            owner,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.ErrorContains(t, err, "operator is not in given operator data list")
+	})
+	t.Run("test cancelled batch", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := ceremony.RunBatch(ctx, initiator.BatchRequest{
+			OperatorIDs:      []uint64{11, 22, 33, 44},
+			Validators:       2,
+			Owner:            owner,
+			WithdrawAddress:  withdraw,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.HoleskyNetwork,
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestCeremonyPreflight(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	version := "test.version"
+	servers, ops := createOperatorsByIDs(t, version, 9)
+	// operator 2 has a different public key at operators info
+	_, pubKey, err := crypto.GenerateRSAKeys()
+	require.NoError(t, err)
+	ops[1].PubKey = pubKey
+	// operator 4 supports a newer protocol only
+	servers[3].Srv.State.Versions = wire.VersionRange{Min: "v3.0.0", Max: "v3.1.0"}
+	// operator 5 is offline
+	servers[4].HttpSrv.Close()
+	ceremony := &initiator.Ceremony{
+		Operators:     ops,
+		Logger:        zap.L().Named("integration-tests"),
+		Version:       version,
+		ClientCACerts: rootCert,
+	}
+	req := initiator.BatchRequest{
+		Validators:       1,
+		Owner:            newEthAddress(t),
+		WithdrawAddress:  newEthAddress(t),
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           crypto.MaxEffectiveBalanceInGwei,
+		Network:          e2m_core.HoleskyNetwork,
+	}
+	t.Run("test preflight report", func(t *testing.T) {
+		dkgInitiator, err := initiator.New(ops, zap.L().Named("integration-tests"), version, rootCert)
+		require.NoError(t, err)
+		report := dkgInitiator.Preflight(context.Background(), []uint64{5, 4, 3, 2, 1})
+		require.Len(t, report, 5)
+		for i, res := range report {
+			require.EqualValues(t, i+1, res.ID)
+		}
+		require.NoError(t, report[0].Err)
+		require.Equal(t, version, report[0].Version)
+		require.Equal(t, wire.SupportedVersions, report[0].Versions)
+		require.ErrorContains(t, report[1].Err, "operator public key doesn't match operators info")
+		require.NoError(t, report[2].Err)
+		require.ErrorContains(t, report[3].Err, "wrong version: operator supports v3.0.0-v3.1.0")
+		require.ErrorContains(t, report[4].Err, "health check failed")
+		require.Len(t, report.Failed(), 3)
+		err = report.Err()
+		require.ErrorContains(t, err, "operator ID: 2")
+		require.ErrorContains(t, err, "operator ID: 4")
+		require.ErrorContains(t, err, "operator ID: 5")
+		require.NotContains(t, err.Error(), "operator ID: 1,")
+	})
+	t.Run("test batch aborted by preflight check", func(t *testing.T) {
+		req := req
+		req.OperatorIDs = []uint64{1, 2, 3, 6}
+		_, err := ceremony.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "preflight check failed")
+		require.ErrorContains(t, err, "operator ID: 2")
+		require.NotContains(t, err.Error(), "operator ID: 1,")
+	})
+	t.Run("test threshold tolerant batch starts with threshold operators passed preflight check", func(t *testing.T) {
+		tolerant := *ceremony
+		tolerant.ThresholdTolerant = true
+		req := req
+		req.OperatorIDs = []uint64{1, 2, 3, 6, 7, 8, 9}
+		res, err := tolerant.RunBatch(context.Background(), req)
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 1)
+		require.Equal(t, []uint64{2}, res.Ceremonies[0].ExcludedOperators)
+		require.Equal(t, []uint64{1, 3, 6, 7}, res.Ceremonies[0].KeyShares.Shares[0].Payload.OperatorIDs)
+	})
+	t.Run("test threshold tolerant batch aborted by preflight check", func(t *testing.T) {
+		tolerant := *ceremony
+		tolerant.ThresholdTolerant = true
+		req := req
+		req.OperatorIDs = []uint64{1, 2, 3, 6}
+		_, err := tolerant.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "preflight check failed")
+		require.ErrorContains(t, err, "operator ID: 2")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
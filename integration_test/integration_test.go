@@ -2,13 +2,18 @@ package integration_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/rsa"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -21,12 +26,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
 	cli_initiator "github.com/bloxapp/ssv-dkg/cli/initiator"
+	cli_utils "github.com/bloxapp/ssv-dkg/cli/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/operator"
 	"github.com/bloxapp/ssv-dkg/pkgs/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/utils/test_utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 )
@@ -49,7 +58,7 @@ func TestHappyFlows(t *testing.T) {
 	owner := newEthAddress(t)
 	t.Run("test 4 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+		depositData, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -57,12 +66,12 @@ func TestHappyFlows(t *testing.T) {
 		require.NoError(t, err)
 		err = testSharesData(ops, 4, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
 		require.NoError(t, err)
-		err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 		require.NoError(t, err)
 	})
 	t.Run("test 7 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
+		depositData, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -70,12 +79,12 @@ func TestHappyFlows(t *testing.T) {
 		require.NoError(t, err)
 		err = testSharesData(ops, 7, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey, servers[4].PrivKey, servers[5].PrivKey, servers[6].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
 		require.NoError(t, err)
-		err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 		require.NoError(t, err)
 	})
 	t.Run("test 10 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100}, "mainnet", owner, 0)
+		depositData, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -83,12 +92,12 @@ func TestHappyFlows(t *testing.T) {
 		require.NoError(t, err)
 		err = testSharesData(ops, 10, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey, servers[4].PrivKey, servers[5].PrivKey, servers[6].PrivKey, servers[7].PrivKey, servers[8].PrivKey, servers[9].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
 		require.NoError(t, err)
-		err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 		require.NoError(t, err)
 	})
 	t.Run("test 13 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133}, "mainnet", owner, 0)
+		depositData, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -96,7 +105,7 @@ func TestHappyFlows(t *testing.T) {
 		require.NoError(t, err)
 		err = testSharesData(ops, 13, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey, servers[4].PrivKey, servers[5].PrivKey, servers[6].PrivKey, servers[7].PrivKey, servers[8].PrivKey, servers[9].PrivKey, servers[10].PrivKey, servers[11].PrivKey, servers[12].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
 		require.NoError(t, err)
-		err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 		require.NoError(t, err)
 	})
 	for _, srv := range servers {
@@ -289,7 +298,7 @@ func TestThreshold(t *testing.T) {
 	owner := newEthAddress(t)
 	t.Run("test 13 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133}, "mainnet", owner, 0)
+		_, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -309,7 +318,7 @@ func TestThreshold(t *testing.T) {
 	})
 	t.Run("test 10 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100}, "mainnet", owner, 0)
+		_, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -329,7 +338,7 @@ func TestThreshold(t *testing.T) {
 	})
 	t.Run("test 7 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
+		_, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -349,7 +358,7 @@ func TestThreshold(t *testing.T) {
 	})
 	t.Run("test 4 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+		_, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -386,7 +395,7 @@ func TestUnhappyFlows(t *testing.T) {
 	withdraw := newEthAddress(t)
 	owner := newEthAddress(t)
 	id := crypto.NewID()
-	depositData, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+	depositData, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
 	require.NoError(t, err)
 	sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 	require.NoError(t, err)
@@ -394,7 +403,7 @@ func TestUnhappyFlows(t *testing.T) {
 	require.NoError(t, err)
 	err = testSharesData(ops, 4, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
 	require.NoError(t, err)
-	err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+	err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 	require.NoError(t, err)
 	marshalledKs, err := json.Marshal(ks)
 	require.NotEmpty(t, marshalledKs)
@@ -403,7 +412,7 @@ func TestUnhappyFlows(t *testing.T) {
 		withdraw := newEthAddress(t)
 		owner := newEthAddress(t)
 		id := crypto.NewID()
-		_, ks, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133}, "mainnet", owner, 0)
+		_, ks, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -422,14 +431,14 @@ func TestUnhappyFlows(t *testing.T) {
 		require.ErrorContains(t, err, "shares order is incorrect")
 	})
 	t.Run("test same ID", func(t *testing.T) {
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "got init msg for existing instance")
 	})
 	t.Run("test wrong operator IDs", func(t *testing.T) {
 		withdraw := newEthAddress(t)
 		owner := newEthAddress(t)
 		id := crypto.NewID()
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{101, 66, 77, 88}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{101, 66, 77, 88}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operator is not in given operator data list")
 	})
 	t.Run("test non 3f+1 operator set", func(t *testing.T) {
@@ -437,62 +446,62 @@ func TestUnhappyFlows(t *testing.T) {
 		owner := newEthAddress(t)
 		id := crypto.NewID()
 		// 0 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: < 4")
 		// 1 op
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: < 4")
 		// 2 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: < 4")
 		// 3 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: < 4")
 		// op with zero ID
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{0, 11, 22, 33}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{0, 11, 22, 33}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operator ID cannot be 0")
 		// 14 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133, 144}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133, 144}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: > 13")
 		// 15 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133, 144, 155}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88, 99, 100, 111, 122, 133, 144, 155}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: > 13")
 		// 5 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
 		// 6 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
 		// 8 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{11, 22, 33, 44, 55, 66, 77, 88}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77, 88}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
 		// 9 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
 		// 11 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
 		// 12 ops
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
 	})
 	t.Run("test out of order operators (i.e 3,2,4,1) ", func(t *testing.T) {
 		withdraw := newEthAddress(t)
 		owner := newEthAddress(t)
 		id := crypto.NewID()
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 22, 44, 11}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 22, 44, 11}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators not unique or not ordered")
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 22, 44, 11, 100, 111, 122}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 22, 44, 11, 100, 111, 122}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators not unique or not ordered")
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 22, 44, 11, 100, 111, 122, 99, 88, 77}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 22, 44, 11, 100, 111, 122, 99, 88, 77}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators not unique or not ordered")
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 22, 44, 11, 100, 111, 122, 99, 88, 77, 66, 55, 133}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 22, 44, 11, 100, 111, 122, 99, 88, 77, 66, 55, 133}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators not unique or not ordered")
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 33, 44, 11}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 33, 44, 11}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators ids should be unique in the list")
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 22, 44, 22, 100, 111, 122, 99, 88, 77}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 22, 44, 22, 100, 111, 122, 99, 88, 77}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators ids should be unique in the list")
-		_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{33, 22, 44, 11, 100, 111, 122, 99, 88, 77, 66, 55, 111}, "mainnet", owner, 0)
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{33, 22, 44, 11, 100, 111, 122, 99, 88, 77, 66, 55, 111}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "operators ids should be unique in the list")
 	})
 	for _, srv := range servers {
@@ -536,7 +545,7 @@ func TestLargeOperatorIDs(t *testing.T) {
 	withdraw := newEthAddress(t)
 	owner := newEthAddress(t)
 	id := crypto.NewID()
-	depositData, ks, _, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1100, 2222, 3300, 4444, 5555, 6666, 7777, 8888, 9999, 10000, 11111, 12222, 13333}, "mainnet", owner, 0)
+	depositData, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1100, 2222, 3300, 4444, 5555, 6666, 7777, 8888, 9999, 10000, 11111, 12222, 13333}, "mainnet", owner, 0)
 	require.NoError(t, err)
 	sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
 	require.NoError(t, err)
@@ -544,7 +553,7 @@ func TestLargeOperatorIDs(t *testing.T) {
 	require.NoError(t, err)
 	err = testSharesData(ops, 13, []*rsa.PrivateKey{srv1.PrivKey, srv2.PrivKey, srv3.PrivKey, srv4.PrivKey, srv5.PrivKey, srv6.PrivKey, srv7.PrivKey, srv8.PrivKey, srv9.PrivKey, srv10.PrivKey, srv11.PrivKey, srv12.PrivKey, srv13.PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
 	require.NoError(t, err)
-	err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+	err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 	require.NoError(t, err)
 	srv1.HttpSrv.Close()
 	srv2.HttpSrv.Close()
@@ -576,10 +585,11 @@ func TestWrongInitiatorVersion(t *testing.T) {
 	ops = append(ops, wire.OperatorCLI{Addr: srv4.HttpSrv.URL, ID: 4, PubKey: &srv4.PrivKey.PublicKey})
 	clnt, err := initiator.New(ops, logger, "v1.0.0", rootCert)
 	require.NoError(t, err)
+	clnt.Versions = wire.VersionRange{Min: "v3.0.0", Max: "v3.1.0"}
 	withdraw := newEthAddress(t)
 	owner := newEthAddress(t)
 	id := crypto.NewID()
-	_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
+	_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
 	require.ErrorContains(t, err, "wrong version")
 	srv1.HttpSrv.Close()
 	srv2.HttpSrv.Close()
@@ -593,6 +603,7 @@ func TestWrongOperatorVersion(t *testing.T) {
 	logger := zap.L().Named("integration-tests")
 	ops := wire.OperatorsCLI{}
 	srv1 := test_utils.CreateTestOperator(t, 1, "v1.0.0", operatorCert, operatorKey)
+	srv1.Srv.State.Versions = wire.VersionRange{Min: "v0.1.0", Max: "v0.2.0"}
 	ops = append(ops, wire.OperatorCLI{Addr: srv1.HttpSrv.URL, ID: 1, PubKey: &srv1.PrivKey.PublicKey})
 	srv2 := test_utils.CreateTestOperator(t, 2, "test.version", operatorCert, operatorKey)
 	ops = append(ops, wire.OperatorCLI{Addr: srv2.HttpSrv.URL, ID: 2, PubKey: &srv2.PrivKey.PublicKey})
@@ -605,14 +616,571 @@ func TestWrongOperatorVersion(t *testing.T) {
 	withdraw := newEthAddress(t)
 	owner := newEthAddress(t)
 	id := crypto.NewID()
-	_, _, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
-	require.ErrorContains(t, err, "wrong version")
+	_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
+	require.ErrorContains(t, err, "operator ID: 1, wrong version")
 	srv1.HttpSrv.Close()
 	srv2.HttpSrv.Close()
 	srv3.HttpSrv.Close()
 	srv4.HttpSrv.Close()
 }
 
+func TestCompatibleVersions(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	ops := wire.OperatorsCLI{}
+	// operators of different releases speaking overlapping ranges of protocol versions
+	versions := []wire.VersionRange{
+		{Min: "v1.0.0", Max: "v1.2.0"},
+		{Min: "v1.1.0", Max: "v1.3.0"},
+		{Min: "v1.0.0", Max: "v1.1.0"},
+		{Min: "v1.1.0", Max: "v1.1.0"},
+	}
+	var servers []*test_utils.TestOperator
+	for i, v := range versions {
+		id := uint64(i + 1)
+		srv := test_utils.CreateTestOperator(t, id, fmt.Sprintf("v1.%d.0", i), operatorCert, operatorKey)
+		srv.Srv.State.Versions = v
+		servers = append(servers, srv)
+		ops = append(ops, wire.OperatorCLI{Addr: srv.HttpSrv.URL, ID: id, PubKey: &srv.PrivKey.PublicKey})
+	}
+	clnt, err := initiator.New(ops, logger, "v1.4.0", rootCert)
+	require.NoError(t, err)
+	clnt.Versions = wire.VersionRange{Min: "v1.0.0", Max: "v1.4.0"}
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	_, _, _, err = clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
+	require.NoError(t, err)
+	t.Run("operator without required feature", func(t *testing.T) {
+		servers[0].Srv.State.EthClient = nil
+		operators, err := initiator.ValidatedOperatorData([]uint64{1, 2, 3, 4}, ops)
+		require.NoError(t, err)
+		_, err = clnt.SendReshareMsg(context.Background(), &wire.ReshareMessage{}, crypto.NewID(), operators)
+		require.ErrorContains(t, err, "operator ID: 1, operator doesn't support reshare")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestTrustedInitiators(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	version := "test.version"
+	// persistent initiator identity key is kept at an encrypted keystore
+	trustedKey, err := cli_utils.OpenPrivateKey("../examples/operator1/password", "../examples/operator1/encrypted_private_key.json")
+	require.NoError(t, err)
+	encTrustedKey, err := crypto.EncodeRSAPublicKey(&trustedKey.PublicKey)
+	require.NoError(t, err)
+	trustedInitiators, err := json.Marshal([]string{string(encTrustedKey)})
+	require.NoError(t, err)
+	trustedInitiatorsPath := filepath.Join(t.TempDir(), "trusted_initiators.json")
+	require.NoError(t, os.WriteFile(trustedInitiatorsPath, trustedInitiators, 0o600))
+	trusted, err := cli_utils.ReadTrustedInitiatorsFile(trustedInitiatorsPath)
+	require.NoError(t, err)
+	servers, ops := createOperatorsByIDs(t, version, 4)
+	for _, srv := range servers {
+		srv.Srv.State.TrustedInitiators = trusted
+	}
+	req := initiator.BatchRequest{
+		OperatorIDs:      []uint64{1, 2, 3, 4},
+		Validators:       1,
+		Owner:            newEthAddress(t),
+		WithdrawAddress:  newEthAddress(t),
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           crypto.MaxEffectiveBalanceInGwei,
+		Network:          "holesky",
+	}
+	t.Run("trusted initiator", func(t *testing.T) {
+		ceremony := &initiator.Ceremony{
+			Operators:     ops,
+			Logger:        zap.L().Named("integration-tests"),
+			Version:       version,
+			ClientCACerts: rootCert,
+			PrivateKey:    trustedKey,
+		}
+		res, err := ceremony.RunBatch(context.Background(), req)
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 1)
+	})
+	t.Run("untrusted initiator", func(t *testing.T) {
+		ceremony := &initiator.Ceremony{
+			Operators:     ops,
+			Logger:        zap.L().Named("integration-tests"),
+			Version:       version,
+			ClientCACerts: rootCert,
+		}
+		_, err := ceremony.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "initiator is not trusted by the operator")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestTrustedInitiatorsReshareAndSign(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	trustedKey, err := cli_utils.OpenPrivateKey("../examples/operator1/password", "../examples/operator1/encrypted_private_key.json")
+	require.NoError(t, err)
+	servers, ops := createOperators(t, version)
+	for _, srv := range servers {
+		srv.Srv.State.TrustedInitiators = []*rsa.PublicKey{&trustedKey.PublicKey}
+		srv.Srv.State.ShareStore = operator.NewShareStore(t.TempDir(), "12345678")
+	}
+	trustedClnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	trustedClnt.PrivateKey = trustedKey
+	untrustedClnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	_, ks, proofs, err := trustedClnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+	t.Run("untrusted initiator reshare", func(t *testing.T) {
+		reshare, err := untrustedClnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		_, _, _, err = untrustedClnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.ErrorContains(t, err, "initiator is not trusted by the operator")
+	})
+	t.Run("untrusted initiator sign", func(t *testing.T) {
+		msg, err := untrustedClnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, ownerSK)
+		_, err = untrustedClnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		require.ErrorContains(t, err, "initiator is not trusted by the operator")
+	})
+	t.Run("trusted initiator sign", func(t *testing.T) {
+		msg, err := trustedClnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, ownerSK)
+		_, err = trustedClnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		require.NoError(t, err)
+	})
+	t.Run("trusted initiator reshare", func(t *testing.T) {
+		reshare, err := trustedClnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		_, newKs, _, err := trustedClnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{55, 66, 77, 88}, newKs.Shares[0].Payload.OperatorIDs)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestOwnerSignedInit(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	version := "test.version"
+	servers, ops := createOperatorsByIDs(t, version, 4)
+	for _, srv := range servers {
+		srv.Srv.State.RequireOwnerSignature = true
+	}
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	req := initiator.BatchRequest{
+		OperatorIDs:      []uint64{1, 2, 3, 4},
+		Validators:       1,
+		Owner:            eth_crypto.PubkeyToAddress(ownerSK.PublicKey),
+		WithdrawAddress:  newEthAddress(t),
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           crypto.MaxEffectiveBalanceInGwei,
+		Network:          "holesky",
+	}
+	signer := func(sk *ecdsa.PrivateKey) initiator.OwnerSigner {
+		return func(id [24]byte, init *wire.Init) ([]byte, error) {
+			hash, err := spec.InitSigningRoot(id, init)
+			if err != nil {
+				return nil, err
+			}
+			return eth_crypto.Sign(hash[:], sk)
+		}
+	}
+	t.Run("init signed by the owner", func(t *testing.T) {
+		ceremony := &initiator.Ceremony{
+			Operators:     ops,
+			Logger:        zap.L().Named("integration-tests"),
+			Version:       version,
+			ClientCACerts: rootCert,
+			OwnerSigner:   signer(ownerSK),
+		}
+		inits, err := ceremony.InitMessages(req)
+		require.NoError(t, err)
+		require.Len(t, inits, 1)
+		signedReq := req
+		signedReq.ID = crypto.NewID()
+		res, err := ceremony.RunBatch(context.Background(), signedReq)
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 1)
+		require.Equal(t, signedReq.ID, res.Ceremonies[0].ID)
+	})
+	t.Run("threshold tolerant init signed by the owner", func(t *testing.T) {
+		ceremony := &initiator.Ceremony{
+			Operators:         ops,
+			Logger:            zap.L().Named("integration-tests"),
+			Version:           version,
+			ClientCACerts:     rootCert,
+			ThresholdTolerant: true,
+			OwnerSigner:       signer(ownerSK),
+		}
+		tolerantReq := req
+		tolerantReq.Nonce = 1
+		res, err := ceremony.RunBatch(context.Background(), tolerantReq)
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 1)
+		require.Empty(t, res.Ceremonies[0].ExcludedOperators)
+	})
+	t.Run("unsigned init", func(t *testing.T) {
+		ceremony := &initiator.Ceremony{
+			Operators:     ops,
+			Logger:        zap.L().Named("integration-tests"),
+			Version:       version,
+			ClientCACerts: rootCert,
+		}
+		_, err := ceremony.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "init message should be signed by the owner")
+	})
+	t.Run("init signed by another account", func(t *testing.T) {
+		otherSK, err := eth_crypto.GenerateKey()
+		require.NoError(t, err)
+		ceremony := &initiator.Ceremony{
+			Operators:     ops,
+			Logger:        zap.L().Named("integration-tests"),
+			Version:       version,
+			ClientCACerts: rootCert,
+			OwnerSigner:   signer(otherSK),
+		}
+		_, err = ceremony.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "failed to verify owner signature")
+	})
+	t.Run("init signed for another ceremony", func(t *testing.T) {
+		otherID := crypto.NewID()
+		ceremony := &initiator.Ceremony{
+			Operators:     ops,
+			Logger:        zap.L().Named("integration-tests"),
+			Version:       version,
+			ClientCACerts: rootCert,
+			OwnerSigner: func(id [24]byte, init *wire.Init) ([]byte, error) {
+				return signer(ownerSK)(otherID, init)
+			},
+		}
+		_, err = ceremony.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "failed to verify owner signature")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestOperatorPolicy(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	version := "test.version"
+	servers, ops := createOperatorsByIDs(t, version, 4)
+	owner := newEthAddress(t)
+	servers[0].Srv.State.Policy = &operator.Policy{
+		Owners:           []common.Address{owner},
+		DeniedOperators:  []uint64{5},
+		AllowedOperators: []uint64{2, 3, 4},
+	}
+	servers[1].Srv.State.Policy = &operator.Policy{MaxCeremoniesPerOwner: 1}
+	ceremony := &initiator.Ceremony{
+		Operators:     ops,
+		Logger:        zap.L().Named("integration-tests"),
+		Version:       version,
+		ClientCACerts: rootCert,
+	}
+	req := initiator.BatchRequest{
+		OperatorIDs:      []uint64{1, 2, 3, 4},
+		Validators:       1,
+		Owner:            owner,
+		WithdrawAddress:  newEthAddress(t),
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           crypto.MaxEffectiveBalanceInGwei,
+		Network:          "holesky",
+	}
+	t.Run("allowed ceremony", func(t *testing.T) {
+		res, err := ceremony.RunBatch(context.Background(), req)
+		require.NoError(t, err)
+		require.Len(t, res.Ceremonies, 1)
+	})
+	t.Run("owner above the limit", func(t *testing.T) {
+		req := req
+		req.Nonce = 1
+		_, err := ceremony.RunBatch(context.Background(), req)
+		policyErr := wire.ParsePolicyError(err)
+		require.NotNil(t, policyErr, err)
+		require.Equal(t, wire.PolicyRuleOwnerRateLimit, policyErr.Rule)
+	})
+	t.Run("owner not allowed", func(t *testing.T) {
+		req := req
+		req.Owner = newEthAddress(t)
+		_, err := ceremony.RunBatch(context.Background(), req)
+		policyErr := wire.ParsePolicyError(err)
+		require.NotNil(t, policyErr, err)
+		require.Equal(t, wire.PolicyRuleOwner, policyErr.Rule)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestApprovalQueue(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	version := "test.version"
+	servers, ops := createOperatorsByIDs(t, version, 4)
+	servers[0].Srv.State.Policy = &operator.Policy{RequireApproval: &operator.ApprovalRule{All: true}}
+	adminSrv := httptest.NewServer(operator.NewAdminRouter(zap.L().Named("admin"), servers[0].Srv.State, "admin-token"))
+	defer adminSrv.Close()
+	admin := operator.NewAdminClient(adminSrv.URL, "admin-token")
+	ceremony := &initiator.Ceremony{
+		Operators:       ops,
+		Logger:          zap.L().Named("integration-tests"),
+		Version:         version,
+		ClientCACerts:   rootCert,
+		ApprovalTimeout: time.Minute,
+	}
+	req := initiator.BatchRequest{
+		OperatorIDs:      []uint64{1, 2, 3, 4},
+		Validators:       1,
+		Owner:            newEthAddress(t),
+		WithdrawAddress:  newEthAddress(t),
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           crypto.MaxEffectiveBalanceInGwei,
+		Network:          "holesky",
+	}
+	// waitPending waits for a ceremony of the nonce in the approval queue of the first operator
+	waitPending := func(t *testing.T, nonce uint64) [24]byte {
+		var id [24]byte
+		require.Eventually(t, func() bool {
+			pending, err := admin.PendingCeremonies(context.Background())
+			if err != nil {
+				return false
+			}
+			for _, p := range pending {
+				if p.Nonce == nonce && p.Status == operator.ApprovalPending {
+					b, err := hex.DecodeString(p.ID)
+					copy(id[:], b)
+					return err == nil
+				}
+			}
+			return false
+		}, 30*time.Second, 100*time.Millisecond)
+		return id
+	}
+	t.Run("approved ceremony", func(t *testing.T) {
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := ceremony.RunBatch(context.Background(), req)
+			errCh <- err
+		}()
+		require.NoError(t, admin.Approve(context.Background(), waitPending(t, 0)))
+		require.NoError(t, <-errCh)
+	})
+	t.Run("rejected ceremony", func(t *testing.T) {
+		req := req
+		req.Nonce = 1
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := ceremony.RunBatch(context.Background(), req)
+			errCh <- err
+		}()
+		require.NoError(t, admin.Reject(context.Background(), waitPending(t, 1)))
+		err := <-errCh
+		policyErr := wire.ParsePolicyError(err)
+		require.NotNil(t, policyErr, err)
+		require.Equal(t, wire.PolicyRuleApproval, policyErr.Rule)
+	})
+	t.Run("approval timeout longer than operators keep the ceremony", func(t *testing.T) {
+		long := *ceremony
+		long.ApprovalTimeout = 5 * time.Minute
+		_, err := long.RunBatch(context.Background(), req)
+		require.ErrorContains(t, err, "approval timeout 5m0s should be positive and not longer than 4m0s")
+	})
+	t.Run("unauthorized admin request", func(t *testing.T) {
+		_, err := operator.NewAdminClient(adminSrv.URL, "wrong-token").PendingCeremonies(context.Background())
+		require.ErrorContains(t, err, "unauthorized")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestReshareAndSignPolicy(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	for _, srv := range servers[:4] {
+		srv.Srv.State.ShareStore = operator.NewShareStore(t.TempDir(), "12345678")
+	}
+	adminSrv := httptest.NewServer(operator.NewAdminRouter(zap.L().Named("admin"), servers[0].Srv.State, "admin-token"))
+	defer adminSrv.Close()
+	admin := operator.NewAdminClient(adminSrv.URL, "admin-token")
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	clnt.ApprovalTimeout = time.Minute
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+	// waitPending waits for a request of the type in the approval queue of the first operator
+	waitPending := func(t *testing.T, requestType string) [24]byte {
+		var id [24]byte
+		require.Eventually(t, func() bool {
+			pending, err := admin.PendingCeremonies(context.Background())
+			if err != nil {
+				return false
+			}
+			for _, p := range pending {
+				if p.Type == requestType && p.Status == operator.ApprovalPending {
+					b, err := hex.DecodeString(p.ID)
+					copy(id[:], b)
+					return err == nil
+				}
+			}
+			return false
+		}, 30*time.Second, 100*time.Millisecond)
+		return id
+	}
+	t.Run("reshare refused by the policy", func(t *testing.T) {
+		servers[4].Srv.State.Policy = &operator.Policy{DeniedOperators: []uint64{11}}
+		defer func() { servers[4].Srv.State.Policy = nil }()
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		_, _, _, err = clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		policyErr := wire.ParsePolicyError(err)
+		require.NotNil(t, policyErr, err)
+		require.Equal(t, wire.PolicyRuleOperators, policyErr.Rule)
+	})
+	t.Run("sign refused by the policy", func(t *testing.T) {
+		for _, srv := range servers[:2] {
+			srv.Srv.State.Policy = &operator.Policy{Owners: []common.Address{newEthAddress(t)}}
+		}
+		defer func() {
+			for _, srv := range servers[:2] {
+				srv.Srv.State.Policy = nil
+			}
+		}()
+		msg, err := clnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, ownerSK)
+		_, err = clnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		policyErr := wire.ParsePolicyError(err)
+		require.NotNil(t, policyErr, err)
+		require.Equal(t, wire.PolicyRuleOwner, policyErr.Rule)
+	})
+	servers[0].Srv.State.Policy = &operator.Policy{RequireApproval: &operator.ApprovalRule{All: true}}
+	t.Run("approved sign", func(t *testing.T) {
+		msg, err := clnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, ownerSK)
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := clnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+			errCh <- err
+		}()
+		require.NoError(t, admin.Approve(context.Background(), waitPending(t, "sign")))
+		require.NoError(t, <-errCh)
+	})
+	t.Run("approved reshare", func(t *testing.T) {
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		errCh := make(chan error, 1)
+		go func() {
+			_, _, _, err := clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+			errCh <- err
+		}()
+		require.NoError(t, admin.Approve(context.Background(), waitPending(t, "reshare")))
+		require.NoError(t, <-errCh)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestStoreShares(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	for _, srv := range servers[:4] {
+		srv.Srv.State.ShareStore = operator.NewShareStore(t.TempDir(), "12345678")
+	}
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	id := crypto.NewID()
+	depositData, _, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 5)
+	require.NoError(t, err)
+	validatorPubKey, err := hex.DecodeString(depositData.PubKey)
+	require.NoError(t, err)
+	for i, srv := range servers[:4] {
+		ks, share, err := srv.Srv.State.ShareStore.Load(validatorPubKey, id)
+		require.NoError(t, err)
+		require.Equal(t, proofs[i].Proof.SharePubKey, share.GetPublicKey().Serialize())
+		require.Equal(t, depositData.PubKey, ks.ValidatorPubKey)
+		require.Equal(t, hex.EncodeToString(id[:]), ks.RequestID)
+		require.Equal(t, owner.Hex(), ks.Owner)
+		require.Equal(t, uint64(5), ks.Nonce)
+	}
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestDKGPhaseTimeout(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	// phases move on as soon as all messages are received, the fallback timeout isn't reached
+	for _, srv := range servers {
+		srv.Srv.State.PhaseTimeout = time.Hour
+	}
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test operator default phase timeout", func(t *testing.T) {
+		depositData, _, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+		require.NoError(t, err)
+		require.NoError(t, crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei))
+	})
+	t.Run("test phase timeout requested by initiator", func(t *testing.T) {
+		clnt.DKGPhaseTimeout = 5 * time.Second
+		depositData, _, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 1)
+		require.NoError(t, err)
+		require.NoError(t, crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei))
+	})
+	t.Run("test too long phase timeout", func(t *testing.T) {
+		clnt.DKGPhaseTimeout = 2 * time.Minute
+		_, _, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 2)
+		require.ErrorContains(t, err, "phase timeout 120000ms exceeds maximum 1m0s")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
 func testSharesData(ops wire.OperatorsCLI, operatorCount int, keys []*rsa.PrivateKey, sharesData, validatorPublicKey []byte, owner common.Address, nonce uint16) error {
 	signatureOffset := phase0.SignatureLength
 	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
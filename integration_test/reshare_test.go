@@ -0,0 +1,382 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	cli_initiator "github.com/bloxapp/ssv-dkg/cli/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils/test_utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec/testing/stubs"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestReshareHappyFlow(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	id := crypto.NewID()
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	t.Run("test reshare 4 old operators to 4 new operators", func(t *testing.T) {
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		id := crypto.NewID()
+		depositData, newKs, _, err := clnt.StartReshare(context.Background(), id, reshare)
+		require.NoError(t, err)
+		require.Equal(t, ks.Shares[0].Payload.PublicKey, newKs.Shares[0].Payload.PublicKey)
+		require.Equal(t, []uint64{55, 66, 77, 88}, newKs.Shares[0].Payload.OperatorIDs)
+		sharesDataSigned, err := hex.DecodeString(newKs.Shares[0].Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(newKs.Shares[0].Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 4, []*rsa.PrivateKey{servers[4].PrivKey, servers[5].PrivKey, servers[6].PrivKey, servers[7].PrivKey}, sharesDataSigned, pubkeyraw, owner, 1)
+		require.NoError(t, err)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+	})
+	t.Run("test reshare to wrong operators set", func(t *testing.T) {
+		_, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88, 99}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.ErrorContains(t, err, "amount of operators should be 4,7,10,13")
+		_, err = clnt.ConstructReshareMessage([]uint64{11, 22, 33, 44}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.ErrorContains(t, err, "new operators should differ from old operators")
+	})
+	t.Run("test reshare with wrong proofs", func(t *testing.T) {
+		_, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, []*wire.SignedProof{proofs[1], proofs[0], proofs[2], proofs[3]}, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.ErrorContains(t, err, "proof of operator 11 is invalid")
+	})
+	t.Run("test reshare without owner signature", func(t *testing.T) {
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		_, _, _, err = clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.ErrorContains(t, err, "reshare message should be signed by the owner")
+	})
+	t.Run("test reshare signed not by the owner", func(t *testing.T) {
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		sk, err := eth_crypto.GenerateKey()
+		require.NoError(t, err)
+		signReshare(t, reshare, sk)
+		_, _, _, err = clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.ErrorContains(t, err, "failed to verify owner signature: invalid signed reshare signature")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestReshareResize(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperatorsByIDs(t, version, 20)
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	id := crypto.NewID()
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "holesky", owner, 0)
+	require.NoError(t, err)
+	validatorPK := ks.Shares[0].Payload.PublicKey
+	// each step reshares the result of the previous one
+	steps := []struct {
+		name   string
+		newOps []uint64
+		newT   uint64
+	}{
+		{name: "test reshare 4 operators to 7 operators", newOps: []uint64{5, 6, 7, 8, 9, 10, 11}, newT: 5},
+		{name: "test reshare 7 operators to 13 operators", newOps: []uint64{1, 2, 3, 4, 12, 13, 14, 15, 16, 17, 18, 19, 20}, newT: 9},
+		{name: "test reshare 13 operators to 4 operators", newOps: []uint64{5, 6, 7, 8}, newT: 3},
+	}
+	for i, step := range steps {
+		nonce := uint64(i + 1)
+		t.Run(step.name, func(t *testing.T) {
+			reshare, err := clnt.ConstructReshareMessage(step.newOps, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", nonce)
+			require.NoError(t, err)
+			require.Equal(t, step.newT, reshare.SignedReshare.Reshare.NewT)
+			signReshare(t, reshare, ownerSK)
+			depositData, newKs, newProofs, err := clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+			require.NoError(t, err)
+			require.Equal(t, validatorPK, newKs.Shares[0].Payload.PublicKey)
+			require.Equal(t, step.newOps, newKs.Shares[0].Payload.OperatorIDs)
+			require.Len(t, newProofs, len(step.newOps))
+			sharesDataSigned, err := hex.DecodeString(newKs.Shares[0].Payload.SharesData[2:])
+			require.NoError(t, err)
+			pubkeyraw, err := hex.DecodeString(newKs.Shares[0].Payload.PublicKey[2:])
+			require.NoError(t, err)
+			keys := make([]*rsa.PrivateKey, len(step.newOps))
+			for j, opID := range step.newOps {
+				keys[j] = servers[opID-1].PrivKey
+			}
+			err = testSharesData(ops, len(step.newOps), keys, sharesDataSigned, pubkeyraw, owner, uint16(nonce))
+			require.NoError(t, err)
+			err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+			require.NoError(t, err)
+			ks, proofs = newKs, newProofs
+		})
+	}
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestReshareReplaceOperator(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	id := crypto.NewID()
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	validatorPK := ks.Shares[0].Payload.PublicKey
+	// each step reshares the result of the previous one
+	steps := []struct {
+		name   string
+		newOps []uint64
+		keys   []*rsa.PrivateKey
+	}{
+		{
+			name:   "test replace operator 33 with operator 55",
+			newOps: []uint64{11, 22, 44, 55},
+			keys:   []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[3].PrivKey, servers[4].PrivKey},
+		},
+		{
+			name:   "test extend 4 operators cluster to 7 operators",
+			newOps: []uint64{11, 22, 44, 55, 66, 77, 88},
+			keys:   []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[3].PrivKey, servers[4].PrivKey, servers[5].PrivKey, servers[6].PrivKey, servers[7].PrivKey},
+		},
+		{
+			name:   "test shrink 7 operators cluster to 4 operators",
+			newOps: []uint64{22, 55, 77, 99},
+			keys:   []*rsa.PrivateKey{servers[1].PrivKey, servers[4].PrivKey, servers[6].PrivKey, servers[8].PrivKey},
+		},
+	}
+	for i, step := range steps {
+		nonce := uint64(i + 1)
+		t.Run(step.name, func(t *testing.T) {
+			reshare, err := clnt.ConstructReshareMessage(step.newOps, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", nonce)
+			require.NoError(t, err)
+			signReshare(t, reshare, ownerSK)
+			depositData, newKs, newProofs, err := clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+			require.NoError(t, err)
+			require.Equal(t, validatorPK, newKs.Shares[0].Payload.PublicKey)
+			require.Equal(t, step.newOps, newKs.Shares[0].Payload.OperatorIDs)
+			sharesDataSigned, err := hex.DecodeString(newKs.Shares[0].Payload.SharesData[2:])
+			require.NoError(t, err)
+			pubkeyraw, err := hex.DecodeString(newKs.Shares[0].Payload.PublicKey[2:])
+			require.NoError(t, err)
+			err = testSharesData(ops, len(step.newOps), step.keys, sharesDataSigned, pubkeyraw, owner, uint16(nonce))
+			require.NoError(t, err)
+			err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+			require.NoError(t, err)
+			ks, proofs = newKs, newProofs
+		})
+	}
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestReshareReplaceOfflineOperator(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	id := crypto.NewID()
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	validatorPK := ks.Shares[0].Payload.PublicKey
+	// operator 33 is offline, staying operators 11, 22, 44 are enough to deal
+	servers[2].HttpSrv.Close()
+	t.Run("test replace offline operator 33 with operator 55", func(t *testing.T) {
+		newOps := []uint64{11, 22, 44, 55}
+		reshare, err := clnt.ConstructReshareMessage(newOps, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		depositData, newKs, _, err := clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.NoError(t, err)
+		require.Equal(t, validatorPK, newKs.Shares[0].Payload.PublicKey)
+		require.Equal(t, newOps, newKs.Shares[0].Payload.OperatorIDs)
+		sharesDataSigned, err := hex.DecodeString(newKs.Shares[0].Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(newKs.Shares[0].Payload.PublicKey[2:])
+		require.NoError(t, err)
+		keys := []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[3].PrivKey, servers[4].PrivKey}
+		err = testSharesData(ops, len(newOps), keys, sharesDataSigned, pubkeyraw, owner, 1)
+		require.NoError(t, err)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+	})
+	t.Run("test reshare needing offline operator fails", func(t *testing.T) {
+		// only operators 11 and 22 stay, so all old operators have to deal
+		reshare, err := clnt.ConstructReshareMessage([]uint64{11, 22, 55, 66}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, ownerSK)
+		_, _, _, err = clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.ErrorContains(t, err, "operator ID: 33")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestReshareSafeOwner(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	// validator is owned by 2 out of 3 Safe multisig
+	var safeOwners []*ecdsa.PrivateKey
+	safe := &stubs.Safe{Threshold: 2}
+	for i := 0; i < 3; i++ {
+		sk, err := eth_crypto.GenerateKey()
+		require.NoError(t, err)
+		safeOwners = append(safeOwners, sk)
+		safe.Owners = append(safe.Owners, eth_crypto.PubkeyToAddress(sk.PublicKey))
+	}
+	owner := newEthAddress(t)
+	ethClient := stubs.NewSafeClient(map[common.Address]*stubs.Safe{owner: safe})
+	for _, srv := range servers {
+		srv.Srv.State.EthClient = ethClient
+	}
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	id := crypto.NewID()
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	t.Run("test reshare signed by not enough Safe owners", func(t *testing.T) {
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, safeOwners[0])
+		_, _, _, err = clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.ErrorContains(t, err, "failed to verify owner signature: signature invalid")
+	})
+	t.Run("test reshare signed by Safe owners", func(t *testing.T) {
+		reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "holesky", 1)
+		require.NoError(t, err)
+		signReshare(t, reshare, safeOwners[0], safeOwners[2])
+		depositData, newKs, _, err := clnt.StartReshare(context.Background(), crypto.NewID(), reshare)
+		require.NoError(t, err)
+		require.Equal(t, ks.Shares[0].Payload.PublicKey, newKs.Shares[0].Payload.PublicKey)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestReshareCLIHappyFlow(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	operators, err := json.Marshal(ops)
+	require.NoError(t, err)
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := common.HexToAddress("0x81592c3de184a3e2c0dcb5a261bc107bfa91f494")
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	id := crypto.NewID()
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	keysharesPath := filepath.Join(dir, "keyshares.json")
+	require.NoError(t, utils.WriteJSON(keysharesPath, ks))
+	proofsPath := filepath.Join(dir, "proofs.json")
+	require.NoError(t, utils.WriteJSON(proofsPath, proofs))
+	// owner signs the same reshare message the CLI constructs from the flags below
+	reshare, err := clnt.ConstructReshareMessage([]uint64{55, 66, 77, 88}, ks, proofs, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, "mainnet", 1)
+	require.NoError(t, err)
+	signReshare(t, reshare, ownerSK)
+	RootCmd := &cobra.Command{
+		Use:   "ssv-dkg",
+		Short: "CLI for running Distributed Key Generation protocol",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		},
+	}
+	RootCmd.AddCommand(cli_initiator.StartReshare)
+	RootCmd.Short = "ssv-dkg-test"
+	RootCmd.Version = version
+	cli_initiator.StartReshare.Version = version
+	t.Run("test reshare 4 old operators to 4 new operators", func(t *testing.T) {
+		args := []string{"reshare", "--operatorsInfo", string(operators), "--owner", owner.Hex(), "--withdrawAddress", withdraw.Hex(), "--newOperatorIDs", "55,66,77,88", "--keysharesFilePath", keysharesPath, "--proofsFilePath", proofsPath, "--nonce", "1", "--signatures", hex.EncodeToString(reshare.SignedReshare.Signature), "--clientCACertPath", "./certs/rootCA.crt"}
+		RootCmd.SetArgs(args)
+		err := RootCmd.Execute()
+		require.NoError(t, err)
+		resetFlags(RootCmd)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+// signReshare signs reshare message hash tree root by an EOA owner or by Safe owners if several keys provided
+func signReshare(t *testing.T, reshare *wire.ReshareMessage, keys ...*ecdsa.PrivateKey) {
+	hash, err := reshare.SignedReshare.Reshare.HashTreeRoot()
+	require.NoError(t, err)
+	if len(keys) == 1 {
+		reshare.SignedReshare.Signature, err = eth_crypto.Sign(hash[:], keys[0])
+		require.NoError(t, err)
+		return
+	}
+	reshare.SignedReshare.Signature, err = stubs.SignSafe(hash[:], keys...)
+	require.NoError(t, err)
+}
+
+// createOperatorsByIDs creates operators with IDs from 1 to count
+func createOperatorsByIDs(t *testing.T, version string, count uint64) ([]*test_utils.TestOperator, wire.OperatorsCLI) {
+	var servers []*test_utils.TestOperator
+	ops := wire.OperatorsCLI{}
+	for id := uint64(1); id <= count; id++ {
+		srv := test_utils.CreateTestOperator(t, id, version, operatorCert, operatorKey)
+		ops = append(ops, wire.OperatorCLI{Addr: srv.HttpSrv.URL, ID: id, PubKey: &srv.PrivKey.PublicKey})
+		servers = append(servers, srv)
+	}
+	return servers, ops
+}
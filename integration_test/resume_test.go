@@ -0,0 +1,123 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestResumeDKG(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	journals := initiator.NewJournalStore(t.TempDir())
+	id := crypto.NewID()
+	var initiatorKey *rsa.PrivateKey
+	t.Run("test failed ceremony journaled", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.Journal = journals
+		initiatorKey = clnt.PrivateKey
+		clnt.Retries = 0
+		// deals of operator 33 are lost, the ceremony fails at phase 2
+		clnt.VerifyMessageSignature = func(pub *rsa.PublicKey, msg, sig []byte) error {
+			ts := &wire.Transport{}
+			if pub.Equal(&servers[2].PrivKey.PublicKey) && ts.UnmarshalSSZ(msg) == nil && ts.Type == wire.KyberMessageType {
+				return fmt.Errorf("lost deal")
+			}
+			return crypto.VerifyRSA(pub, msg, sig)
+		}
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+		require.ErrorContains(t, err, "operator ID: 33")
+		j, err := journals.Load(id)
+		require.NoError(t, err)
+		require.Len(t, j.Exchanges, 4)
+		require.Len(t, j.Deals, 3)
+		require.NotContains(t, j.Deals, uint64(33))
+		require.Empty(t, j.Results)
+	})
+	t.Run("test ceremony can't be resumed with another initiator key", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.Journal = journals
+		_, _, _, err = clnt.ResumeDKG(context.Background(), id)
+		require.ErrorContains(t, err, "started by another initiator key")
+		// journal is kept for the resume with the right key
+		_, err = journals.Load(id)
+		require.NoError(t, err)
+	})
+	t.Run("test ceremony resumed from the journal", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.Journal = journals
+		clnt.PrivateKey = initiatorKey
+		depositData, ks, _, err := clnt.ResumeDKG(context.Background(), id)
+		require.NoError(t, err)
+		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Shares[0].Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 4, []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
+		require.NoError(t, err)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+		// journal of a finished ceremony is removed
+		_, err = journals.Load(id)
+		require.Error(t, err)
+	})
+	t.Run("test unknown ceremony can't be resumed", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.Journal = journals
+		_, _, _, err = clnt.ResumeDKG(context.Background(), crypto.NewID())
+		require.ErrorContains(t, err, "failed to load ceremony journal")
+	})
+	t.Run("test ceremony resumed after lost init response", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.Journal = journals
+		clnt.Retries = 0
+		initiatorKey := clnt.PrivateKey
+		id := crypto.NewID()
+		// response of operator 44 to the init message is lost after the operator created the instance
+		clnt.VerifyMessageSignature = func(pub *rsa.PublicKey, msg, sig []byte) error {
+			ts := &wire.Transport{}
+			if pub.Equal(&servers[3].PrivKey.PublicKey) && ts.UnmarshalSSZ(msg) == nil && ts.Type == wire.ExchangeMessageType {
+				return fmt.Errorf("lost init response")
+			}
+			return crypto.VerifyRSA(pub, msg, sig)
+		}
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+		require.ErrorContains(t, err, "operator ID: 44")
+		j, err := journals.Load(id)
+		require.NoError(t, err)
+		require.Len(t, j.Exchanges, 3)
+		require.NotContains(t, j.Exchanges, uint64(44))
+
+		clnt, err = initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.Journal = journals
+		clnt.PrivateKey = initiatorKey
+		depositData, _, _, err := clnt.ResumeDKG(context.Background(), id)
+		require.NoError(t, err)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestSignBeaconMessage(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	for _, srv := range servers[:4] {
+		srv.Srv.State.ShareStore = operator.NewShareStore(t.TempDir(), "12345678")
+	}
+	clnt, err := initiator.New(ops, logger, version, rootCert)
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	ownerSK, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := eth_crypto.PubkeyToAddress(ownerSK.PublicKey)
+	_, ks, proofs, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "holesky", owner, 0)
+	require.NoError(t, err)
+	validatorPK, err := hex.DecodeString(ks.Shares[0].Payload.PublicKey[2:])
+	require.NoError(t, err)
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+	forkVersion, err := crypto.ExitForkVersion(e2m_core.HoleskyNetwork)
+	require.NoError(t, err)
+	t.Run("test sign voluntary exit", func(t *testing.T) {
+		msg, err := clnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, ownerSK)
+		sig, err := clnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		require.NoError(t, err)
+		err = crypto.VerifyVoluntaryExit(validatorPK, &phase0.SignedVoluntaryExit{Message: exit, Signature: sig}, forkVersion, e2m_core.HoleskyNetwork.GenesisValidatorsRoot())
+		require.NoError(t, err)
+	})
+	t.Run("test sign with threshold of operators", func(t *testing.T) {
+		servers[3].HttpSrv.Close()
+		msg, err := clnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, ownerSK)
+		sig, err := clnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		require.NoError(t, err)
+		err = crypto.VerifyVoluntaryExit(validatorPK, &phase0.SignedVoluntaryExit{Message: exit, Signature: sig}, forkVersion, e2m_core.HoleskyNetwork.GenesisValidatorsRoot())
+		require.NoError(t, err)
+	})
+	t.Run("test sign request without owner signature", func(t *testing.T) {
+		msg, err := clnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		_, err = clnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		require.ErrorContains(t, err, "sign request should be signed by the owner")
+	})
+	t.Run("test sign request signed not by the owner", func(t *testing.T) {
+		msg, err := clnt.ConstructBlsSignMessage(ks, proofs, exit, e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		sk, err := eth_crypto.GenerateKey()
+		require.NoError(t, err)
+		signBlsSignRequest(t, msg, sk)
+		_, err = clnt.SignBeaconMessage(context.Background(), crypto.NewID(), msg)
+		require.ErrorContains(t, err, "failed to verify owner signature: invalid signed BLS sign request signature")
+	})
+	t.Run("test sign with wrong proofs", func(t *testing.T) {
+		_, err := clnt.ConstructBlsSignMessage(ks, []*wire.SignedProof{proofs[1], proofs[0], proofs[2], proofs[3]}, exit, e2m_core.HoleskyNetwork)
+		require.ErrorContains(t, err, "proof of operator 11 is invalid")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
+
+func signBlsSignRequest(t *testing.T, msg *wire.BlsSignMessage, sk *ecdsa.PrivateKey) {
+	hash, err := msg.SignedRequest.Request.HashTreeRoot()
+	require.NoError(t, err)
+	msg.SignedRequest.Signature, err = eth_crypto.Sign(hash[:], sk)
+	require.NoError(t, err)
+}
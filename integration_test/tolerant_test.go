@@ -0,0 +1,113 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestThresholdTolerant(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("integration-tests")
+	version := "test.version"
+	servers, ops := createOperators(t, version)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	// operator 77 is offline
+	servers[6].HttpSrv.Close()
+	t.Run("test offline operator fails the ceremony", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		id := crypto.NewID()
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
+		require.ErrorContains(t, err, "operator ID: 77")
+	})
+	t.Run("test offline operator excluded", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.ThresholdTolerant = true
+		id := crypto.NewID()
+		depositData, ks, proofs, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{77}, clnt.ExcludedOperators)
+		// key shares of the remaining 6 operators are reshared to the first 4 of them
+		require.Equal(t, []uint64{11, 22, 33, 44}, ks.Shares[0].Payload.OperatorIDs)
+		require.Len(t, proofs, 4)
+		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Shares[0].Payload.PublicKey[2:])
+		require.NoError(t, err)
+		priviteKeys := []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[2].PrivKey, servers[3].PrivKey}
+		err = testSharesData(ops, 4, priviteKeys, sharesDataSigned, pubkeyraw, owner, 0)
+		require.NoError(t, err)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+	})
+	t.Run("test dealer excluded after exchange", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.ThresholdTolerant = true
+		// deals of operator 33 are rejected, so other operators complain about the missing dealer
+		clnt.VerifyMessageSignature = func(pub *rsa.PublicKey, msg, sig []byte) error {
+			ts := &wire.Transport{}
+			if pub.Equal(&servers[2].PrivKey.PublicKey) && ts.UnmarshalSSZ(msg) == nil && ts.Type == wire.KyberMessageType {
+				return fmt.Errorf("rejected deal")
+			}
+			return crypto.VerifyRSA(pub, msg, sig)
+		}
+		id := crypto.NewID()
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44}, "mainnet", owner, 0)
+		// remaining operators agree on the qualified dealers and finish DKG, but 3 operators can't be registered as a cluster
+		require.ErrorContains(t, err, "keyshares of the remaining 3 operators can't be registered")
+		require.Equal(t, []uint64{33}, clnt.ExcludedOperators)
+	})
+	t.Run("test dealer excluded after exchange reshared", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.ThresholdTolerant = true
+		// deals of operator 33 are rejected
+		clnt.VerifyMessageSignature = func(pub *rsa.PublicKey, msg, sig []byte) error {
+			ts := &wire.Transport{}
+			if pub.Equal(&servers[2].PrivKey.PublicKey) && ts.UnmarshalSSZ(msg) == nil && ts.Type == wire.KyberMessageType {
+				return fmt.Errorf("rejected deal")
+			}
+			return crypto.VerifyRSA(pub, msg, sig)
+		}
+		id := crypto.NewID()
+		_, ks, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 88}, "mainnet", owner, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{33}, clnt.ExcludedOperators)
+		require.Equal(t, []uint64{11, 22, 44, 55}, ks.Shares[0].Payload.OperatorIDs)
+		sharesDataSigned, err := hex.DecodeString(ks.Shares[0].Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Shares[0].Payload.PublicKey[2:])
+		require.NoError(t, err)
+		priviteKeys := []*rsa.PrivateKey{servers[0].PrivKey, servers[1].PrivKey, servers[3].PrivKey, servers[4].PrivKey}
+		err = testSharesData(ops, 4, priviteKeys, sharesDataSigned, pubkeyraw, owner, 0)
+		require.NoError(t, err)
+	})
+	t.Run("test not enough operators", func(t *testing.T) {
+		clnt, err := initiator.New(ops, logger, version, rootCert)
+		require.NoError(t, err)
+		clnt.ThresholdTolerant = true
+		servers[5].HttpSrv.Close()
+		servers[4].HttpSrv.Close()
+		id := crypto.NewID()
+		_, _, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{11, 22, 33, 44, 55, 66, 77}, "mainnet", owner, 0)
+		require.ErrorContains(t, err, "not enough operators to finish DKG")
+	})
+	for _, srv := range servers {
+		srv.HttpSrv.Close()
+	}
+}
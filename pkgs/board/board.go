@@ -1,6 +1,8 @@
 package board
 
 import (
+	"sync"
+
 	"github.com/drand/kyber/share/dkg"
 	"go.uber.org/zap"
 
@@ -18,6 +20,8 @@ type Board struct {
 	DealC          chan dkg.DealBundle
 	ResponseC      chan dkg.ResponseBundle
 	JustificationC chan dkg.JustificationBundle
+	mtx            sync.Mutex
+	phaser         *Phaser // phaser of the protocol notified about received bundles, set when the protocol is started
 }
 
 // NewBoard creates a new instance of Board structure
@@ -59,9 +63,25 @@ func (b *Board) IncomingDeal() <-chan dkg.DealBundle {
 	return b.DealC
 }
 
-// PushResponses implements a kyber DKG Board interface to broadcast responses
+// PushResponses implements a kyber DKG Board interface to broadcast responses.
+// Responses contain the status of each received deal, initiator relays them to all nodes
 func (b *Board) PushResponses(bundle *dkg.ResponseBundle) {
-	// dont push responses to nodes, allowing them to finish with error
+	b.logger.Debug("Pushing response bundle: ", zap.Int("num of responses", len(bundle.Responses)))
+
+	byts, err := wire2.EncodeResponseBundle(bundle)
+	if err != nil {
+		b.logger.Error(err.Error())
+		return
+	}
+	msg := &wire2.KyberMessage{
+		Type: wire2.KyberResponseBundleMessageType,
+		Data: byts,
+	}
+
+	if err := b.broadcastF(msg); err != nil {
+		b.logger.Error(err.Error())
+		return
+	}
 }
 
 // IncomingResponse implements a kyber DKG Board interface function
@@ -70,11 +90,72 @@ func (b *Board) IncomingResponse() <-chan dkg.ResponseBundle {
 }
 
 // PushJustifications implements a kyber DKG interface to broadcast justifications
+// answering complaints about the deals of this node
 func (b *Board) PushJustifications(bundle *dkg.JustificationBundle) {
-	// dont push justifications to nodes, allowing them to finish with error
+	b.logger.Debug("Pushing justification bundle: ", zap.Int("num of justifications", len(bundle.Justifications)))
+
+	byts, err := wire2.EncodeJustificationBundle(bundle)
+	if err != nil {
+		b.logger.Error(err.Error())
+		return
+	}
+	msg := &wire2.KyberMessage{
+		Type: wire2.KyberJustificationBundleMessageType,
+		Data: byts,
+	}
+
+	if err := b.broadcastF(msg); err != nil {
+		b.logger.Error(err.Error())
+		return
+	}
 }
 
 // IncomingJustification implements a kyber DKG Board interface function
 func (b *Board) IncomingJustification() <-chan dkg.JustificationBundle {
 	return b.JustificationC
 }
+
+// ReceiveDeal passes the deal bundle relayed by initiator to the protocol. It returns false if the protocol didn't
+// receive the bundle before done is closed.
+func (b *Board) ReceiveDeal(bundle *dkg.DealBundle, done <-chan struct{}) bool {
+	select {
+	case b.DealC <- *bundle:
+	case <-done:
+		return false
+	}
+	if p := b.getPhaser(); p != nil {
+		p.dealers.received(bundle.DealerIndex)
+	}
+	return true
+}
+
+// ReceiveResponse passes the response bundle relayed by initiator to the protocol. It returns false if the protocol didn't
+// receive the bundle before done is closed.
+func (b *Board) ReceiveResponse(bundle *dkg.ResponseBundle, done <-chan struct{}) bool {
+	select {
+	case b.ResponseC <- *bundle:
+	case <-done:
+		return false
+	}
+	if p := b.getPhaser(); p != nil {
+		p.holders.received(bundle.ShareIndex)
+	}
+	return true
+}
+
+// ReceiveJustification passes the justification bundle relayed by initiator to the protocol. It returns false if the protocol
+// didn't receive the bundle before done is closed.
+func (b *Board) ReceiveJustification(bundle *dkg.JustificationBundle, done <-chan struct{}) bool {
+	select {
+	case b.JustificationC <- *bundle:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+func (b *Board) getPhaser() *Phaser {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.phaser
+}
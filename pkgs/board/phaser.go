@@ -0,0 +1,120 @@
+package board
+
+import (
+	"sync"
+	"time"
+
+	"github.com/drand/kyber/share/dkg"
+)
+
+// Phaser moves the DKG protocol to the next phase as soon as the board collected all bundles expected at the
+// current phase. The timeout is only a fallback if some bundles are missing.
+//
+// The protocol runs in fast sync mode: every share holder sends a response bundle with a status of each deal,
+// and initiator relays all of them to every node. A node moves to the justification phase only when it has
+// response bundles of all share holders or the timeout fires, so nodes decide on the qualified dealers
+// from the same complaints.
+type Phaser struct {
+	out      chan dkg.Phase
+	timeout  time.Duration
+	dealers  *bundleSet // dealer indices of the received deal bundles
+	holders  *bundleSet // share holder indices of the received response bundles
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPhaser creates a phaser expecting deal bundles from the dealers and response bundles from the share holders
+// and registers it at the board
+func (b *Board) NewPhaser(dealers, holders int, timeout time.Duration) *Phaser {
+	p := &Phaser{
+		// the channel isn't buffered: a phase is received by the protocol only when the previous one is processed
+		out:     make(chan dkg.Phase),
+		timeout: timeout,
+		dealers: newBundleSet(dealers),
+		holders: newBundleSet(holders),
+		stop:    make(chan struct{}),
+	}
+	b.mtx.Lock()
+	b.phaser = p
+	b.mtx.Unlock()
+	return p
+}
+
+// NextPhase implements a kyber DKG Phaser interface function
+func (p *Phaser) NextPhase() chan dkg.Phase {
+	return p.out
+}
+
+// Start signals the protocol phases. It returns when the protocol moved to the finish phase or the phaser is stopped.
+func (p *Phaser) Start() {
+	if !p.send(dkg.DealPhase) {
+		return
+	}
+	p.wait(p.dealers.done)
+	if !p.send(dkg.ResponsePhase) {
+		return
+	}
+	// the protocol finishes by itself when it has all response bundles without complaints
+	p.wait(p.holders.done)
+	if !p.send(dkg.JustifPhase) {
+		return
+	}
+	// justifications are sent only by dealers answering complaints, so the phase always lasts until the timeout
+	p.wait(nil)
+	p.send(dkg.FinishPhase)
+}
+
+// Stop stops the phaser when the protocol is finished before the finish phase
+func (p *Phaser) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+}
+
+// send signals the next phase to the protocol
+func (p *Phaser) send(phase dkg.Phase) bool {
+	select {
+	case p.out <- phase:
+		return true
+	case <-p.stop:
+		return false
+	}
+}
+
+// wait waits for the event until the timeout
+func (p *Phaser) wait(event <-chan struct{}) {
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	select {
+	case <-event:
+	case <-timer.C:
+	case <-p.stop:
+	}
+}
+
+// bundleSet records senders of bundles expected at a phase
+type bundleSet struct {
+	mtx      sync.Mutex
+	expected int
+	senders  map[uint32]struct{}
+	done     chan struct{} // closed when bundles of all expected senders are received
+}
+
+func newBundleSet(expected int) *bundleSet {
+	return &bundleSet{
+		expected: expected,
+		senders:  make(map[uint32]struct{}, expected),
+		done:     make(chan struct{}),
+	}
+}
+
+// received records the sender of a bundle passed to the protocol, a retried bundle isn't counted twice
+func (s *bundleSet) received(sender uint32) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.senders) == s.expected {
+		return
+	}
+	s.senders[sender] = struct{}{}
+	if len(s.senders) == s.expected {
+		close(s.done)
+	}
+}
@@ -0,0 +1,123 @@
+package board
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drand/kyber/share/dkg"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func newTestBoard(t *testing.T) *Board {
+	b := NewBoard(zap.NewNop(), func(msg *wire.KyberMessage) error { return nil })
+	// drain bundles passed to the protocol
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		for {
+			select {
+			case <-b.DealC:
+			case <-b.ResponseC:
+			case <-b.JustificationC:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return b
+}
+
+func readPhase(t *testing.T, p *Phaser, timeout time.Duration) dkg.Phase {
+	select {
+	case phase := <-p.NextPhase():
+		return phase
+	case <-time.After(timeout):
+		require.FailNow(t, "phase wasn't signaled")
+		return 0
+	}
+}
+
+func requireNoPhase(t *testing.T, p *Phaser) {
+	select {
+	case <-p.NextPhase():
+		require.FailNow(t, "phase signaled before all bundles are received")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPhaser(t *testing.T) {
+	t.Run("moves to the next phase when all deals are received", func(t *testing.T) {
+		b := newTestBoard(t)
+		p := b.NewPhaser(2, 2, time.Hour)
+		go p.Start()
+		defer p.Stop()
+		require.Equal(t, dkg.DealPhase, readPhase(t, p, time.Second))
+		require.True(t, b.ReceiveDeal(&dkg.DealBundle{DealerIndex: 0}, nil))
+		// a retried bundle of the same dealer isn't counted twice
+		require.True(t, b.ReceiveDeal(&dkg.DealBundle{DealerIndex: 0}, nil))
+		requireNoPhase(t, p)
+		require.True(t, b.ReceiveDeal(&dkg.DealBundle{DealerIndex: 1}, nil))
+		require.Equal(t, dkg.ResponsePhase, readPhase(t, p, time.Second))
+	})
+	t.Run("waits for response bundles of all share holders", func(t *testing.T) {
+		b := newTestBoard(t)
+		p := b.NewPhaser(1, 2, time.Hour)
+		go p.Start()
+		defer p.Stop()
+		require.Equal(t, dkg.DealPhase, readPhase(t, p, time.Second))
+		require.True(t, b.ReceiveDeal(&dkg.DealBundle{DealerIndex: 0}, nil))
+		require.Equal(t, dkg.ResponsePhase, readPhase(t, p, time.Second))
+		// a node without complaints waits for the responses of the rest, they may complain
+		require.True(t, b.ReceiveResponse(&dkg.ResponseBundle{ShareIndex: 0}, nil))
+		require.True(t, b.ReceiveResponse(&dkg.ResponseBundle{ShareIndex: 0}, nil))
+		requireNoPhase(t, p)
+		require.True(t, b.ReceiveResponse(&dkg.ResponseBundle{ShareIndex: 1}, nil))
+		require.Equal(t, dkg.JustifPhase, readPhase(t, p, time.Second))
+	})
+	t.Run("falls back to the timeout if deals are missing", func(t *testing.T) {
+		b := newTestBoard(t)
+		p := b.NewPhaser(2, 2, 200*time.Millisecond)
+		go p.Start()
+		defer p.Stop()
+		require.Equal(t, dkg.DealPhase, readPhase(t, p, time.Second))
+		require.True(t, b.ReceiveDeal(&dkg.DealBundle{DealerIndex: 0}, nil))
+		start := time.Now()
+		require.Equal(t, dkg.ResponsePhase, readPhase(t, p, time.Second))
+		require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+	t.Run("falls back to the timeout if response bundles are missing", func(t *testing.T) {
+		b := newTestBoard(t)
+		p := b.NewPhaser(1, 2, 200*time.Millisecond)
+		go p.Start()
+		defer p.Stop()
+		require.Equal(t, dkg.DealPhase, readPhase(t, p, time.Second))
+		require.True(t, b.ReceiveDeal(&dkg.DealBundle{DealerIndex: 0}, nil))
+		require.Equal(t, dkg.ResponsePhase, readPhase(t, p, time.Second))
+		require.True(t, b.ReceiveResponse(&dkg.ResponseBundle{ShareIndex: 0}, nil))
+		start := time.Now()
+		require.Equal(t, dkg.JustifPhase, readPhase(t, p, time.Second))
+		require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+		start = time.Now()
+		require.Equal(t, dkg.FinishPhase, readPhase(t, p, time.Second))
+		require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+	t.Run("stopped phaser doesn't signal phases", func(t *testing.T) {
+		b := newTestBoard(t)
+		p := b.NewPhaser(1, 1, time.Hour)
+		stopped := make(chan struct{})
+		go func() {
+			p.Start()
+			close(stopped)
+		}()
+		require.Equal(t, dkg.DealPhase, readPhase(t, p, time.Second))
+		p.Stop()
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			require.FailNow(t, "phaser isn't stopped")
+		}
+	})
+}
@@ -4,3 +4,7 @@ const API_INIT_URL = "init"
 const API_DKG_URL = "dkg"
 const API_HEALTH_CHECK_URL = "health_check"
 const API_RESULTS_URL = "results"
+const API_RESHARE_URL = "reshare"
+const API_SIGN_URL = "sign"
+const API_ADMIN_APPROVALS_URL = "approvals"
+const API_ADMIN_INSTANCES_URL = "instances"
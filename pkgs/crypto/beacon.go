@@ -6,6 +6,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	e2m_core "github.com/bloxapp/eth2-key-manager/core"
 	e2m_deposit "github.com/bloxapp/eth2-key-manager/eth1_deposit"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
@@ -15,6 +16,8 @@ const (
 	// BLSWithdrawalPrefixByte is the BLS withdrawal prefix
 	BLSWithdrawalPrefixByte  = byte(0)
 	ETH1WithdrawalPrefixByte = byte(1)
+	// CompoundingWithdrawalPrefixByte is the compounding withdrawal prefix introduced at Electra
+	CompoundingWithdrawalPrefixByte = byte(2)
 )
 
 // withdrawalCredentialsHash forms a 32 byte hash of the withdrawal public
@@ -39,6 +42,51 @@ func ETH1WithdrawalCredentials(withdrawalAddr []byte) []byte {
 	return withdrawalCredentials
 }
 
+func CompoundingWithdrawalCredentials(withdrawalAddr []byte) []byte {
+	withdrawalCredentials := ETH1WithdrawalCredentials(withdrawalAddr)
+	withdrawalCredentials[0] = CompoundingWithdrawalPrefixByte
+	return withdrawalCredentials
+}
+
+// WithdrawalCredentials returns withdrawal credentials for the withdrawal prefix. The withdrawal is a BLS public key
+// for 0x00 prefix and an execution layer address for 0x01 and 0x02 prefixes.
+func WithdrawalCredentials(prefix byte, withdrawal []byte) ([]byte, error) {
+	switch prefix {
+	case BLSWithdrawalPrefixByte:
+		if len(withdrawal) != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("incorrect withdrawal public key length %d", len(withdrawal))
+		}
+		pk := &bls.PublicKey{}
+		if err := pk.Deserialize(withdrawal); err != nil {
+			return nil, fmt.Errorf("invalid withdrawal public key: %w", err)
+		}
+		return BLSWithdrawalCredentials(withdrawal), nil
+	case ETH1WithdrawalPrefixByte, CompoundingWithdrawalPrefixByte:
+		if len(withdrawal) != common.AddressLength {
+			return nil, fmt.Errorf("incorrect withdrawal address length %d", len(withdrawal))
+		}
+		if prefix == ETH1WithdrawalPrefixByte {
+			return ETH1WithdrawalCredentials(withdrawal), nil
+		}
+		return CompoundingWithdrawalCredentials(withdrawal), nil
+	default:
+		return nil, fmt.Errorf("unsupported withdrawal prefix %#x", prefix)
+	}
+}
+
+// ValidateDepositAmount checks that the deposit amount is accepted by the deposit contract
+// and doesn't exceed the max effective balance of the withdrawal credentials type
+func ValidateDepositAmount(prefix byte, amount phase0.Gwei) error {
+	maxAmount := MaxEffectiveBalanceInGwei
+	if prefix == CompoundingWithdrawalPrefixByte {
+		maxAmount = MaxEffectiveBalanceElectraInGwei
+	}
+	if amount < MinDepositAmountInGwei || amount > maxAmount {
+		return fmt.Errorf("deposit amount %d is out of range %d to %d Gwei", amount, MinDepositAmountInGwei, maxAmount)
+	}
+	return nil
+}
+
 func ParseWithdrawalCredentials(withdrawalCredentials []byte) (prefix byte, addr []byte) {
 	return withdrawalCredentials[0], withdrawalCredentials[12:]
 }
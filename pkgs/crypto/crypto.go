@@ -26,6 +26,10 @@ const (
 	SignatureLength = 256
 	// MaxEffectiveBalanceInGwei is the max effective balance
 	MaxEffectiveBalanceInGwei phase0.Gwei = 32000000000
+	// MaxEffectiveBalanceElectraInGwei is the max effective balance of a validator with compounding withdrawal credentials
+	MaxEffectiveBalanceElectraInGwei phase0.Gwei = 2048000000000
+	// MinDepositAmountInGwei is the minimal amount accepted by the deposit contract
+	MinDepositAmountInGwei phase0.Gwei = 1000000000
 )
 
 func init() {
@@ -71,6 +75,42 @@ func ResultToValidatorPK(result *drand_dkg.DistKeyShare, suite drand_dkg.Suite)
 	return pk, nil
 }
 
+// ShareSecretKeyToPriShare converts github.com/herumi/bls-eth-go-binary/bls private key share of an operator to kyber private share
+func ShareSecretKeyToPriShare(sk *bls.SecretKey, operatorID uint64, suite drand_dkg.Suite) (*share.PriShare, error) {
+	v := suite.Scalar()
+	if err := v.UnmarshalBinary(sk.Serialize()); err != nil {
+		return nil, fmt.Errorf("could not unmarshal share secret key %w", err)
+	}
+	// kyber DKG node index is operator ID - 1, see GetDKGNodes
+	return &share.PriShare{I: int(operatorID - 1), V: v}, nil
+}
+
+// RecoverPubPoly reconstructs a public polynomial of the previous DKG ceremony from operators share public keys.
+// All share public keys are checked to be evaluations of the recovered polynomial.
+func RecoverPubPoly(ids []uint64, sharePubKeys [][]byte, t int, suite drand_dkg.Suite) (*share.PubPoly, error) {
+	if len(ids) != len(sharePubKeys) {
+		return nil, fmt.Errorf("inconsistent IDs len")
+	}
+	pubShares := make([]*share.PubShare, len(ids))
+	for i, id := range ids {
+		p := suite.Point()
+		if err := p.UnmarshalBinary(sharePubKeys[i]); err != nil {
+			return nil, fmt.Errorf("could not unmarshal share public key %w", err)
+		}
+		pubShares[i] = &share.PubShare{I: int(id - 1), V: p}
+	}
+	pubPoly, err := share.RecoverPubPoly(suite, pubShares, t, len(ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, pubShare := range pubShares {
+		if !pubPoly.Eval(pubShare.I).V.Equal(pubShare.V) {
+			return nil, fmt.Errorf("share public key of operator %d doesn't match recovered public polynomial", pubShare.I+1)
+		}
+	}
+	return pubPoly, nil
+}
+
 // VerifyOwnerNonceSignature check that owner + nonce correctly signed
 func VerifyOwnerNonceSignature(sig []byte, owner common.Address, pubKey []byte, nonce uint16) error {
 	data := fmt.Sprintf("%s:%d", owner.String(), nonce)
@@ -18,7 +18,7 @@ import (
 func BuildDepositDataCLI(network core.Network, depositData *phase0.DepositData, depositCLIVersion string) (*wire.DepositDataCLI, error) {
 	depositMsg := &phase0.DepositMessage{
 		WithdrawalCredentials: depositData.WithdrawalCredentials,
-		Amount:                MaxEffectiveBalanceInGwei,
+		Amount:                depositData.Amount,
 	}
 	copy(depositMsg.PublicKey[:], depositData.PublicKey[:])
 	depositMsgRoot, err := depositMsg.HashTreeRoot()
@@ -32,14 +32,17 @@ func BuildDepositDataCLI(network core.Network, depositData *phase0.DepositData,
 	}
 
 	// Final checks of prepared deposit data
-	if !(MaxEffectiveBalanceInGwei == depositData.Amount) {
-		return nil, fmt.Errorf("deposit data is invalid. Wrong amount %d", depositData.Amount)
+	if len(depositData.WithdrawalCredentials) != 32 {
+		return nil, fmt.Errorf("deposit data is invalid. Wrong withdrawal credentials length %d", len(depositData.WithdrawalCredentials))
+	}
+	if err := ValidateDepositAmount(depositData.WithdrawalCredentials[0], depositData.Amount); err != nil {
+		return nil, fmt.Errorf("deposit data is invalid: %w", err)
 	}
 	forkbytes := network.GenesisForkVersion()
 	depositDataJson := &wire.DepositDataCLI{
 		PubKey:                hex.EncodeToString(depositData.PublicKey[:]),
 		WithdrawalCredentials: hex.EncodeToString(depositData.WithdrawalCredentials),
-		Amount:                MaxEffectiveBalanceInGwei,
+		Amount:                depositData.Amount,
 		Signature:             hex.EncodeToString(depositData.Signature[:]),
 		DepositMessageRoot:    hex.EncodeToString(depositMsgRoot[:]),
 		DepositDataRoot:       hex.EncodeToString(depositDataRoot[:]),
@@ -50,15 +53,22 @@ func BuildDepositDataCLI(network core.Network, depositData *phase0.DepositData,
 	return depositDataJson, nil
 }
 
-func ValidateDepositDataCLI(d *wire.DepositDataCLI, expectedWithdrawalAddress common.Address) error {
-	return validateDepositDataCLI(d, ETH1WithdrawalCredentials(expectedWithdrawalAddress.Bytes()))
+// ValidateDepositDataCLI validates deposit data of a validator with withdrawal credentials of an execution layer address.
+// The withdrawal prefix is either 0x01 or 0x02 (compounding).
+func ValidateDepositDataCLI(d *wire.DepositDataCLI, expectedWithdrawalPrefix byte, expectedWithdrawalAddress common.Address, expectedAmount phase0.Gwei) error {
+	withdrawalCredentials, err := WithdrawalCredentials(expectedWithdrawalPrefix, expectedWithdrawalAddress.Bytes())
+	if err != nil {
+		return err
+	}
+	return ValidateDepositDataCLICredentials(d, withdrawalCredentials, expectedAmount)
 }
 
-func ValidateDepositDataCLIBLS(d *wire.DepositDataCLI, expectedWithdrawalPubKey []byte) error {
-	return validateDepositDataCLI(d, BLSWithdrawalCredentials(expectedWithdrawalPubKey))
+func ValidateDepositDataCLIBLS(d *wire.DepositDataCLI, expectedWithdrawalPubKey []byte, expectedAmount phase0.Gwei) error {
+	return ValidateDepositDataCLICredentials(d, BLSWithdrawalCredentials(expectedWithdrawalPubKey), expectedAmount)
 }
 
-func validateDepositDataCLI(d *wire.DepositDataCLI, expectedWithdrawalCredentials []byte) error {
+// ValidateDepositDataCLICredentials validates deposit data of a validator with the 32 bytes withdrawal credentials of any type
+func ValidateDepositDataCLICredentials(d *wire.DepositDataCLI, expectedWithdrawalCredentials []byte, expectedAmount phase0.Gwei) error {
 	// Re-encode and re-decode the deposit data json to ensure encoding is valid.
 	b, err := json.Marshal(d)
 	if err != nil {
@@ -85,6 +95,10 @@ func validateDepositDataCLI(d *wire.DepositDataCLI, expectedWithdrawalCredential
 	if d.WithdrawalCredentials != hex.EncodeToString(expectedWithdrawalCredentials) {
 		return fmt.Errorf("failed to verify withdrawal address (%s != %x)", d.WithdrawalCredentials, expectedWithdrawalCredentials)
 	}
+	// 4. Verify deposit amount
+	if d.Amount != expectedAmount {
+		return fmt.Errorf("failed to verify deposit amount (%d != %d)", d.Amount, expectedAmount)
+	}
 	return nil
 }
 
@@ -121,8 +135,12 @@ func validateFieldFormatting(d *wire.DepositDataCLI) error {
 		return fmt.Errorf("resulting deposit data json has wrong fields length")
 	}
 	// check the deposit amount
-	if d.Amount != 32000000000 {
-		return fmt.Errorf("resulting deposit data json has wrong amount")
+	prefix, err := hex.DecodeString(d.WithdrawalCredentials[:2])
+	if err != nil {
+		return fmt.Errorf("resulting deposit data json has wrong withdrawal credentials: %w", err)
+	}
+	if err := ValidateDepositAmount(prefix[0], d.Amount); err != nil {
+		return fmt.Errorf("resulting deposit data json has wrong amount: %w", err)
 	}
 	v, err := version.NewVersion(d.DepositCliVersion)
 	if err != nil {
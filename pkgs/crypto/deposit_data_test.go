@@ -0,0 +1,113 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func TestDepositDataCLI(t *testing.T) {
+	withdraw := common.HexToAddress("0x81592c3de184a3e2c0dcb5a261bc107bfa91f494")
+	tests := []struct {
+		name   string
+		prefix byte
+		amount phase0.Gwei
+		err    string
+	}{
+		{"0x01 32 ETH", ETH1WithdrawalPrefixByte, MaxEffectiveBalanceInGwei, ""},
+		{"0x01 1 ETH", ETH1WithdrawalPrefixByte, MinDepositAmountInGwei, ""},
+		{"0x02 32 ETH", CompoundingWithdrawalPrefixByte, MaxEffectiveBalanceInGwei, ""},
+		{"0x02 2048 ETH", CompoundingWithdrawalPrefixByte, MaxEffectiveBalanceElectraInGwei, ""},
+		{"0x01 above max effective balance", ETH1WithdrawalPrefixByte, 33000000000, "deposit amount 33000000000 is out of range"},
+		{"0x02 above max effective balance", CompoundingWithdrawalPrefixByte, MaxEffectiveBalanceElectraInGwei + 1, "deposit amount 2048000000001 is out of range"},
+		{"below min deposit", CompoundingWithdrawalPrefixByte, MinDepositAmountInGwei - 1, "deposit amount 999999999 is out of range"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sk := &bls.SecretKey{}
+			sk.SetByCSPRNG()
+			withdrawalCredentials, err := WithdrawalCredentials(test.prefix, withdraw.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, test.prefix, withdrawalCredentials[0])
+			depositData, err := SignDepositMessage(e2m_core.HoleskyNetwork, sk, &phase0.DepositMessage{
+				PublicKey:             phase0.BLSPubKey(sk.GetPublicKey().Serialize()),
+				WithdrawalCredentials: withdrawalCredentials,
+				Amount:                test.amount,
+			})
+			require.NoError(t, err)
+			depositDataCLI, err := BuildDepositDataCLI(e2m_core.HoleskyNetwork, depositData, wire.DepositCliVersion)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.amount, depositDataCLI.Amount)
+			require.NoError(t, ValidateDepositDataCLI(depositDataCLI, test.prefix, withdraw, test.amount))
+			require.ErrorContains(t, ValidateDepositDataCLI(depositDataCLI, test.prefix, withdraw, test.amount+MinDepositAmountInGwei), "failed to verify deposit amount")
+			otherPrefix := CompoundingWithdrawalPrefixByte
+			if test.prefix == CompoundingWithdrawalPrefixByte {
+				otherPrefix = ETH1WithdrawalPrefixByte
+			}
+			require.ErrorContains(t, ValidateDepositDataCLI(depositDataCLI, otherPrefix, withdraw, test.amount), "failed to verify withdrawal address")
+		})
+	}
+	t.Run("0x00 BLS withdrawal credentials", func(t *testing.T) {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		withdrawSK := &bls.SecretKey{}
+		withdrawSK.SetByCSPRNG()
+		withdrawPubKey := withdrawSK.GetPublicKey().Serialize()
+		withdrawalCredentials, err := WithdrawalCredentials(BLSWithdrawalPrefixByte, withdrawPubKey)
+		require.NoError(t, err)
+		require.Equal(t, BLSWithdrawalCredentials(withdrawPubKey), withdrawalCredentials)
+		depositData, err := SignDepositMessage(e2m_core.HoleskyNetwork, sk, &phase0.DepositMessage{
+			PublicKey:             phase0.BLSPubKey(sk.GetPublicKey().Serialize()),
+			WithdrawalCredentials: withdrawalCredentials,
+			Amount:                MaxEffectiveBalanceInGwei,
+		})
+		require.NoError(t, err)
+		depositDataCLI, err := BuildDepositDataCLI(e2m_core.HoleskyNetwork, depositData, wire.DepositCliVersion)
+		require.NoError(t, err)
+		require.NoError(t, ValidateDepositDataCLIBLS(depositDataCLI, withdrawPubKey, MaxEffectiveBalanceInGwei))
+		require.NoError(t, ValidateDepositDataCLICredentials(depositDataCLI, withdrawalCredentials, MaxEffectiveBalanceInGwei))
+		require.ErrorContains(t, ValidateDepositDataCLI(depositDataCLI, ETH1WithdrawalPrefixByte, withdraw, MaxEffectiveBalanceInGwei), "failed to verify withdrawal address")
+	})
+	t.Run("invalid withdrawal public key", func(t *testing.T) {
+		_, err := WithdrawalCredentials(BLSWithdrawalPrefixByte, withdraw.Bytes())
+		require.EqualError(t, err, "incorrect withdrawal public key length 20")
+		_, err = WithdrawalCredentials(BLSWithdrawalPrefixByte, make([]byte, phase0.PublicKeyLength))
+		require.ErrorContains(t, err, "invalid withdrawal public key")
+	})
+	t.Run("invalid withdrawal address", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			prefix     byte
+			withdrawal []byte
+		}{
+			{"0x01 empty", ETH1WithdrawalPrefixByte, nil},
+			{"0x01 short", ETH1WithdrawalPrefixByte, withdraw.Bytes()[:19]},
+			{"0x01 long", ETH1WithdrawalPrefixByte, append(withdraw.Bytes(), 0)},
+			{"0x01 public key", ETH1WithdrawalPrefixByte, make([]byte, phase0.PublicKeyLength)},
+			{"0x02 empty", CompoundingWithdrawalPrefixByte, nil},
+			{"0x02 short", CompoundingWithdrawalPrefixByte, withdraw.Bytes()[:19]},
+			{"0x02 long", CompoundingWithdrawalPrefixByte, append(withdraw.Bytes(), 0)},
+			{"0x02 credentials", CompoundingWithdrawalPrefixByte, make([]byte, 32)},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				_, err := WithdrawalCredentials(test.prefix, test.withdrawal)
+				require.ErrorContains(t, err, "incorrect withdrawal address length")
+			})
+		}
+	})
+	t.Run("unsupported withdrawal prefix", func(t *testing.T) {
+		_, err := WithdrawalCredentials(3, withdraw.Bytes())
+		require.EqualError(t, err, "unsupported withdrawal prefix 0x3")
+	})
+}
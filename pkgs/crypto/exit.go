@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// ExitForkVersion returns the fork version of the voluntary exit signing domain.
+// Since Deneb voluntary exits are signed with the Capella fork version of the network (EIP-7044).
+func ExitForkVersion(network e2m_core.Network) (phase0.Version, error) {
+	switch network {
+	case e2m_core.MainNetwork:
+		return phase0.Version{0x03, 0x00, 0x00, 0x00}, nil
+	case e2m_core.PraterNetwork:
+		return phase0.Version{0x03, 0x00, 0x10, 0x20}, nil
+	case e2m_core.HoleskyNetwork:
+		return phase0.Version{0x04, 0x01, 0x70, 0x00}, nil
+	default:
+		return phase0.Version{}, fmt.Errorf("network %s is not supported", network)
+	}
+}
+
+// ComputeVoluntaryExitSigningRoot returns the signing root of the voluntary exit
+func ComputeVoluntaryExitSigningRoot(exit *phase0.VoluntaryExit, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Root, error) {
+	exitRoot, err := exit.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to determine the root hash of voluntary exit: %s", err)
+	}
+	return computeSigningRoot(exitRoot, types.DomainVoluntaryExit, forkVersion, genesisValidatorsRoot)
+}
+
+// VerifyVoluntaryExit checks BLS signature of the validator over the voluntary exit
+func VerifyVoluntaryExit(validatorPubKey []byte, signedExit *phase0.SignedVoluntaryExit, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) error {
+	signingRoot, err := ComputeVoluntaryExitSigningRoot(signedExit.Message, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	return verifyBLSSignature(validatorPubKey, signedExit.Signature, signingRoot)
+}
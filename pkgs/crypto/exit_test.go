@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+)
+
+func TestVoluntaryExit(t *testing.T) {
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+	forkVersion, err := ExitForkVersion(e2m_core.HoleskyNetwork)
+	require.NoError(t, err)
+	genesisValidatorsRoot := e2m_core.HoleskyNetwork.GenesisValidatorsRoot()
+	root, err := ComputeVoluntaryExitSigningRoot(exit, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	signedExit := &phase0.SignedVoluntaryExit{Message: exit}
+	copy(signedExit.Signature[:], sk.SignByte(root[:]).Serialize())
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, VerifyVoluntaryExit(sk.GetPublicKey().Serialize(), signedExit, forkVersion, genesisValidatorsRoot))
+	})
+	t.Run("wrong genesis validators root", func(t *testing.T) {
+		err := VerifyVoluntaryExit(sk.GetPublicKey().Serialize(), signedExit, forkVersion, e2m_core.MainNetwork.GenesisValidatorsRoot())
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+	t.Run("wrong fork version", func(t *testing.T) {
+		mainnetForkVersion, err := ExitForkVersion(e2m_core.MainNetwork)
+		require.NoError(t, err)
+		err = VerifyVoluntaryExit(sk.GetPublicKey().Serialize(), signedExit, mainnetForkVersion, genesisValidatorsRoot)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+	t.Run("unsupported network", func(t *testing.T) {
+		_, err := ExitForkVersion(e2m_core.Network("unknown"))
+		require.EqualError(t, err, "network unknown is not supported")
+	})
+}
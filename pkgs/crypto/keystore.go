@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// ShareKeystore is an EIP-2335 keystore https://eips.ethereum.org/EIPS/eip-2335 of an operator's BLS key share.
+// Besides the standard fields it holds information about the ceremony which created the share.
+type ShareKeystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description"`
+	PubKey      string                 `json:"pubkey"`
+	Path        string                 `json:"path"`
+	UUID        string                 `json:"uuid"`
+	Version     uint                   `json:"version"`
+	// validator public key the share belongs to
+	ValidatorPubKey string `json:"validator_pubkey"`
+	// ID of the ceremony which created the share
+	RequestID string `json:"request_id"`
+	Owner     string `json:"owner"`
+	Nonce     uint64 `json:"nonce"`
+}
+
+// EncryptShareKeystore encrypts BLS key share with a password to EIP-2335 keystore
+func EncryptShareKeystore(share *bls.SecretKey, password string) (*ShareKeystore, error) {
+	encrypted, err := keystorev4.New().Encrypt(share.Serialize(), password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt share: %w", err)
+	}
+	return &ShareKeystore{
+		Crypto:  encrypted,
+		PubKey:  hex.EncodeToString(share.GetPublicKey().Serialize()),
+		UUID:    uuid.New().String(),
+		Version: keystorev4.New().Version(),
+	}, nil
+}
+
+// DecryptShareKeystore decrypts BLS key share from EIP-2335 keystore and checks it matches keystore public key
+func DecryptShareKeystore(ks *ShareKeystore, password string) (*bls.SecretKey, error) {
+	decrypted, err := keystorev4.New().Decrypt(ks.Crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt share: %w", err)
+	}
+	share := &bls.SecretKey{}
+	if err := share.Deserialize(decrypted); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted share: %w", err)
+	}
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(ks.PubKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode keystore public key: %w", err)
+	}
+	if !bytes.Equal(share.GetPublicKey().Serialize(), pubKey) {
+		return nil, fmt.Errorf("decrypted share doesn't match keystore public key")
+	}
+	return share, nil
+}
@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// DomainBLSToExecutionChange is the signing domain of BLS to execution changes
+var DomainBLSToExecutionChange = types.DomainType{0x0a, 0x00, 0x00, 0x00}
+
+// computeSigningRoot returns the signing root of the object root at the domain
+func computeSigningRoot(objectRoot phase0.Root, domainType types.DomainType, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Root, error) {
+	domain, err := types.ComputeDomain(domainType, forkVersion[:], genesisValidatorsRoot[:])
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to calculate domain: %s", err)
+	}
+	container := &phase0.SigningData{
+		ObjectRoot: objectRoot,
+		Domain:     phase0.Domain(domain),
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to determine the root hash of signing container: %s", err)
+	}
+	return signingRoot, nil
+}
+
+// ComputeBLSToExecutionChangeSigningRoot returns the signing root of the BLS to execution change.
+// BLS to execution changes are signed with the genesis fork version of the network.
+func ComputeBLSToExecutionChangeSigningRoot(change *capella.BLSToExecutionChange, genesisForkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Root, error) {
+	changeRoot, err := change.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("failed to determine the root hash of BLS to execution change: %s", err)
+	}
+	return computeSigningRoot(changeRoot, DomainBLSToExecutionChange, genesisForkVersion, genesisValidatorsRoot)
+}
+
+// verifyBLSSignature checks BLS signature of the public key over the signing root
+func verifyBLSSignature(pubKey []byte, signature phase0.BLSSignature, signingRoot phase0.Root) error {
+	pk, err := types.BLSPublicKeyFromBytes(pubKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %s", err)
+	}
+	// copy the signature, it's passed to cgo and signed messages hold Go pointers
+	sig, err := types.BLSSignatureFromBytes(append([]byte{}, signature[:]...))
+	if err != nil {
+		return fmt.Errorf("failed to parse signature: %s", err)
+	}
+	if !sig.Verify(signingRoot[:], pk) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyBLSToExecutionChange checks BLS signature of the withdrawal key over the BLS to execution change
+func VerifyBLSToExecutionChange(signedChange *capella.SignedBLSToExecutionChange, genesisForkVersion phase0.Version, genesisValidatorsRoot phase0.Root) error {
+	signingRoot, err := ComputeBLSToExecutionChangeSigningRoot(signedChange.Message, genesisForkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	return verifyBLSSignature(signedChange.Message.FromBLSPubkey[:], signedChange.Signature, signingRoot)
+}
@@ -2,8 +2,11 @@ package dkg
 
 import (
 	"crypto/rsa"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/drand/kyber"
@@ -24,6 +27,9 @@ import (
 	"github.com/bloxapp/ssv-dkg/spec"
 )
 
+// DefaultPhaseTimeout is the fallback timeout of DKG protocol phases if initiator doesn't request another one
+const DefaultPhaseTimeout = 10 * time.Second
+
 // DKGdata structure to store at LocalOwner information about initial message parameters and secret scalar to be used as input for DKG protocol
 type DKGdata struct {
 	// Request ID formed by initiator to identify DKG ceremony
@@ -32,6 +38,14 @@ type DKGdata struct {
 	init *wire.Init
 	// Randomly generated scalar to be used for DKG ceremony
 	secret kyber.Scalar
+	// reshare message from initiator, set only for resharing ceremony
+	reshare *wire.ReshareMessage
+	// public polynomial commitments of the previous ceremony, set only for resharing ceremony
+	oldCommits []kyber.Point
+	// key share of the previous ceremony, set only for old operators at resharing ceremony
+	secretShare *kyber_dkg.DistKeyShare
+	// init message of the threshold tolerant ceremony which key shares are reshared, set only for resharing finishing it
+	tolerantInit *wire.Init
 }
 
 // OwnerOpts structure to pass parameters from Switch to LocalOwner structure
@@ -48,54 +62,101 @@ type OwnerOpts struct {
 	Owner              [20]byte
 	Nonce              uint64
 	Version            []byte
+	StoreShareFunc     func(reqID [24]byte, validatorPubKey []byte, share *bls.SecretKey, owner [20]byte, nonce uint64) error
+	SaveStateFunc      func(st *State) error
+	DeleteStateFunc    func() error
+	PhaseTimeout       time.Duration // fallback timeout of DKG protocol phases, DefaultPhaseTimeout if zero
 }
 
 var ErrAlreadyExists = errors.New("duplicate message")
 
 // LocalOwner as a main structure created for a new DKG initiation ceremony
 type LocalOwner struct {
-	Logger             *zap.Logger
-	startedDKG         chan struct{}
-	ErrorChan          chan error
-	ID                 uint64
-	data               *DKGdata
-	board              *board.Board
-	Suite              pairing.Suite
-	broadcastF         func([]byte) error
-	exchanges          map[uint64]*wire.Exchange
-	signer             spec.Signer
-	encryptFunc        func([]byte) ([]byte, error)
-	decryptFunc        func([]byte) ([]byte, error)
-	InitiatorPublicKey *rsa.PublicKey
-	OperatorPublicKey  *rsa.PublicKey
-	done               chan struct{}
-	version            []byte
+	Logger              *zap.Logger
+	startedDKG          chan struct{}
+	ErrorChan           chan error
+	ID                  uint64
+	data                *DKGdata
+	boards              []*board.Board // boards of DKG protocols, one per validator created by the ceremony
+	Suite               pairing.Suite
+	broadcastF          func([]byte) error
+	exchanges           map[uint64]*wire.Exchange
+	signer              spec.Signer
+	encryptFunc         func([]byte) ([]byte, error)
+	decryptFunc         func([]byte) ([]byte, error)
+	InitiatorPublicKey  *rsa.PublicKey
+	OperatorPublicKey   *rsa.PublicKey
+	done                chan struct{}
+	version             []byte
+	storeShareFunc      func(reqID [24]byte, validatorPubKey []byte, share *bls.SecretKey, owner [20]byte, nonce uint64) error
+	saveStateFunc       func(st *State) error
+	deleteStateFunc     func() error
+	restoredPhase       Phase                     // phase of the instance restored after operator restart
+	restoredExchanges   map[uint64]*wire.Exchange // exchange messages received before operator restart
+	skipDeals           bool                      // deals were sent before operator restart
+	defaultPhaseTimeout time.Duration             // fallback timeout of DKG protocol phases if initiator doesn't request another one
+	mtx                 sync.Mutex
+	collected           map[wire.TransportType][]*wire.KyberMessage // deal and response bundles of a multi-validator ceremony collected to be sent at once
+	results             []*wire.Result                              // results of a multi-validator ceremony collected to be sent at once
+	result              *wire.Result                                // result of a single validator ceremony, set when it is finished
+	errOnce             sync.Once
+	finishOnce          sync.Once
 }
 
 // New creates a LocalOwner structure. We create it for each new DKG ceremony.
 func New(opts *OwnerOpts) *LocalOwner {
 	owner := &LocalOwner{
-		Logger:             opts.Logger,
-		startedDKG:         make(chan struct{}, 1),
-		ErrorChan:          make(chan error, 1),
-		ID:                 opts.ID,
-		broadcastF:         opts.BroadcastF,
-		exchanges:          make(map[uint64]*wire.Exchange),
-		signer:             opts.Signer,
-		encryptFunc:        opts.EncryptFunc,
-		decryptFunc:        opts.DecryptFunc,
-		InitiatorPublicKey: opts.InitiatorPublicKey,
-		OperatorPublicKey:  opts.OperatorPublicKey,
-		done:               make(chan struct{}, 1),
-		Suite:              opts.Suite,
-		version:            opts.Version,
+		Logger:              opts.Logger,
+		startedDKG:          make(chan struct{}, 1),
+		ErrorChan:           make(chan error, 1),
+		ID:                  opts.ID,
+		broadcastF:          opts.BroadcastF,
+		exchanges:           make(map[uint64]*wire.Exchange),
+		restoredExchanges:   make(map[uint64]*wire.Exchange),
+		signer:              opts.Signer,
+		encryptFunc:         opts.EncryptFunc,
+		decryptFunc:         opts.DecryptFunc,
+		InitiatorPublicKey:  opts.InitiatorPublicKey,
+		OperatorPublicKey:   opts.OperatorPublicKey,
+		done:                make(chan struct{}, 1),
+		Suite:               opts.Suite,
+		version:             opts.Version,
+		storeShareFunc:      opts.StoreShareFunc,
+		saveStateFunc:       opts.SaveStateFunc,
+		deleteStateFunc:     opts.DeleteStateFunc,
+		defaultPhaseTimeout: opts.PhaseTimeout,
 	}
 	return owner
 }
 
-// StartDKG initializes and starts DKG protocol
+// phaseTimeout returns the fallback timeout of DKG protocol phases requested by initiator or the operator default
+func (o *LocalOwner) phaseTimeout() time.Duration {
+	if o.data.init.PhaseTimeout != 0 {
+		return time.Duration(o.data.init.PhaseTimeout) * time.Millisecond
+	}
+	if o.defaultPhaseTimeout != 0 {
+		return o.defaultPhaseTimeout
+	}
+	return DefaultPhaseTimeout
+}
+
+// StartDKG initializes and starts DKG protocols, one per validator created by the ceremony
 func (o *LocalOwner) StartDKG() error {
-	o.Logger.Info("Starting DKG")
+	o.Logger.Info("Starting DKG", zap.Int("validators", len(o.boards)))
+	if err := o.saveState(DealPhase); err != nil {
+		return err
+	}
+	for i := range o.boards {
+		if err := o.startProtocol(i); err != nil {
+			return err
+		}
+	}
+	close(o.startedDKG)
+	return nil
+}
+
+// startProtocol starts DKG protocol creating the validator at the index
+func (o *LocalOwner) startProtocol(index int) error {
 	nodes := make([]kyber_dkg.Node, 0)
 	// Create nodes using public points of all operators participating in the protocol
 	// Each operator creates a random secret/public points at G1 when initiating new LocalOwner instance
@@ -111,31 +172,103 @@ func (o *LocalOwner) StartDKG() error {
 	}
 	// New protocol
 	logger := o.Logger.With(zap.Uint64("ID", o.ID))
+	if len(o.boards) > 1 {
+		logger = logger.With(zap.Int("validator", index))
+	}
 	dkgConfig := &kyber_dkg.Config{
 		Longterm:  o.data.secret,
-		Nonce:     utils.GetNonce(o.data.reqID[:]),
+		Nonce:     o.dkgNonce(index),
 		Suite:     o.Suite.G1().(kyber_dkg.Suite),
 		NewNodes:  nodes,
 		OldNodes:  nodes, // when initiating dkg we consider the old nodes the new nodes (taken from kyber)
 		Threshold: int(o.data.init.T),
 		Auth:      drand_bls.NewSchemeOnG2(o.Suite),
+		// every node sends a response bundle, so nodes agree on complaints before deciding on qualified dealers
+		FastSync: true,
 	}
-	p, err := wire.NewDKGProtocol(dkgConfig, o.board, logger)
+	if err := o.seedRandomness(dkgConfig, index); err != nil {
+		return err
+	}
+	b := o.boards[index]
+	// protocols of a multi-validator ceremony share the operator, deals of the last ones are processed
+	// only after the deals of the rest, so the fallback timeout scales with their number
+	phaser := b.NewPhaser(len(dkgConfig.OldNodes), len(dkgConfig.NewNodes), o.phaseTimeout()*time.Duration(len(o.boards)))
+	p, err := wire.NewDKGProtocol(dkgConfig, b, phaser, logger)
 	if err != nil {
 		return err
 	}
 	// Wait when the protocol exchanges finish and process the result
-	go func(p *kyber_dkg.Protocol, postF func(res *kyber_dkg.OptionResult) error) {
+	go func(p *kyber_dkg.Protocol) {
 		res := <-p.WaitEnd()
-		if err := postF(&res); err != nil {
-			o.Logger.Error("Error in PostDKG function", zap.Error(err))
+		phaser.Stop()
+		if err := o.PostDKG(index, &res); err != nil {
+			logger.Error("Error in PostDKG function", zap.Error(err))
+			if len(o.boards) > 1 {
+				err = fmt.Errorf("validator %d: %w", index, err)
+			}
 			o.broadcastError(fmt.Errorf("operator ID:%d, err:%w", o.ID, err))
 		}
-	}(p, o.PostDKG)
-	close(o.startedDKG)
+	}(p)
 	return nil
 }
 
+// dkgNonce returns kyber nonce of the DKG protocol creating the validator at the index. Protocols of a multi-validator
+// ceremony share the ceremony ID, so the index is a part of the nonce.
+func (o *LocalOwner) dkgNonce(index int) []byte {
+	if index == 0 {
+		return utils.GetNonce(o.data.reqID[:])
+	}
+	return utils.GetNonce(binary.BigEndian.AppendUint64(o.data.reqID[:], uint64(index)))
+}
+
+// start starts DKG or resharing protocol depending on the ceremony
+func (o *LocalOwner) start() error {
+	if o.data.reshare != nil {
+		return o.StartReshare()
+	}
+	return o.StartDKG()
+}
+
+// isStarted checks if DKG protocol is started
+func (o *LocalOwner) isStarted() bool {
+	select {
+	case <-o.startedDKG:
+		return true
+	default:
+		return false
+	}
+}
+
+// StartWithReceivedExchanges starts DKG protocol with operators which exchange messages were received so far.
+// Initiator running a threshold tolerant ceremony excludes operators which didn't respond to init message,
+// the protocol is started if at least threshold operators remain. Other ceremonies and resharing require
+// all participants.
+func (o *LocalOwner) StartWithReceivedExchanges() error {
+	if o.isStarted() {
+		return nil
+	}
+	if o.data.reshare != nil {
+		return fmt.Errorf("resharing requires exchange messages from all participating operators")
+	}
+	if !o.data.init.ThresholdTolerant {
+		return fmt.Errorf("ceremony isn't threshold tolerant, exchange messages from all operators are required")
+	}
+	if o.exchanges[o.ID] == nil {
+		return fmt.Errorf("missing own exchange message")
+	}
+	if uint64(len(o.exchanges)) < o.data.init.T {
+		return fmt.Errorf("not enough exchange messages to start DKG: got %d, threshold %d", len(o.exchanges), o.data.init.T)
+	}
+	var excluded []uint64
+	for _, op := range o.data.init.Operators {
+		if o.exchanges[op.ID] == nil {
+			excluded = append(excluded, op.ID)
+		}
+	}
+	o.Logger.Warn("Starting DKG without some of the operators", zap.Uint64s("excluded operator IDs", excluded))
+	return o.StartDKG()
+}
+
 // Function to send signed messages back to initiator
 func (o *LocalOwner) Broadcast(ts *wire.Transport) error {
 	bts, err := ts.MarshalSSZ()
@@ -165,13 +298,14 @@ func (o *LocalOwner) Broadcast(ts *wire.Transport) error {
 	return o.broadcastF(final)
 }
 
-// PostDKG stores the resulting key share, convert it to BLS points acceptable by ETH2
+// PostDKG stores the resulting key share of the validator at the index, convert it to BLS points acceptable by ETH2
 // and creates the Result structure to send back to initiator
-func (o *LocalOwner) PostDKG(res *kyber_dkg.OptionResult) error {
+func (o *LocalOwner) PostDKG(index int, res *kyber_dkg.OptionResult) error {
 	if res.Error != nil {
 		return fmt.Errorf("dkg protocol failed: %w", res.Error)
 	}
-	o.Logger.Info("DKG ceremony finished successfully")
+	o.Logger.Info("DKG ceremony finished successfully", zap.Int("validator", index))
+	init := spec.ValidatorInit(o.data.init, index)
 	// Get validator BLS public key from result
 	validatorPubKey, err := crypto.ResultToValidatorPK(res.Result.Key, o.Suite.G1().(kyber_dkg.Suite))
 	if err != nil {
@@ -182,20 +316,30 @@ func (o *LocalOwner) PostDKG(res *kyber_dkg.OptionResult) error {
 	if err != nil {
 		return fmt.Errorf("failed to get BLS partial secret key share: %w", err)
 	}
+	// Store BLS share at operator if a store is set
+	if o.storeShareFunc != nil {
+		if err := o.storeShareFunc(o.data.reqID, validatorPubKey.Serialize(), secretKeyBLS, init.Owner, init.Nonce); err != nil {
+			return fmt.Errorf("failed to store BLS share: %w", err)
+		}
+	}
 	// Encrypt BLS share for SSV contract
 	encryptedShare, err := o.encryptFunc([]byte(secretKeyBLS.SerializeToHexStr()))
 	if err != nil {
 		return fmt.Errorf("failed to encrypt BLS share: %w", err)
 	}
 	// Sign root
-	network, err := utils.GetNetworkByFork(o.data.init.Fork)
+	network, err := utils.GetNetworkByFork(init.Fork)
 	if err != nil {
 		return fmt.Errorf("failed to get network by fork: %w", err)
 	}
+	withdrawalCredentials, err := crypto.WithdrawalCredentials(init.WithdrawalPrefix, init.WithdrawalCredentials)
+	if err != nil {
+		return err
+	}
 	signingRoot, err := crypto.ComputeDepositMessageSigningRoot(network, &phase0.DepositMessage{
 		PublicKey:             phase0.BLSPubKey(validatorPubKey.Serialize()),
-		WithdrawalCredentials: crypto.ETH1WithdrawalCredentials(o.data.init.WithdrawalCredentials),
-		Amount:                crypto.MaxEffectiveBalanceInGwei,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                phase0.Gwei(init.Amount),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to generate deposit data with root %w", err)
@@ -211,7 +355,7 @@ func (o *LocalOwner) PostDKG(res *kyber_dkg.OptionResult) error {
 		return err
 	}
 	// Sign SSV owner + nonce
-	data := []byte(fmt.Sprintf("%s:%d", eth_common.Address(o.data.init.Owner).String(), o.data.init.Nonce))
+	data := []byte(fmt.Sprintf("%s:%d", eth_common.Address(init.Owner).String(), init.Nonce))
 	hash := eth_crypto.Keccak256([]byte(data))
 	sigOwnerNonce := secretKeyBLS.SignByte(hash)
 	// Verify partial SSV owner + nonce signature
@@ -219,12 +363,25 @@ func (o *LocalOwner) PostDKG(res *kyber_dkg.OptionResult) error {
 	if !val {
 		return fmt.Errorf("partial owner + nonce signature isnt valid %x", sigOwnerNonce.Serialize())
 	}
+	// Sign voluntary exit if requested
+	var exitPartialSignature []byte
+	if init.SignExit {
+		exitRoot, err := crypto.ComputeVoluntaryExitSigningRoot(spec.VoluntaryExit(init), init.ExitForkVersion, init.GenesisValidatorsRoot)
+		if err != nil {
+			return fmt.Errorf("failed to compute voluntary exit root: %w", err)
+		}
+		sigExit := secretKeyBLS.SignByte(exitRoot[:])
+		if !sigExit.VerifyByte(secretKeyBLS.GetPublicKey(), exitRoot[:]) {
+			return fmt.Errorf("partial voluntary exit signature isnt valid %x", sigExit.Serialize())
+		}
+		exitPartialSignature = sigExit.Serialize()
+	}
 	// Generate and sign proof
 	proof := &wire.Proof{
 		ValidatorPubKey: validatorPubKey.Serialize(),
 		EncryptedShare:  encryptedShare,
 		SharePubKey:     secretKeyBLS.GetPublicKey().Serialize(),
-		Owner:           o.data.init.Owner,
+		Owner:           init.Owner,
 	}
 	signedProof, err := spec.SignCeremonyProof(o.signer, proof)
 	if err != nil {
@@ -236,6 +393,10 @@ func (o *LocalOwner) PostDKG(res *kyber_dkg.OptionResult) error {
 		OperatorID:                 o.ID,
 		OwnerNoncePartialSignature: sigOwnerNonce.Serialize(),
 		SignedProof:                *signedProof,
+		ExitPartialSignature:       exitPartialSignature,
+	}
+	if len(o.boards) > 1 {
+		return o.collectResult(index, out)
 	}
 	encodedOutput, err := out.MarshalSSZ()
 	if err != nil {
@@ -247,72 +408,226 @@ func (o *LocalOwner) PostDKG(res *kyber_dkg.OptionResult) error {
 		Data:       encodedOutput,
 		Version:    o.version,
 	}
+	o.mtx.Lock()
+	o.result = out
+	o.mtx.Unlock()
+	if err := o.Broadcast(tsMsg); err != nil {
+		o.Logger.Error("failed to broadcast output in PostDKG", zap.Error(err))
+	}
+	o.finish()
+	return nil
+}
+
+// TolerantResult returns init message and result of a finished threshold tolerant ceremony, so its key shares can be
+// reshared to a registrable cluster. Nil is returned if the instance isn't such ceremony or it isn't finished.
+func (o *LocalOwner) TolerantResult() (*wire.Init, *wire.Result) {
+	if o.data == nil || o.data.reshare != nil || o.data.init == nil || !o.data.init.ThresholdTolerant {
+		return nil, nil
+	}
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if o.result == nil {
+		return nil, nil
+	}
+	return o.data.init, o.result
+}
+
+// collectResult sends results of a multi-validator ceremony at once when protocols of all validators are finished
+func (o *LocalOwner) collectResult(index int, out *wire.Result) error {
+	o.mtx.Lock()
+	if o.results == nil {
+		o.results = make([]*wire.Result, len(o.boards))
+	}
+	o.results[index] = out
+	for _, res := range o.results {
+		if res == nil {
+			o.mtx.Unlock()
+			return nil
+		}
+	}
+	o.mtx.Unlock()
+	encodedOutput, err := (&wire.MultipleResults{Results: o.results}).MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	tsMsg := &wire.Transport{
+		Type:       wire.MultipleOutputMessageType,
+		Identifier: o.data.reqID,
+		Data:       encodedOutput,
+		Version:    o.version,
+	}
 	if err := o.Broadcast(tsMsg); err != nil {
 		o.Logger.Error("failed to broadcast output in PostDKG", zap.Error(err))
 	}
-	close(o.done)
+	o.finish()
 	return nil
 }
 
+// collectKyber sends deal or response bundles of a multi-validator ceremony at once when protocols of all validators
+// pushed their bundles of this type
+func (o *LocalOwner) collectKyber(index int, msg *wire.KyberMessage) error {
+	o.mtx.Lock()
+	if o.collected == nil {
+		o.collected = make(map[wire.TransportType][]*wire.KyberMessage)
+	}
+	msgs := o.collected[msg.Type]
+	if msgs == nil {
+		msgs = make([]*wire.KyberMessage, len(o.boards))
+		o.collected[msg.Type] = msgs
+	}
+	msgs[index] = msg
+	for _, m := range msgs {
+		if m == nil {
+			o.mtx.Unlock()
+			return nil
+		}
+	}
+	o.mtx.Unlock()
+	byts, err := (&wire.MultipleKyberMessages{Messages: msgs}).MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	o.broadcastKyber(&wire.Transport{
+		Type:       wire.MultipleKyberMessageType,
+		Identifier: o.data.reqID,
+		Data:       byts,
+		Version:    o.version,
+	})
+	return nil
+}
+
+// broadcastKyber sends kyber messages of DKG protocols without blocking the protocol
+func (o *LocalOwner) broadcastKyber(trsp *wire.Transport) {
+	// todo not loop with channels
+	go func(trsp *wire.Transport) {
+		if err := o.Broadcast(trsp); err != nil {
+			o.Logger.Error("broadcasting failed", zap.Error(err))
+		}
+	}(trsp)
+}
+
+// finish marks the ceremony as finished, its state isn't needed to restore the instance anymore
+func (o *LocalOwner) finish() {
+	o.finishOnce.Do(func() {
+		if o.deleteStateFunc != nil {
+			if err := o.deleteStateFunc(); err != nil {
+				o.Logger.Error("failed to delete instance state", zap.Error(err))
+			}
+		}
+		close(o.done)
+	})
+}
+
+// Cancel stops the ceremony on request of the operator. The instance refuses protocol messages after it,
+// initiator waiting for a response gets the error instead.
+func (o *LocalOwner) Cancel() {
+	o.finish()
+	// the response channel is read only while initiator waits for a response
+	go o.broadcastError(fmt.Errorf("operator ID:%d, err: ceremony is cancelled by the operator", o.ID))
+}
+
 // Init function creates an interface for DKG (board) which process protocol messages
 // Here we randomly create a point at G1 as a DKG public key for the node
 func (o *LocalOwner) Init(reqID [24]byte, init *wire.Init) (*wire.Transport, error) {
+	// Generate random k scalar (secret) and corresponding public key k*G where G is a G1 generator
+	eciesSK, _ := initsecret(o.Suite)
+	resp, err := o.init(reqID, init, eciesSK)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.saveState(ExchangePhase); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// init creates the board and the exchange message for the instance secret
+func (o *LocalOwner) init(reqID [24]byte, init *wire.Init, secret kyber.Scalar) (*wire.Transport, error) {
 	if o.data == nil {
 		o.data = &DKGdata{}
 	}
 	o.data.init = init
 	o.data.reqID = reqID
 	kyberLogger := o.Logger.With(zap.String("reqid", fmt.Sprintf("%x", o.data.reqID[:])))
-	o.board = board.NewBoard(
-		kyberLogger,
+	o.boards = make([]*board.Board, spec.CeremonyValidators(init))
+	for i := range o.boards {
+		o.boards[i] = o.newBoard(kyberLogger, i)
+	}
+	o.data.secret = secret
+	pk := o.Suite.G1().Point().Mul(secret, nil)
+	bts, _, err := CreateExchange(pk, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.Transport{
+		Type:       wire.ExchangeMessageType,
+		Identifier: reqID,
+		Data:       bts,
+		Version:    o.version,
+	}, nil
+}
+
+// newBoard creates a board of DKG protocol creating the validator at the index. Deal and response bundles of
+// a multi-validator ceremony are collected to be sent at once, justifications are sent as they are pushed by the protocol.
+func (o *LocalOwner) newBoard(logger *zap.Logger, index int) *board.Board {
+	return board.NewBoard(
+		logger,
 		func(msg *wire.KyberMessage) error {
-			kyberLogger.Debug("server: broadcasting kyber message")
+			if o.skipDeals && msg.Type == wire.KyberDealBundleMessageType {
+				logger.Debug("server: deal bundle was sent before restart, skipping")
+				return nil
+			}
+			if len(o.boards) > 1 && (msg.Type == wire.KyberDealBundleMessageType || msg.Type == wire.KyberResponseBundleMessageType) {
+				return o.collectKyber(index, msg)
+			}
+			logger.Debug("server: broadcasting kyber message")
 			byts, err := msg.MarshalSSZ()
 			if err != nil {
 				return err
 			}
-			trsp := &wire.Transport{
+			o.broadcastKyber(&wire.Transport{
 				Type:       wire.KyberMessageType,
 				Identifier: o.data.reqID,
 				Data:       byts,
 				Version:    o.version,
-			}
-
-			// todo not loop with channels
-			go func(trsp *wire.Transport) {
-				if err := o.Broadcast(trsp); err != nil {
-					o.Logger.Error("broadcasting failed", zap.Error(err))
-				}
-			}(trsp)
-
+			})
 			return nil
 		},
 	)
-	// Generate random k scalar (secret) and corresponding public key k*G where G is a G1 generator
-	eciesSK, pk := initsecret(o.Suite)
-	o.data.secret = eciesSK
-	bts, _, err := CreateExchange(pk, nil)
-	if err != nil {
-		return nil, err
-	}
-	return &wire.Transport{
-		Type:       wire.ExchangeMessageType,
-		Identifier: reqID,
-		Data:       bts,
-		Version:    o.version,
-	}, nil
 }
 
-// processDKG after receiving a kyber message type at /dkg route
-// KyberDealBundleMessageType - message that contains all the deals and the public polynomial from participating party
-// KyberResponseBundleMessageType - status for the deals received at deal bundle
-// KyberJustificationBundleMessageType - all justifications for each complaint for received deals bundles
+// processDKG after receiving a kyber message type at /dkg route. A multi-validator ceremony message holds
+// kyber messages of each validator protocol.
 func (o *LocalOwner) processDKG(from uint64, msg *wire.Transport) error {
+	if msg.Type == wire.MultipleKyberMessageType {
+		msgs := &wire.MultipleKyberMessages{}
+		if err := msgs.UnmarshalSSZ(msg.Data); err != nil {
+			return err
+		}
+		if len(msgs.Messages) != len(o.boards) {
+			return fmt.Errorf("expected kyber messages of %d validators, got %d", len(o.boards), len(msgs.Messages))
+		}
+		for i, kyberMsg := range msgs.Messages {
+			if err := o.processKyberMessage(from, i, kyberMsg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	kyberMsg := &wire.KyberMessage{}
 	if err := kyberMsg.UnmarshalSSZ(msg.Data); err != nil {
 		return err
 	}
-	o.Logger.Debug("operator: received kyber msg", zap.String("type", kyberMsg.Type.String()), zap.Uint64("from", from))
+	return o.processKyberMessage(from, 0, kyberMsg)
+}
+
+// processKyberMessage passes a kyber message to the protocol creating the validator at the index
+// KyberDealBundleMessageType - message that contains all the deals and the public polynomial from participating party
+// KyberResponseBundleMessageType - status for the deals received at deal bundle
+// KyberJustificationBundleMessageType - all justifications for each complaint for received deals bundles
+func (o *LocalOwner) processKyberMessage(from uint64, index int, kyberMsg *wire.KyberMessage) error {
+	o.Logger.Debug("operator: received kyber msg", zap.String("type", kyberMsg.Type.String()), zap.Uint64("from", from), zap.Int("validator", index))
+	board := o.boards[index]
 	switch kyberMsg.Type {
 	case wire.KyberDealBundleMessageType:
 		b, err := wire.DecodeDealBundle(kyberMsg.Data, o.Suite.G1().(kyber_dkg.Suite))
@@ -320,21 +635,27 @@ func (o *LocalOwner) processDKG(from uint64, msg *wire.Transport) error {
 			return err
 		}
 		o.Logger.Debug("operator: received deal bundle from", zap.Uint64("ID", from))
-		o.board.DealC <- *b
+		if !board.ReceiveDeal(b, o.done) {
+			o.Logger.Debug("operator: ceremony is finished, skipping deal bundle", zap.Uint64("ID", from))
+		}
 	case wire.KyberResponseBundleMessageType:
 		b, err := wire.DecodeResponseBundle(kyberMsg.Data)
 		if err != nil {
 			return err
 		}
 		o.Logger.Debug("operator: received response bundle from", zap.Uint64("ID", from))
-		o.board.ResponseC <- *b
+		if !board.ReceiveResponse(b, o.done) {
+			o.Logger.Debug("operator: ceremony is finished, skipping response bundle", zap.Uint64("ID", from))
+		}
 	case wire.KyberJustificationBundleMessageType:
 		b, err := wire.DecodeJustificationBundle(kyberMsg.Data, o.Suite.G1().(kyber_dkg.Suite))
 		if err != nil {
 			return err
 		}
 		o.Logger.Debug("operator: received justification bundle from", zap.Uint64("ID", from))
-		o.board.JustificationC <- *b
+		if !board.ReceiveJustification(b, o.done) {
+			o.Logger.Debug("operator: ceremony is finished, skipping justification bundle", zap.Uint64("ID", from))
+		}
 	default:
 		return fmt.Errorf("unknown kyber message type")
 	}
@@ -343,7 +664,7 @@ func (o *LocalOwner) processDKG(from uint64, msg *wire.Transport) error {
 
 // Process processes incoming messages from initiator at /dkg route
 func (o *LocalOwner) Process(st *wire.SignedTransport) error {
-	from, err := spec.OperatorIDByPubKey(o.data.init.Operators, st.Signer)
+	from, err := spec.OperatorIDByPubKey(o.participants(), st.Signer)
 	if err != nil {
 		return err
 	}
@@ -366,6 +687,9 @@ func (o *LocalOwner) Process(st *wire.SignedTransport) error {
 		if err := exchMsg.UnmarshalSSZ(st.Message.Data); err != nil {
 			return err
 		}
+		if err := o.checkRestoredExchange(from, exchMsg); err != nil {
+			return err
+		}
 		if _, ok := o.exchanges[from]; ok {
 			return ErrAlreadyExists
 		}
@@ -373,12 +697,13 @@ func (o *LocalOwner) Process(st *wire.SignedTransport) error {
 
 		// check if have all participating operators pub keys, then start dkg protocol
 		if o.checkOperators() {
-			if err := o.StartDKG(); err != nil {
-				return err
-			}
+			return o.start()
 		}
 
-	case wire.KyberMessageType:
+	case wire.KyberMessageType, wire.MultipleKyberMessageType:
+		if err := o.resumeDKG(); err != nil {
+			return err
+		}
 		<-o.startedDKG
 		return o.processDKG(from, st.Message)
 	default:
@@ -410,8 +735,15 @@ func CreateExchange(pk kyber.Point, commits []byte) ([]byte, *wire.Exchange, err
 	return exchByts, &exch, nil
 }
 
-// broadcastError propagates the error at operator back to initiator
+// broadcastError propagates the error at operator back to initiator. Only the first error of a multi-validator
+// ceremony is sent, the ceremony is finished after it.
 func (o *LocalOwner) broadcastError(err error) {
+	o.errOnce.Do(func() {
+		o.sendError(err)
+	})
+}
+
+func (o *LocalOwner) sendError(err error) {
 	errMsgEnc, err := json.Marshal(err.Error())
 	if err != nil {
 		o.Logger.Error("failed to marshal error message", zap.Error(err))
@@ -427,12 +759,12 @@ func (o *LocalOwner) broadcastError(err error) {
 	if err := o.Broadcast(errMsg); err != nil {
 		o.Logger.Error("failed to broadcast error message", zap.Error(err))
 	}
-	close(o.done)
+	o.finish()
 }
 
 // checkOperators checks that operator received all participating parties DKG public keys
 func (o *LocalOwner) checkOperators() bool {
-	for _, op := range o.data.init.Operators {
+	for _, op := range o.participants() {
 		if o.exchanges[op.ID] == nil {
 			return false
 		}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"sort"
+	"sync"
 	"testing"
 
 	kyber_bls "github.com/drand/kyber-bls12381"
@@ -93,7 +94,8 @@ func NewTestOperator(ts *testState, id uint64) (*LocalOwner, *rsa.PrivateKey) {
 	}, pv
 }
 
-func TestDKGInit(t *testing.T) {
+// newTestState creates 4 test operators
+func newTestState(t *testing.T) (*testState, []*wire2.Operator) {
 	// Send operators we want to deal with them
 	_, initatorPk, err := crypto.GenerateRSAKeys()
 	require.NoError(t, err)
@@ -121,6 +123,11 @@ func TestDKGInit(t *testing.T) {
 	sort.SliceStable(opsarr, func(i, j int) bool {
 		return opsarr[i].ID < opsarr[j].ID
 	})
+	return ts, opsarr
+}
+
+func TestDKGInit(t *testing.T) {
+	ts, opsarr := newTestState(t)
 	init := &wire2.Init{
 		Operators:             opsarr,
 		T:                     3,
@@ -128,11 +135,13 @@ func TestDKGInit(t *testing.T) {
 		Fork:                  [4]byte{0, 0, 0, 0},
 		Nonce:                 0,
 		Owner:                 common.HexToAddress("0x1234"),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
 	}
 	uid := crypto.NewID()
 	exch := map[uint64]*wire2.Transport{}
 
-	err = ts.ForAll(func(o *LocalOwner) error {
+	err := ts.ForAll(func(o *LocalOwner) error {
 		ts, err := o.Init(uid, init)
 		if err != nil {
 			t.Error(t, err)
@@ -157,3 +166,73 @@ func TestDKGInit(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestDKGInitMultipleValidators(t *testing.T) {
+	ts, opsarr := newTestState(t)
+	var mtx sync.Mutex
+	outputs := map[uint64]*wire2.MultipleResults{}
+	for _, op := range ts.ops {
+		id := op.ID
+		op.broadcastF = func(bytes []byte) error {
+			st := &wire2.SignedTransport{}
+			if err := st.UnmarshalSSZ(bytes); err != nil {
+				return err
+			}
+			if st.Message.Type == wire2.MultipleOutputMessageType {
+				res := &wire2.MultipleResults{}
+				if err := res.UnmarshalSSZ(st.Message.Data); err != nil {
+					return err
+				}
+				mtx.Lock()
+				outputs[id] = res
+				mtx.Unlock()
+				return nil
+			}
+			return ts.Broadcast(id, bytes)
+		}
+	}
+	init := &wire2.Init{
+		Operators:             opsarr,
+		T:                     3,
+		WithdrawalCredentials: common.HexToAddress("0x0000").Bytes(),
+		Fork:                  [4]byte{0, 0, 0, 0},
+		Nonce:                 0,
+		Owner:                 common.HexToAddress("0x1234"),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		Validators:            3,
+	}
+	uid := crypto.NewID()
+	exch := map[uint64]*wire2.Transport{}
+	err := ts.ForAll(func(o *LocalOwner) error {
+		ts, err := o.Init(uid, init)
+		if err != nil {
+			return err
+		}
+		exch[o.ID] = ts
+		return nil
+	})
+	require.NoError(t, err)
+	err = ts.ForAll(func(o *LocalOwner) error {
+		return o.Broadcast(exch[o.ID])
+	})
+	require.NoError(t, err)
+	err = ts.ForAll(func(o *LocalOwner) error {
+		<-o.done
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, outputs, 4)
+	// each protocol creates its own validator, all operators agree on it
+	validators := map[string]struct{}{}
+	for i := 0; i < 3; i++ {
+		pk := outputs[1].Results[i].SignedProof.Proof.ValidatorPubKey
+		validators[string(pk)] = struct{}{}
+		for id, out := range outputs {
+			require.Len(t, out.Results, 3)
+			require.Equal(t, id, out.Results[i].OperatorID)
+			require.Equal(t, pk, out.Results[i].SignedProof.Proof.ValidatorPubKey)
+		}
+	}
+	require.Len(t, validators, 3)
+}
@@ -0,0 +1,212 @@
+package dkg
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	kyber_dkg "github.com/drand/kyber/share/dkg"
+	drand_bls "github.com/drand/kyber/sign/bls" //nolint:all
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+)
+
+// InitReshare prepares LocalOwner for a resharing ceremony. Old operators recover their key share
+// of the previous ceremony from the proofs. All operators recover the public polynomial of the previous ceremony to verify the deals.
+// The resulting shares are signed the same way as at a new DKG ceremony for the new operators.
+func (o *LocalOwner) InitReshare(reqID [24]byte, reshareMsg *wire.ReshareMessage) (*wire.Transport, error) {
+	return o.initReshareSecret(reqID, reshareMsg, nil)
+}
+
+// InitTolerantReshare prepares LocalOwner for resharing key shares of a threshold tolerant ceremony which excluded some
+// of its operators to a registrable cluster of the remaining ones. Parameters of the ceremony init message are kept,
+// so the new key shares sign the deposit, owner nonce and voluntary exit the same way.
+func (o *LocalOwner) InitTolerantReshare(reqID [24]byte, reshareMsg *wire.ReshareMessage, tolerantInit *wire.Init) (*wire.Transport, error) {
+	return o.initReshareSecret(reqID, reshareMsg, tolerantInit)
+}
+
+// initReshareSecret initializes resharing instance with a new random secret and saves its state
+func (o *LocalOwner) initReshareSecret(reqID [24]byte, reshareMsg *wire.ReshareMessage, tolerantInit *wire.Init) (*wire.Transport, error) {
+	eciesSK, _ := initsecret(o.Suite)
+	resp, err := o.initReshare(reqID, reshareMsg, tolerantInit, eciesSK)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.saveState(ExchangePhase); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// initReshare recovers the data of the previous ceremony and initializes the instance with the secret. Init message
+// of a threshold tolerant ceremony is set only for resharing finishing it.
+func (o *LocalOwner) initReshare(reqID [24]byte, reshareMsg *wire.ReshareMessage, tolerantInit *wire.Init, secret kyber.Scalar) (*wire.Transport, error) {
+	reshare := reshareMsg.SignedReshare.Reshare
+	o.data = &DKGdata{reshare: reshareMsg, tolerantInit: tolerantInit}
+	suite := o.Suite.G1().(kyber_dkg.Suite)
+	ids := make([]uint64, len(reshare.OldOperators))
+	sharePubKeys := make([][]byte, len(reshare.OldOperators))
+	for i, op := range reshare.OldOperators {
+		ids[i] = op.ID
+		sharePubKeys[i] = reshareMsg.Proofs[i].Proof.SharePubKey
+	}
+	pubPoly, err := crypto.RecoverPubPoly(ids, sharePubKeys, int(reshare.OldT), suite)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public polynomial: %w", err)
+	}
+	validatorPubKey, err := pubPoly.Commit().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(validatorPubKey, reshare.ValidatorPubKey) {
+		return nil, fmt.Errorf("recovered validator public key doesn't match reshare message")
+	}
+	_, o.data.oldCommits = pubPoly.Info()
+	for i, op := range reshare.OldOperators {
+		if op.ID != o.ID {
+			continue
+		}
+		priShare, err := o.decryptSecretShare(reshareMsg.Proofs[i].Proof)
+		if err != nil {
+			return nil, err
+		}
+		o.data.secretShare = &kyber_dkg.DistKeyShare{
+			Commits: o.data.oldCommits,
+			Share:   priShare,
+		}
+	}
+	init := &wire.Init{
+		Operators:             reshare.NewOperators,
+		T:                     reshare.NewT,
+		WithdrawalCredentials: reshareMsg.WithdrawalCredentials,
+		Fork:                  reshareMsg.Fork,
+		Owner:                 reshare.Owner,
+		Nonce:                 reshare.Nonce,
+		WithdrawalPrefix:      reshareMsg.WithdrawalPrefix,
+		Amount:                reshareMsg.Amount,
+		PhaseTimeout:          reshareMsg.PhaseTimeout,
+	}
+	if tolerantInit != nil {
+		qualInit := *tolerantInit
+		qualInit.Operators = reshare.NewOperators
+		qualInit.T = reshare.NewT
+		qualInit.PhaseTimeout = reshareMsg.PhaseTimeout
+		qualInit.ThresholdTolerant = false
+		init = &qualInit
+	}
+	return o.init(reqID, init, secret)
+}
+
+// StartReshare initializes and starts resharing protocol. Dealing old operators deal their key shares to new operators.
+func (o *LocalOwner) StartReshare() error {
+	o.Logger.Info("Starting resharing")
+	reshare := o.data.reshare.SignedReshare.Reshare
+	oldNodes, err := o.GetDKGNodes(spec.ReshareDealers(&reshare))
+	if err != nil {
+		return err
+	}
+	newNodes, err := o.GetDKGNodes(reshare.NewOperators)
+	if err != nil {
+		return err
+	}
+	logger := o.Logger.With(zap.Uint64("ID", o.ID))
+	dkgConfig := &kyber_dkg.Config{
+		Longterm:     o.data.secret,
+		Nonce:        utils.GetNonce(o.data.reqID[:]),
+		Suite:        o.Suite.G1().(kyber_dkg.Suite),
+		NewNodes:     newNodes,
+		OldNodes:     oldNodes,
+		Threshold:    int(reshare.NewT),
+		OldThreshold: int(reshare.OldT),
+		Auth:         drand_bls.NewSchemeOnG2(o.Suite),
+		FastSync:     true,
+	}
+	// old operators deal their shares, new operators verify the deals against public polynomial of the previous ceremony
+	if o.data.secretShare != nil {
+		dkgConfig.Share = o.data.secretShare
+	} else {
+		dkgConfig.PublicCoeffs = o.data.oldCommits
+	}
+	if err := o.seedRandomness(dkgConfig, 0); err != nil {
+		return err
+	}
+	if err := o.saveState(DealPhase); err != nil {
+		return err
+	}
+	phaser := o.boards[0].NewPhaser(len(dkgConfig.OldNodes), len(dkgConfig.NewNodes), o.phaseTimeout())
+	p, err := wire.NewDKGProtocol(dkgConfig, o.boards[0], phaser, logger)
+	if err != nil {
+		return err
+	}
+	go func(p *kyber_dkg.Protocol, postF func(res *kyber_dkg.OptionResult) error) {
+		res := <-p.WaitEnd()
+		phaser.Stop()
+		if err := postF(&res); err != nil {
+			o.Logger.Error("Error in PostReshare function", zap.Error(err))
+			o.broadcastError(fmt.Errorf("operator ID:%d, err:%w", o.ID, err))
+		}
+	}(p, o.PostReshare)
+	close(o.startedDKG)
+	return nil
+}
+
+// PostReshare checks that the new key share corresponds to the same validator public key and
+// creates the Result structure to send back to initiator
+func (o *LocalOwner) PostReshare(res *kyber_dkg.OptionResult) error {
+	reshare := o.data.reshare.SignedReshare.Reshare
+	if spec.GetOperator(reshare.NewOperators, o.ID) == nil {
+		// operator leaving the cluster only deals its share, it doesn't receive a new one
+		o.Logger.Info("Resharing finished, operator is not a member of the new cluster")
+		o.finish()
+		return nil
+	}
+	if res.Error != nil {
+		return fmt.Errorf("resharing protocol failed: %w", res.Error)
+	}
+	validatorPubKey, err := crypto.ResultToValidatorPK(res.Result.Key, o.Suite.G1().(kyber_dkg.Suite))
+	if err != nil {
+		return fmt.Errorf("failed to get validator BLS public key: %w", err)
+	}
+	if !bytes.Equal(validatorPubKey.Serialize(), reshare.ValidatorPubKey) {
+		return fmt.Errorf("resharing resulted in a different validator public key %x", validatorPubKey.Serialize())
+	}
+	return o.PostDKG(0, res)
+}
+
+// decryptSecretShare decrypts operator's key share of the previous ceremony
+func (o *LocalOwner) decryptSecretShare(proof *wire.Proof) (*share.PriShare, error) {
+	decrypted, err := o.decryptFunc(proof.EncryptedShare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt share: %w", err)
+	}
+	secretKeyBLS := &bls.SecretKey{}
+	if err := secretKeyBLS.SetHexString(string(decrypted)); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted share: %w", err)
+	}
+	if !bytes.Equal(secretKeyBLS.GetPublicKey().Serialize(), proof.SharePubKey) {
+		return nil, fmt.Errorf("decrypted share doesn't match share public key at proof")
+	}
+	return crypto.ShareSecretKeyToPriShare(secretKeyBLS, o.ID, o.Suite.G1().(kyber_dkg.Suite))
+}
+
+// participants returns all operators participating in the ceremony. At resharing these are dealing old operators
+// and new operators.
+func (o *LocalOwner) participants() []*wire.Operator {
+	if o.data.reshare == nil {
+		return o.data.init.Operators
+	}
+	reshare := o.data.reshare.SignedReshare.Reshare
+	ops := append([]*wire.Operator{}, spec.ReshareDealers(&reshare)...)
+	for _, op := range reshare.NewOperators {
+		if spec.GetOperator(ops, op.ID) == nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,245 @@
+package dkg
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	kyber_dkg "github.com/drand/kyber/share/dkg"
+	"github.com/drand/kyber/xof/blake2xb"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// Phase of a DKG instance at operator
+type Phase uint8
+
+const (
+	// ExchangePhase instance is initialized and waits for exchange messages of other operators
+	ExchangePhase Phase = iota + 1
+	// DealPhase DKG protocol is started and the deals are sent
+	DealPhase
+	// FinishedPhase ceremony is finished, failed or cancelled, it isn't persisted
+	FinishedPhase
+)
+
+func (p Phase) String() string {
+	switch p {
+	case ExchangePhase:
+		return "exchange"
+	case DealPhase:
+		return "deal"
+	case FinishedPhase:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// State is a snapshot of LocalOwner which is enough to restore the instance after operator restart
+type State struct {
+	Phase Phase `json:"phase"`
+	// SSZ encoded init message, set for DKG ceremony and for resharing finishing a threshold tolerant ceremony
+	Init []byte `json:"init,omitempty"`
+	// SSZ encoded reshare message, set for resharing ceremony
+	Reshare []byte `json:"reshare,omitempty"`
+	// instance secret scalar encrypted with operator's RSA public key
+	Secret []byte `json:"secret"`
+	// SSZ encoded exchange messages received from operators
+	Exchanges map[uint64][]byte `json:"exchanges,omitempty"`
+}
+
+// State creates a snapshot of the instance at the phase
+func (o *LocalOwner) State(phase Phase) (*State, error) {
+	secret, err := o.data.secret.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	encSecret, err := o.encryptFunc(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt instance secret: %w", err)
+	}
+	st := &State{
+		Phase:     phase,
+		Secret:    encSecret,
+		Exchanges: make(map[uint64][]byte, len(o.exchanges)),
+	}
+	if o.data.reshare != nil {
+		st.Reshare, err = o.data.reshare.MarshalSSZ()
+		if err == nil && o.data.tolerantInit != nil {
+			st.Init, err = o.data.tolerantInit.MarshalSSZ()
+		}
+	} else {
+		st.Init, err = o.data.init.MarshalSSZ()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for id, e := range o.exchanges {
+		bts, err := e.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		st.Exchanges[id] = bts
+	}
+	return st, nil
+}
+
+// Info describes the ceremony of an instance
+type Info struct {
+	Reshare   bool             // resharing ceremony
+	Owner     [20]byte         // validator owner
+	Nonce     uint64           // owner nonce of the first validator
+	Operators []*wire.Operator // all operators participating in the ceremony
+	Phase     Phase            // current phase of the instance
+}
+
+// Info returns the ceremony description of the instance
+func (o *LocalOwner) Info() *Info {
+	info := &Info{Operators: o.participants(), Phase: o.CurrentPhase()}
+	if o.data.reshare != nil {
+		reshare := o.data.reshare.SignedReshare.Reshare
+		info.Reshare = true
+		info.Owner = reshare.Owner
+		info.Nonce = reshare.Nonce
+	} else {
+		info.Owner = o.data.init.Owner
+		info.Nonce = o.data.init.Nonce
+	}
+	return info
+}
+
+// CurrentPhase returns the phase the instance is at
+func (o *LocalOwner) CurrentPhase() Phase {
+	select {
+	case <-o.done:
+		return FinishedPhase
+	default:
+	}
+	if o.isStarted() || o.restoredPhase == DealPhase {
+		return DealPhase
+	}
+	return ExchangePhase
+}
+
+// saveState persists the instance state if a store is set
+func (o *LocalOwner) saveState(phase Phase) error {
+	if o.saveStateFunc == nil {
+		return nil
+	}
+	st, err := o.State(phase)
+	if err != nil {
+		return fmt.Errorf("failed to create instance state: %w", err)
+	}
+	if err := o.saveStateFunc(st); err != nil {
+		return fmt.Errorf("failed to save instance state: %w", err)
+	}
+	return nil
+}
+
+// Restore recreates LocalOwner from the persisted state. Initiator retries the pending phase at a restored instance:
+// exchange messages start DKG protocol again with the same deals, kyber messages resume the protocol without
+// sending the deals again, as other operators received them before the restart. Exchange messages received
+// before the restart are pinned, initiator can't replace them.
+func Restore(opts *OwnerOpts, reqID [24]byte, st *State) (*LocalOwner, error) {
+	o := New(opts)
+	decrypted, err := o.decryptFunc(st.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt instance secret: %w", err)
+	}
+	secret := o.Suite.G1().Scalar()
+	if err := secret.UnmarshalBinary(decrypted); err != nil {
+		return nil, fmt.Errorf("failed to parse instance secret: %w", err)
+	}
+	var init *wire.Init
+	if st.Init != nil {
+		init = &wire.Init{}
+		if err := init.UnmarshalSSZ(st.Init); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal init message: %w", err)
+		}
+	}
+	if st.Reshare != nil {
+		reshare := &wire.ReshareMessage{}
+		if err := reshare.UnmarshalSSZ(st.Reshare); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reshare message: %w", err)
+		}
+		_, err = o.initReshare(reqID, reshare, init, secret)
+	} else {
+		if init == nil {
+			return nil, fmt.Errorf("missing init message")
+		}
+		_, err = o.init(reqID, init, secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for id, bts := range st.Exchanges {
+		e := &wire.Exchange{}
+		if err := e.UnmarshalSSZ(bts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal exchange message of operator %d: %w", id, err)
+		}
+		o.restoredExchanges[id] = e
+	}
+	o.restoredPhase = st.Phase
+	o.Logger.Info("Restored instance", zap.String("phase", st.Phase.String()), zap.Int("exchanges", len(o.restoredExchanges)))
+	return o, nil
+}
+
+// resumeDKG starts DKG protocol of an instance restored after the deals were sent. Initiator already relayed
+// the deals to other operators, so the instance doesn't send them again.
+func (o *LocalOwner) resumeDKG() error {
+	if o.restoredPhase != DealPhase || o.isStarted() {
+		return nil
+	}
+	o.Logger.Info("Resuming DKG protocol of the restored instance")
+	for id, e := range o.restoredExchanges {
+		o.exchanges[id] = e
+	}
+	o.skipDeals = true
+	return o.start()
+}
+
+// checkRestoredExchange checks that the exchange message is the same as the one received from the operator before restart
+func (o *LocalOwner) checkRestoredExchange(from uint64, e *wire.Exchange) error {
+	prev, ok := o.restoredExchanges[from]
+	if ok && (!bytes.Equal(prev.PK, e.PK) || !bytes.Equal(prev.Commits, e.Commits)) {
+		return fmt.Errorf("exchange message of operator %d differs from the one received before restart", from)
+	}
+	return nil
+}
+
+// seededSuite derives randomness of the DKG polynomial from a seed
+type seededSuite struct {
+	kyber_dkg.Suite
+	seed []byte
+}
+
+func (s *seededSuite) RandomStream() cipher.Stream {
+	return blake2xb.New(s.seed)
+}
+
+// seedRandomness derives randomness of DKG protocol creating the validator at the index from the instance secret,
+// so an instance restored after operator restart creates the same polynomial and deals as before
+func (o *LocalOwner) seedRandomness(c *kyber_dkg.Config, index int) error {
+	secret, err := o.data.secret.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	c.Suite = &seededSuite{Suite: c.Suite, seed: deriveSeed(secret, "polynomial", index)}
+	// the seed is secret and random, so the secret coefficient is picked only from it
+	c.Reader = blake2xb.New(deriveSeed(secret, "secret coefficient", index))
+	c.UserReaderOnly = true
+	return nil
+}
+
+func deriveSeed(secret []byte, label string, index int) []byte {
+	h := sha256.New()
+	h.Write([]byte("ssv-dkg " + label))
+	h.Write(secret)
+	// protocols of a multi-validator ceremony share the instance secret, the index keeps their keys independent
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(index)))
+	return h.Sum(nil)
+}
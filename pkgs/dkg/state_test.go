@@ -0,0 +1,302 @@
+package dkg
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	kyber_bls "github.com/drand/kyber-bls12381"
+	kyber_dkg "github.com/drand/kyber/share/dkg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// restoreTestOperator runs LocalOwner the way Switch does: broadcasted messages are collected for initiator
+// and the last saved state is kept to restore the instance
+type restoreTestOperator struct {
+	opts  *OwnerOpts
+	owner *LocalOwner
+	out   chan *wire.SignedTransport
+	state *State
+}
+
+func newRestoreTestOperator(t *testing.T, id uint64, initiatorPk *rsa.PublicKey) *restoreTestOperator {
+	pv, pk, err := crypto.GenerateRSAKeys()
+	require.NoError(t, err)
+	logger, _ := zap.NewDevelopment()
+	op := &restoreTestOperator{}
+	op.opts = &OwnerOpts{
+		Logger: logger.With(zap.Uint64("id", id)),
+		ID:     id,
+		Suite:  kyber_bls.NewBLS12381Suite(),
+		Signer: spec.RSASigner(pv),
+		EncryptFunc: func(d []byte) ([]byte, error) {
+			return rsa.EncryptPKCS1v15(rand.Reader, pk, d)
+		},
+		DecryptFunc: func(d []byte) ([]byte, error) {
+			return rsaencryption.DecodeKey(pv, d)
+		},
+		InitiatorPublicKey: initiatorPk,
+		OperatorPublicKey:  pk,
+		SaveStateFunc: func(st *State) error {
+			op.state = st
+			return nil
+		},
+		DeleteStateFunc: func() error {
+			op.state = nil
+			return nil
+		},
+	}
+	op.resetBroadcast()
+	op.owner = New(op.opts)
+	return op
+}
+
+// resetBroadcast collects messages to a new channel, so messages of the instance before restart are dropped
+func (op *restoreTestOperator) resetBroadcast() {
+	out := make(chan *wire.SignedTransport, 10)
+	op.out = out
+	op.opts.BroadcastF = func(bts []byte) error {
+		st := &wire.SignedTransport{}
+		if err := st.UnmarshalSSZ(bts); err != nil {
+			return err
+		}
+		out <- st
+		return nil
+	}
+}
+
+func (op *restoreTestOperator) read(t *testing.T, timeout time.Duration) *wire.SignedTransport {
+	select {
+	case st := <-op.out:
+		return st
+	case <-time.After(timeout):
+		require.FailNow(t, "operator didn't respond", "operator %d", op.owner.ID)
+		return nil
+	}
+}
+
+func (op *restoreTestOperator) restore(t *testing.T, reqID [24]byte) {
+	require.NotNil(t, op.state)
+	op.resetBroadcast()
+	owner, err := Restore(op.opts, reqID, op.state)
+	require.NoError(t, err)
+	op.owner = owner
+}
+
+func startRestoreTestCeremony(t *testing.T) ([24]byte, []*restoreTestOperator, []*wire.SignedTransport) {
+	_, initiatorPk, err := crypto.GenerateRSAKeys()
+	require.NoError(t, err)
+	var ops []*restoreTestOperator
+	var operators []*wire.Operator
+	for id := uint64(1); id <= 4; id++ {
+		op := newRestoreTestOperator(t, id, initiatorPk)
+		pkBytes, err := crypto.EncodeRSAPublicKey(op.opts.OperatorPublicKey)
+		require.NoError(t, err)
+		ops = append(ops, op)
+		operators = append(operators, &wire.Operator{ID: id, PubKey: pkBytes})
+	}
+	init := &wire.Init{
+		Operators:             operators,
+		T:                     3,
+		WithdrawalCredentials: common.HexToAddress("0x1234").Bytes(),
+		Fork:                  [4]byte{0, 0, 0, 0},
+		Owner:                 common.HexToAddress("0x1234"),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	reqID := crypto.NewID()
+	var exchanges []*wire.SignedTransport
+	for _, op := range ops {
+		exch, err := op.owner.Init(reqID, init)
+		require.NoError(t, err)
+		require.NoError(t, op.owner.Broadcast(exch))
+		exchanges = append(exchanges, op.read(t, time.Second))
+		require.Equal(t, ExchangePhase, op.state.Phase)
+	}
+	return reqID, ops, exchanges
+}
+
+func dealBundlePublic(t *testing.T, st *wire.SignedTransport) []byte {
+	require.Equal(t, wire.KyberMessageType, st.Message.Type)
+	kyberMsg := &wire.KyberMessage{}
+	require.NoError(t, kyberMsg.UnmarshalSSZ(st.Message.Data))
+	require.Equal(t, wire.KyberDealBundleMessageType, kyberMsg.Type)
+	b, err := wire.DecodeDealBundle(kyberMsg.Data, kyber_bls.NewBLS12381Suite().G1().(kyber_dkg.Suite))
+	require.NoError(t, err)
+	var public []byte
+	for _, p := range b.Public {
+		bts, err := p.MarshalBinary()
+		require.NoError(t, err)
+		public = append(public, bts...)
+	}
+	return public
+}
+
+func TestRestoreDealsAreTheSame(t *testing.T) {
+	reqID, ops, exchanges := startRestoreTestCeremony(t)
+	op := ops[0]
+	for _, exch := range exchanges {
+		require.NoError(t, op.owner.Process(exch))
+	}
+	deal := op.read(t, time.Second)
+	require.Equal(t, DealPhase, op.state.Phase)
+	require.Len(t, op.state.Exchanges, 4)
+
+	// operator restarts, initiator retries the exchange phase
+	op.restore(t, reqID)
+	for _, exch := range exchanges {
+		require.NoError(t, op.owner.Process(exch))
+	}
+	restoredDeal := op.read(t, time.Second)
+	require.Equal(t, dealBundlePublic(t, deal), dealBundlePublic(t, restoredDeal))
+	require.ErrorIs(t, op.owner.Process(exchanges[1]), ErrAlreadyExists)
+}
+
+func TestRestoreRejectsDifferentExchange(t *testing.T) {
+	reqID, ops, exchanges := startRestoreTestCeremony(t)
+	for _, exch := range exchanges {
+		require.NoError(t, ops[0].owner.Process(exch))
+	}
+	ops[0].read(t, time.Second)
+	ops[0].restore(t, reqID)
+	// operator 2 sends a different exchange message after restart of operator 1
+	exch, err := ops[1].owner.Init(reqID, ops[1].owner.data.init)
+	require.NoError(t, err)
+	require.NoError(t, ops[1].owner.Broadcast(exch))
+	require.ErrorContains(t, ops[0].owner.Process(ops[1].read(t, time.Second)), "differs from the one received before restart")
+}
+
+func TestRestoreResumesDKG(t *testing.T) {
+	reqID, ops, exchanges := startRestoreTestCeremony(t)
+	var deals []*wire.SignedTransport
+	for _, op := range ops {
+		for _, exch := range exchanges {
+			require.NoError(t, op.owner.Process(exch))
+		}
+		deals = append(deals, op.read(t, time.Second))
+	}
+	// operator 1 restarts after sending its deals, initiator retries the deal phase
+	ops[0].restore(t, reqID)
+	var responses []*wire.SignedTransport
+	for _, op := range ops {
+		for _, deal := range deals {
+			require.NoError(t, op.owner.Process(deal))
+		}
+		st := op.read(t, time.Second)
+		require.Equal(t, wire.KyberMessageType, st.Message.Type)
+		responses = append(responses, st)
+	}
+	// every operator waits for response bundles of the rest before finishing
+	for _, op := range ops {
+		for _, resp := range responses {
+			require.NoError(t, op.owner.Process(resp))
+		}
+	}
+	var validatorPubKey []byte
+	for _, op := range ops {
+		st := op.read(t, time.Minute)
+		require.Equal(t, wire.OutputMessageType, st.Message.Type, string(st.Message.Data))
+		res := &wire.Result{}
+		require.NoError(t, res.UnmarshalSSZ(st.Message.Data))
+		if validatorPubKey == nil {
+			validatorPubKey = res.SignedProof.Proof.ValidatorPubKey
+		}
+		require.Equal(t, validatorPubKey, res.SignedProof.Proof.ValidatorPubKey)
+		require.Nil(t, op.state)
+	}
+}
+
+func TestStartWithReceivedExchanges(t *testing.T) {
+	_, ops, exchanges := startRestoreTestCeremony(t)
+	op := ops[0]
+	// operator 4 didn't respond to init message
+	for _, exch := range exchanges[:3] {
+		require.NoError(t, op.owner.Process(exch))
+	}
+	require.ErrorContains(t, op.owner.StartWithReceivedExchanges(), "ceremony isn't threshold tolerant")
+	require.False(t, op.owner.isStarted())
+
+	op.owner.data.init.ThresholdTolerant = true
+	require.NoError(t, op.owner.StartWithReceivedExchanges())
+	require.True(t, op.owner.isStarted())
+	st := op.read(t, time.Second)
+	require.Equal(t, wire.KyberMessageType, st.Message.Type)
+}
+
+func TestInstanceInfoAndCancel(t *testing.T) {
+	_, ops, exchanges := startRestoreTestCeremony(t)
+	op := ops[0]
+	info := op.owner.Info()
+	require.False(t, info.Reshare)
+	require.Equal(t, common.HexToAddress("0x1234"), common.Address(info.Owner))
+	require.Len(t, info.Operators, 4)
+	require.Equal(t, ExchangePhase, info.Phase)
+	for _, exch := range exchanges {
+		require.NoError(t, op.owner.Process(exch))
+	}
+	op.read(t, time.Second)
+	require.Equal(t, DealPhase, op.owner.Info().Phase)
+
+	op.owner.Cancel()
+	require.Equal(t, FinishedPhase, op.owner.Info().Phase)
+	require.Nil(t, op.state)
+	st := op.read(t, time.Second)
+	require.Equal(t, wire.ErrorMessageType, st.Message.Type)
+	require.Contains(t, string(st.Message.Data), "ceremony is cancelled by the operator")
+}
+
+func TestComplaintIsSeenByAllOperators(t *testing.T) {
+	_, ops, exchanges := startRestoreTestCeremony(t)
+	var deals []*wire.SignedTransport
+	for i, op := range ops {
+		// operator 1 stops waiting for the missing deal long before the rest stop waiting for its response
+		op.owner.defaultPhaseTimeout = 2 * time.Second
+		if i == 0 {
+			op.owner.defaultPhaseTimeout = 200 * time.Millisecond
+		}
+		for _, exch := range exchanges {
+			require.NoError(t, op.owner.Process(exch))
+		}
+		deals = append(deals, op.read(t, time.Second))
+	}
+	// operator 1 doesn't get the deal of operator 4 and complains about it, the rest of operators have no complaints
+	var responses []*wire.SignedTransport
+	for i, op := range ops {
+		for j, deal := range deals {
+			if i == 0 && j == 3 {
+				continue
+			}
+			require.NoError(t, op.owner.Process(deal))
+		}
+		if i == 3 {
+			continue
+		}
+		responses = append(responses, op.read(t, 5*time.Second))
+	}
+	responses = append(responses, ops[3].read(t, 5*time.Second))
+	// operators without complaints don't finish before they get the complaint, so they agree on qualified dealers
+	for _, op := range ops {
+		for _, resp := range responses {
+			require.NoError(t, op.owner.Process(resp))
+		}
+	}
+	var validatorPubKey []byte
+	for _, op := range ops[:3] {
+		st := op.read(t, 5*time.Second)
+		require.Equal(t, wire.OutputMessageType, st.Message.Type, string(st.Message.Data))
+		res := &wire.Result{}
+		require.NoError(t, res.UnmarshalSSZ(st.Message.Data))
+		if validatorPubKey == nil {
+			validatorPubKey = res.SignedProof.Proof.ValidatorPubKey
+		}
+		require.Equal(t, validatorPubKey, res.SignedProof.Proof.ValidatorPubKey)
+	}
+}
@@ -0,0 +1,113 @@
+package initiator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// DefaultApprovalTimeout is the time initiator waits for operators approving a ceremony
+const DefaultApprovalTimeout = 3 * time.Minute
+
+// MaxApprovalTimeout limits the time initiator waits for operators approving a ceremony. Operators drop ceremonies
+// waiting for approval 5 minutes after they receive them, the initiator should give up before.
+const MaxApprovalTimeout = 4 * time.Minute
+
+// approvalPollInterval is the delay before a message is repeated to operators waiting for approval
+var approvalPollInterval = 3 * time.Second
+
+// sendApprovalPhase sends the message starting a ceremony to operators. Operators which wait for manual approval of
+// the ceremony get the message again until they join the ceremony or the approval timeout passes.
+func (c *Initiator) sendApprovalPhase(ctx context.Context, j *Journal, responses map[uint64][]byte, id [24]byte, method string, msg []byte, operators []*wire.Operator) error {
+	timeout, err := c.approvalTimeout()
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := c.sendPhase(ctx, j, responses, id, method, msg, operators)
+		if err == nil || !pendingApproval(err) {
+			return err
+		}
+		if time.Now().Add(approvalPollInterval).After(deadline) {
+			return fmt.Errorf("ceremony wasn't approved by operators in %s: %w", timeout, err)
+		}
+		c.Logger.Info("⏸️ ceremony is waiting for approval of operators", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(approvalPollInterval):
+		}
+	}
+}
+
+// sendSignRequest sends the sign request to operators and returns responses and errors per operator ID. Operators
+// which wait for manual approval of the request get it again until they respond or the approval timeout passes.
+func (c *Initiator) sendSignRequest(ctx context.Context, msg []byte, operators []*wire.Operator, timeout time.Duration) (map[uint64][]byte, map[uint64]error) {
+	deadline := time.Now().Add(timeout)
+	results := make(map[uint64][]byte, len(operators))
+	errs := make(map[uint64]error)
+	for {
+		res, opErrs := c.SendToAllTolerant(ctx, consts.API_SIGN_URL, msg, operators)
+		var waiting []*wire.Operator
+		for _, op := range operators {
+			if r, ok := res[op.ID]; ok {
+				results[op.ID] = r
+				delete(errs, op.ID)
+				continue
+			}
+			errs[op.ID] = opErrs[op.ID]
+			if wire.IsPendingApproval(opErrs[op.ID]) {
+				waiting = append(waiting, op)
+			}
+		}
+		if len(waiting) == 0 || time.Now().Add(approvalPollInterval).After(deadline) {
+			return results, errs
+		}
+		c.Logger.Info("⏸️ sign request is waiting for approval of operators", zap.Uint64s("operator IDs", operatorIDs(waiting)))
+		select {
+		case <-ctx.Done():
+			return results, errs
+		case <-time.After(approvalPollInterval):
+		}
+		operators = waiting
+	}
+}
+
+// approvalTimeout returns the time to wait for operators approving a ceremony
+func (c *Initiator) approvalTimeout() (time.Duration, error) {
+	if err := validateApprovalTimeout(c.ApprovalTimeout); err != nil {
+		return 0, err
+	}
+	if c.ApprovalTimeout == 0 {
+		return DefaultApprovalTimeout, nil
+	}
+	return c.ApprovalTimeout, nil
+}
+
+// validateApprovalTimeout checks that operators keep a ceremony waiting for approval longer than initiator waits for it
+func validateApprovalTimeout(timeout time.Duration) error {
+	if timeout < 0 || timeout > MaxApprovalTimeout {
+		return fmt.Errorf("approval timeout %s should be positive and not longer than %s", timeout, MaxApprovalTimeout)
+	}
+	return nil
+}
+
+// pendingApproval checks that every operator failed the phase because the ceremony is waiting for its approval
+func pendingApproval(err error) bool {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return wire.IsPendingApproval(err)
+	}
+	for _, opErr := range joined.Unwrap() {
+		if !wire.IsPendingApproval(opErr) {
+			return false
+		}
+	}
+	return true
+}
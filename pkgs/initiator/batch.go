@@ -0,0 +1,434 @@
+package initiator
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/validator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+)
+
+// defaultConcurrency is the maximum number of DKG ceremonies of a batch running concurrently
+const defaultConcurrency = 20
+
+// BatchRequest is a request to create validators of one owner with the same cluster of operators.
+// Validators are split between DKG ceremonies creating several validators each, owner nonces are assigned
+// incrementally starting at Nonce.
+type BatchRequest struct {
+	OperatorIDs      []uint64
+	Validators       int
+	Owner            common.Address
+	Nonce            uint64 // owner nonce of the first validator
+	WithdrawAddress  common.Address
+	WithdrawPubKey   []byte      // BLS withdrawal public key of 0x00 withdrawal credentials, used instead of WithdrawAddress
+	WithdrawalPrefix byte        // withdrawal credentials type: 0x00 (BLS), 0x01 or 0x02 (compounding)
+	Amount           phase0.Gwei // deposit amount of each validator
+	Network          eth2_key_manager_core.Network
+	Exit             *ExitRequest // pre-sign voluntary exits if set, validator indices increment from Exit.ValidatorIndex by nonce
+	ID               [24]byte     // ID of a batch run by one ceremony, e.g. to sign its init message by the owner beforehand; random if zero
+}
+
+// withdrawal returns the BLS withdrawal public key for 0x00 withdrawal prefix and the withdrawal address otherwise
+func (r *BatchRequest) withdrawal() []byte {
+	if r.WithdrawalPrefix == crypto.BLSWithdrawalPrefixByte {
+		return r.WithdrawPubKey
+	}
+	return r.WithdrawAddress.Bytes()
+}
+
+// Validate checks the request before any ceremony is started
+func (r *BatchRequest) Validate(operators wire.OperatorsCLI) error {
+	if r.Validators < 1 {
+		return fmt.Errorf("amount of validators should be at least 1")
+	}
+	if eth2_key_manager_core.NetworkFromString(string(r.Network)) == "" {
+		return fmt.Errorf("unsupported network: %s", r.Network)
+	}
+	if r.Owner == (common.Address{}) {
+		return fmt.Errorf("owner address is not set")
+	}
+	if r.WithdrawalPrefix != crypto.BLSWithdrawalPrefixByte && r.WithdrawAddress == (common.Address{}) {
+		return fmt.Errorf("withdrawal address is not set")
+	}
+	if _, err := crypto.WithdrawalCredentials(r.WithdrawalPrefix, r.withdrawal()); err != nil {
+		return err
+	}
+	if err := crypto.ValidateDepositAmount(r.WithdrawalPrefix, r.Amount); err != nil {
+		return err
+	}
+	_, err := ValidatedOperatorData(r.OperatorIDs, operators)
+	return err
+}
+
+// CeremonyResult is the outcome of a DKG ceremony for one validator. Validators created by the same ceremony share its ID.
+type CeremonyResult struct {
+	ID                    [24]byte
+	Owner                 common.Address
+	Nonce                 uint64
+	WithdrawalCredentials []byte // 32 bytes withdrawal credentials of the deposit data
+	Amount                phase0.Gwei
+	DepositData           *wire.DepositDataCLI
+	KeyShares             *wire.KeySharesCLI
+	Proofs                []*wire.SignedProof
+	ExcludedOperators     []uint64                    // operators excluded from a threshold tolerant ceremony
+	Exit                  *phase0.SignedVoluntaryExit // pre-signed voluntary exit if requested
+}
+
+// CeremonyError is a failure of one ceremony of a batch
+type CeremonyError struct {
+	ID         [24]byte
+	Nonce      uint64 // owner nonce of the first validator of the ceremony
+	Validators int    // number of validators the ceremony was creating
+	Resumable  bool   // journal of the ceremony is kept, so it can be resumed
+	Err        error
+}
+
+func (e *CeremonyError) Error() string {
+	return fmt.Sprintf("ceremony %s, nonce %d: %s", hex.EncodeToString(e.ID[:]), e.Nonce, e.Err.Error())
+}
+
+func (e *CeremonyError) Unwrap() error {
+	return e.Err
+}
+
+// BatchResult holds results of finished ceremonies of a batch and failures of the rest of them, both ordered by owner nonce
+type BatchResult struct {
+	Ceremonies []*CeremonyResult
+	Failed     []*CeremonyError
+}
+
+// ByCeremony splits results of the batch by ceremony, validators of each ceremony have consecutive owner nonces
+func (r BatchResult) ByCeremony() []BatchResult {
+	var res []BatchResult
+	for i, c := range r.Ceremonies {
+		if i == 0 || c.ID != r.Ceremonies[i-1].ID {
+			res = append(res, BatchResult{})
+		}
+		res[len(res)-1].Ceremonies = append(res[len(res)-1].Ceremonies, c)
+	}
+	return res
+}
+
+// DepositData returns deposit data of all validators of the batch
+func (r BatchResult) DepositData() []*wire.DepositDataCLI {
+	res := make([]*wire.DepositDataCLI, len(r.Ceremonies))
+	for i, c := range r.Ceremonies {
+		res[i] = c.DepositData
+	}
+	return res
+}
+
+// KeyShares returns key shares of all validators of the batch
+func (r BatchResult) KeyShares() []*wire.KeySharesCLI {
+	res := make([]*wire.KeySharesCLI, len(r.Ceremonies))
+	for i, c := range r.Ceremonies {
+		res[i] = c.KeyShares
+	}
+	return res
+}
+
+// VoluntaryExits returns pre-signed voluntary exits of the batch by validator public key as in deposit data
+func (r BatchResult) VoluntaryExits() map[string]*phase0.SignedVoluntaryExit {
+	res := make(map[string]*phase0.SignedVoluntaryExit)
+	for _, c := range r.Ceremonies {
+		if c.Exit != nil {
+			res[c.DepositData.PubKey] = c.Exit
+		}
+	}
+	return res
+}
+
+// Proofs returns proofs of all validators of the batch
+func (r BatchResult) Proofs() [][]*wire.SignedProof {
+	res := make([][]*wire.SignedProof, len(r.Ceremonies))
+	for i, c := range r.Ceremonies {
+		res[i] = c.Proofs
+	}
+	return res
+}
+
+// Ceremony runs DKG ceremonies with a set of operators. It's meant to embed the initiator in other services:
+// results are returned instead of being written to files. Each ceremony uses its own Initiator,
+// so several batches can run concurrently.
+type Ceremony struct {
+	Operators         wire.OperatorsCLI // operators which can participate in ceremonies
+	Logger            *zap.Logger       // nothing is logged if not set
+	Version           string            // initiator version sent to operators
+	ClientCACerts     []string          // paths to CA certificates of operators, TLS certificates aren't verified if empty
+	Journal           *JournalStore     // store of ceremony journals to resume failed ceremonies, not journaled if not set or PrivateKey isn't set
+	ThresholdTolerant bool              // finish ceremonies if at least threshold operators are responsive, excluding the rest
+	Concurrency       int               // maximum number of ceremonies running concurrently, 20 if not set
+	DKGPhaseTimeout   time.Duration     // fallback timeout of DKG protocol phases at operators, operators use their default if zero
+	PrivateKey        *rsa.PrivateKey   // initiator identity key, a new key is generated for each ceremony if not set
+	OwnerSigner       OwnerSigner       // signs init messages of ceremonies by the owner, init messages aren't signed if not set
+	ApprovalTimeout   time.Duration     // time to wait for operators approving a ceremony, DefaultApprovalTimeout if zero, up to MaxApprovalTimeout
+	// ValidatorsPerCeremony is the maximum number of validators created by one ceremony, spec.MaxCeremonyValidators if not set.
+	// Threshold tolerant ceremonies create one validator each.
+	ValidatorsPerCeremony int
+}
+
+// validatorsPerCeremony returns the number of validators created by one ceremony of a batch
+func (c *Ceremony) validatorsPerCeremony() int {
+	if c.ThresholdTolerant {
+		return 1
+	}
+	if c.ValidatorsPerCeremony <= 0 || c.ValidatorsPerCeremony > spec.MaxCeremonyValidators {
+		return spec.MaxCeremonyValidators
+	}
+	return c.ValidatorsPerCeremony
+}
+
+// newInitiator creates an initiator of one ceremony
+func (c *Ceremony) newInitiator() (*Initiator, error) {
+	logger := c.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	dkgInitiator, err := New(c.Operators.Clone(), logger, c.Version, c.ClientCACerts)
+	if err != nil {
+		return nil, err
+	}
+	dkgInitiator.ThresholdTolerant = c.ThresholdTolerant
+	dkgInitiator.DKGPhaseTimeout = c.DKGPhaseTimeout
+	// a ceremony is resumed with the initiator key it was started with, ceremonies of generated keys can't be resumed
+	if c.PrivateKey != nil {
+		dkgInitiator.PrivateKey = c.PrivateKey
+		dkgInitiator.Journal = c.Journal
+	}
+	dkgInitiator.OwnerSigner = c.OwnerSigner
+	dkgInitiator.ApprovalTimeout = c.ApprovalTimeout
+	return dkgInitiator, nil
+}
+
+// batchPart is the part of a batch created by one ceremony
+type batchPart struct {
+	nonce      uint64
+	validators int
+}
+
+// split splits validators of the request between ceremonies
+func (c *Ceremony) split(req BatchRequest) []batchPart {
+	perCeremony := c.validatorsPerCeremony()
+	var parts []batchPart
+	for i := 0; i < req.Validators; i += perCeremony {
+		validators := perCeremony
+		if rest := req.Validators - i; rest < validators {
+			validators = rest
+		}
+		parts = append(parts, batchPart{nonce: req.Nonce + uint64(i), validators: validators})
+	}
+	return parts
+}
+
+// setExit sets the voluntary exit request of the ceremony starting at the nonce
+func (c *Ceremony) setExit(dkgInitiator *Initiator, req BatchRequest, nonce uint64) {
+	if req.Exit == nil {
+		return
+	}
+	exit := *req.Exit
+	exit.ValidatorIndex += phase0.ValidatorIndex(nonce - req.Nonce)
+	dkgInitiator.Exit = &exit
+}
+
+// InitMessages returns init messages of ceremonies of the request ordered by owner nonce. The owner signs
+// hash tree root of each message when init messages are signed.
+func (c *Ceremony) InitMessages(req BatchRequest) ([]*wire.Init, error) {
+	if err := req.Validate(c.Operators); err != nil {
+		return nil, err
+	}
+	var inits []*wire.Init
+	for _, part := range c.split(req) {
+		dkgInitiator, err := c.newInitiator()
+		if err != nil {
+			return nil, err
+		}
+		c.setExit(dkgInitiator, req, part.nonce)
+		init, err := dkgInitiator.ConstructInit(req.withdrawal(), req.WithdrawalPrefix, req.Amount, req.OperatorIDs, req.Network, req.Owner, part.nonce, part.validators)
+		if err != nil {
+			return nil, err
+		}
+		inits = append(inits, init)
+	}
+	return inits, nil
+}
+
+// RunBatch creates validators of the request. A failed ceremony doesn't stop the rest of the batch, results of finished
+// ceremonies are returned together with failures of the rest of them joined into the error. Cancelling the context
+// cancels all ceremonies. Results of each ceremony are validated before they're returned.
+func (c *Ceremony) RunBatch(ctx context.Context, req BatchRequest) (BatchResult, error) {
+	if err := req.Validate(c.Operators); err != nil {
+		return BatchResult{}, err
+	}
+	if err := validateApprovalTimeout(c.ApprovalTimeout); err != nil {
+		return BatchResult{}, err
+	}
+	if req.ID != ([24]byte{}) && len(c.split(req)) != 1 {
+		return BatchResult{}, fmt.Errorf("ceremony ID can be set for a batch run by one ceremony only")
+	}
+	if err := c.preflight(ctx, req.OperatorIDs); err != nil {
+		return BatchResult{}, err
+	}
+	if c.Journal != nil && c.PrivateKey == nil && c.Logger != nil {
+		c.Logger.Warn("⚠️ Initiator private key isn't set, ceremonies are signed by one-time keys and aren't journaled, failed ceremonies can't be resumed")
+	}
+	withdrawalCredentials, err := crypto.WithdrawalCredentials(req.WithdrawalPrefix, req.withdrawal())
+	if err != nil {
+		return BatchResult{}, err
+	}
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	p := pool.NewWithResults[ceremonyOutcome]().WithMaxGoroutines(concurrency)
+	for _, part := range c.split(req) {
+		part := part
+		p.Go(func() ceremonyOutcome {
+			res, err := c.run(ctx, req, part.nonce, part.validators)
+			if err == nil {
+				err = validateBatch(BatchResult{Ceremonies: res}, req.Owner, part.nonce, withdrawalCredentials, req.Amount)
+			}
+			if err != nil {
+				return ceremonyOutcome{err: c.ceremonyError(req, part, err)}
+			}
+			return ceremonyOutcome{results: res}
+		})
+	}
+	var res BatchResult
+	for _, outcome := range p.Wait() {
+		if outcome.err != nil {
+			res.Failed = append(res.Failed, outcome.err)
+			continue
+		}
+		res.Ceremonies = append(res.Ceremonies, outcome.results...)
+	}
+	sort.Slice(res.Ceremonies, func(i, j int) bool { return res.Ceremonies[i].Nonce < res.Ceremonies[j].Nonce })
+	sort.Slice(res.Failed, func(i, j int) bool { return res.Failed[i].Nonce < res.Failed[j].Nonce })
+	if len(res.Failed) > 0 {
+		errs := make([]error, len(res.Failed))
+		for i, f := range res.Failed {
+			errs[i] = f
+		}
+		return res, errors.Join(errs...)
+	}
+	return res, nil
+}
+
+// ceremonyOutcome is either results or a failure of one ceremony of a batch
+type ceremonyOutcome struct {
+	results []*CeremonyResult
+	err     *CeremonyError
+}
+
+// ceremonyError describes a failed ceremony of the batch, the ceremony is resumable if its journal is kept
+func (c *Ceremony) ceremonyError(req BatchRequest, part batchPart, err error) *CeremonyError {
+	ceremonyErr := &CeremonyError{Nonce: part.nonce, Validators: part.validators, Err: err}
+	var runErr *CeremonyError
+	if errors.As(err, &runErr) {
+		ceremonyErr.ID = runErr.ID
+		ceremonyErr.Err = runErr.Err
+	}
+	if c.Journal != nil && ceremonyErr.ID != ([24]byte{}) {
+		_, loadErr := c.Journal.Load(ceremonyErr.ID)
+		ceremonyErr.Resumable = loadErr == nil
+	}
+	return ceremonyErr
+}
+
+// preflight checks operators of the batch before ceremonies start. Threshold tolerant batches start if at least
+// threshold operators passed the check and they are enough to register a cluster.
+func (c *Ceremony) preflight(ctx context.Context, ids []uint64) error {
+	dkgInitiator, err := c.newInitiator()
+	if err != nil {
+		return err
+	}
+	report := dkgInitiator.Preflight(ctx, ids)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	failed := report.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	if c.ThresholdTolerant {
+		threshold, err := utils.GetThreshold(ids)
+		if err != nil {
+			return err
+		}
+		passed := len(report) - len(failed)
+		if passed >= threshold && spec.RegistrableClusterSize(passed) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("preflight check failed: %w", report.Err())
+}
+
+// run runs a DKG ceremony creating validators of the batch starting at the nonce
+func (c *Ceremony) run(ctx context.Context, req BatchRequest, nonce uint64, validators int) ([]*CeremonyResult, error) {
+	dkgInitiator, err := c.newInitiator()
+	if err != nil {
+		return nil, err
+	}
+	c.setExit(dkgInitiator, req, nonce)
+	id := req.ID
+	if id == ([24]byte{}) {
+		id = crypto.NewID()
+	}
+	res, err := dkgInitiator.StartBatchDKG(ctx, id, req.withdrawal(), req.WithdrawalPrefix, req.Amount, req.OperatorIDs, req.Network, req.Owner, nonce, validators)
+	if err != nil {
+		return nil, &CeremonyError{ID: id, Nonce: nonce, Validators: validators, Err: err}
+	}
+	return res, nil
+}
+
+// Resume continues a failed ceremony from its journal, returning all validators created by the ceremony
+func (c *Ceremony) Resume(ctx context.Context, id [24]byte) (BatchResult, error) {
+	if c.Journal == nil {
+		return BatchResult{}, fmt.Errorf("journal store is not set")
+	}
+	if c.PrivateKey == nil {
+		return BatchResult{}, fmt.Errorf("initiator private key the ceremony was started with is not set")
+	}
+	dkgInitiator, err := c.newInitiator()
+	if err != nil {
+		return BatchResult{}, err
+	}
+	ceremonies, err := dkgInitiator.ResumeBatchDKG(ctx, id)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	res := BatchResult{Ceremonies: ceremonies}
+	first := ceremonies[0]
+	if err := validateBatch(res, first.Owner, first.Nonce, first.WithdrawalCredentials, first.Amount); err != nil {
+		return BatchResult{}, err
+	}
+	return res, nil
+}
+
+// validateBatch checks that validators of the batch are unique and belong to the owner with consecutive nonces
+func validateBatch(res BatchResult, owner common.Address, nonce uint64, withdrawalCredentials []byte, amount phase0.Gwei) error {
+	if len(res.Ceremonies) == 0 {
+		return errors.New("no ceremony results")
+	}
+	keyShares := &wire.KeySharesCLI{
+		Version:   res.Ceremonies[0].KeyShares.Version,
+		CreatedAt: res.Ceremonies[0].KeyShares.CreatedAt,
+	}
+	for _, c := range res.Ceremonies {
+		keyShares.Shares = append(keyShares.Shares, c.KeyShares.Shares...)
+	}
+	return validator.ValidateResults(res.DepositData(), keyShares, res.Proofs(), len(res.Ceremonies), owner, nonce, withdrawalCredentials, amount)
+}
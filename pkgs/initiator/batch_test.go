@@ -0,0 +1,97 @@
+package initiator_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+)
+
+func TestBatchRequestValidate(t *testing.T) {
+	ops := generateOperators([]uint64{1, 2, 3, 4})
+	valid := func() initiator.BatchRequest {
+		return initiator.BatchRequest{
+			OperatorIDs:      []uint64{1, 2, 3, 4},
+			Validators:       10,
+			Owner:            common.HexToAddress("0x0000001"),
+			WithdrawAddress:  common.HexToAddress("0x0000002"),
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           crypto.MaxEffectiveBalanceInGwei,
+			Network:          e2m_core.MainNetwork,
+		}
+	}
+	req := valid()
+	require.NoError(t, req.Validate(ops))
+	// batches larger than a ceremony are split between ceremonies
+	req.Validators = 1000
+	require.NoError(t, req.Validate(ops))
+	withdrawPubKey, err := hex.DecodeString("8d176708b908f288cc0e9d43f75674e73c0db94026822c5ce2c3e0f9e773c9ee95fdba824302f1208c225b0ed2d54154")
+	require.NoError(t, err)
+	req = valid()
+	req.WithdrawAddress = common.Address{}
+	req.WithdrawPubKey = withdrawPubKey
+	req.WithdrawalPrefix = crypto.BLSWithdrawalPrefixByte
+	require.NoError(t, req.Validate(ops))
+	tests := []struct {
+		name   string
+		modify func(r *initiator.BatchRequest)
+		err    string
+	}{
+		{"no validators", func(r *initiator.BatchRequest) { r.Validators = 0 }, "amount of validators should be at least 1"},
+		{"unknown network", func(r *initiator.BatchRequest) { r.Network = "devnet" }, "unsupported network"},
+		{"no owner", func(r *initiator.BatchRequest) { r.Owner = common.Address{} }, "owner address is not set"},
+		{"no withdrawal address", func(r *initiator.BatchRequest) { r.WithdrawAddress = common.Address{} }, "withdrawal address is not set"},
+		{"no withdrawal public key", func(r *initiator.BatchRequest) { r.WithdrawalPrefix = crypto.BLSWithdrawalPrefixByte }, "incorrect withdrawal public key length 0"},
+		{"unsupported withdrawal prefix", func(r *initiator.BatchRequest) { r.WithdrawalPrefix = 3 }, "unsupported withdrawal prefix 0x3"},
+		{"amount above 0x01 max", func(r *initiator.BatchRequest) { r.Amount = 64000000000 }, "deposit amount 64000000000 is out of range"},
+		{"amount above 0x02 max", func(r *initiator.BatchRequest) {
+			r.WithdrawalPrefix = crypto.CompoundingWithdrawalPrefixByte
+			r.Amount = crypto.MaxEffectiveBalanceElectraInGwei + 1
+		}, "deposit amount 2048000000001 is out of range"},
+		{"unknown operator", func(r *initiator.BatchRequest) { r.OperatorIDs = []uint64{1, 2, 3, 5} }, "operator is not in given operator data list"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := valid()
+			test.modify(&req)
+			require.ErrorContains(t, req.Validate(ops), test.err)
+		})
+	}
+}
+
+func TestCeremonyInitMessages(t *testing.T) {
+	ceremony := &initiator.Ceremony{
+		Operators:             generateOperators([]uint64{1, 2, 3, 4}),
+		ValidatorsPerCeremony: 40,
+	}
+	req := initiator.BatchRequest{
+		OperatorIDs:      []uint64{1, 2, 3, 4},
+		Validators:       100,
+		Owner:            common.HexToAddress("0x0000001"),
+		Nonce:            5,
+		WithdrawAddress:  common.HexToAddress("0x0000002"),
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           crypto.MaxEffectiveBalanceInGwei,
+		Network:          e2m_core.MainNetwork,
+		Exit:             &initiator.ExitRequest{Epoch: 10, ValidatorIndex: 1000},
+	}
+	inits, err := ceremony.InitMessages(req)
+	require.NoError(t, err)
+	require.Len(t, inits, 3)
+	for i, validators := range []uint64{40, 40, 20} {
+		require.Equal(t, req.Owner, common.Address(inits[i].Owner))
+		require.Equal(t, uint64(5+40*i), inits[i].Nonce)
+		require.Equal(t, validators, inits[i].Validators)
+		require.EqualValues(t, 3, inits[i].T)
+		require.True(t, inits[i].SignExit)
+		require.Equal(t, uint64(1000+40*i), inits[i].ExitValidatorIndex)
+	}
+	req.Validators = 0
+	_, err = ceremony.InitMessages(req)
+	require.ErrorContains(t, err, "amount of validators should be at least 1")
+}
@@ -0,0 +1,40 @@
+package initiator
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// ExitRequest is a request to pre-sign a voluntary exit of the validator during the DKG ceremony.
+// The exit is valid only if the validator gets ValidatorIndex at the beacon chain after the deposit.
+type ExitRequest struct {
+	Epoch                 phase0.Epoch          // earliest epoch the exit can be processed at
+	ValidatorIndex        phase0.ValidatorIndex // expected index of the validator
+	ForkVersion           phase0.Version        // fork version of the signing domain, Capella fork version of the network if not set
+	GenesisValidatorsRoot phase0.Root           // genesis validators root of the network if not set
+}
+
+// setInit adds the voluntary exit request to the init message
+func (r *ExitRequest) setInit(init *wire.Init, network eth2_key_manager_core.Network) error {
+	forkVersion := r.ForkVersion
+	if forkVersion == (phase0.Version{}) {
+		var err error
+		forkVersion, err = crypto.ExitForkVersion(network)
+		if err != nil {
+			return err
+		}
+	}
+	genesisValidatorsRoot := r.GenesisValidatorsRoot
+	if genesisValidatorsRoot == (phase0.Root{}) {
+		genesisValidatorsRoot = network.GenesisValidatorsRoot()
+	}
+	init.SignExit = true
+	init.ExitEpoch = uint64(r.Epoch)
+	init.ExitValidatorIndex = uint64(r.ValidatorIndex)
+	init.ExitForkVersion = forkVersion
+	init.GenesisValidatorsRoot = genesisValidatorsRoot
+	return nil
+}
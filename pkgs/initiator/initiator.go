@@ -2,6 +2,7 @@ package initiator
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"crypto/tls"
 	"encoding/hex"
@@ -27,14 +28,42 @@ import (
 
 type VerifyMessageSignatureFunc func(pub *rsa.PublicKey, msg, sig []byte) error
 
+// OwnerSigner returns owner signature over spec.InitSigningRoot of the init message of the ceremony with the ID:
+// an ECDSA signature of an EOA owner or a signature accepted by EIP-1271 isValidSignature of a smart contract wallet
+type OwnerSigner func(id [24]byte, init *wire.Init) ([]byte, error)
+
+// retry parameters of requests to operators
+const (
+	defaultRetries      = 3
+	defaultRetryBackoff = time.Second
+	// defaultPhaseTimeout is a deadline of a ceremony phase including retries of requests to operators
+	defaultPhaseTimeout = time.Minute
+	// defaultRequestTimeout is a timeout of one request to an operator
+	defaultRequestTimeout = 30 * time.Second
+)
+
 // Initiator main structure for initiator
 type Initiator struct {
-	Logger                 *zap.Logger                // logger
-	Client                 *req.Client                // http client
-	Operators              wire.OperatorsCLI          // operators info mapping
-	VerifyMessageSignature VerifyMessageSignatureFunc // function to verify signatures of incoming messages
-	PrivateKey             *rsa.PrivateKey            // a unique initiator's RSA private key used for signing messages and identity
-	Version                []byte
+	Logger                 *zap.Logger                 // logger
+	Client                 *req.Client                 // http client
+	Operators              wire.OperatorsCLI           // operators info mapping
+	VerifyMessageSignature VerifyMessageSignatureFunc  // function to verify signatures of incoming messages
+	PrivateKey             *rsa.PrivateKey             // initiator's RSA private key used for signing messages and identity, generated by New unless a persistent key is set
+	Version                []byte                      // release version of the initiator
+	Versions               wire.VersionRange           // protocol versions supported by the initiator, the highest one supported by all operators is used
+	ThresholdTolerant      bool                        // finish DKG ceremony if at least threshold operators are responsive, excluding the rest
+	ExcludedOperators      []uint64                    // IDs of operators excluded from the last threshold tolerant DKG ceremony
+	Journal                *JournalStore               // store of ceremony journals to resume failed ceremonies, not journaled if not set
+	Retries                int                         // number of retries of a request failed with a transient error
+	RetryBackoff           time.Duration               // delay before the first retry, doubled at each next retry
+	PhaseTimeout           time.Duration               // deadline of each ceremony phase per validator created by the ceremony, phases aren't limited if zero
+	RequestTimeout         time.Duration               // timeout of a request to an operator per validator created by the ceremony, requests aren't limited if zero
+	DKGPhaseTimeout        time.Duration               // fallback timeout of DKG protocol phases at operators, operators use their default if zero
+	Exit                   *ExitRequest                // request to pre-sign a voluntary exit of the validator, not signed if not set
+	SignedExit             *phase0.SignedVoluntaryExit // voluntary exit of the validator signed at the last DKG ceremony
+	OwnerSigner            OwnerSigner                 // signs init messages by the owner, init messages aren't signed if not set
+	ApprovalTimeout        time.Duration               // time to wait for operators approving the ceremony, DefaultApprovalTimeout if zero, up to MaxApprovalTimeout
+	validators             int                         // validators created by the running DKG ceremony, operators process each phase per validator
 }
 
 // GeneratePayload generates at initiator ssv smart contract payload using DKG result  received from operators participating in DKG ceremony
@@ -105,8 +134,6 @@ func New(operators wire.OperatorsCLI, logger *zap.Logger, ver string, certs []st
 	} else {
 		client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
 	}
-	// Set timeout for operator responses
-	client.SetTimeout(30 * time.Second)
 	privKey, _, err := crypto.GenerateRSAKeys()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate RSA keys: %s", err)
@@ -118,6 +145,11 @@ func New(operators wire.OperatorsCLI, logger *zap.Logger, ver string, certs []st
 		PrivateKey:             privKey,
 		VerifyMessageSignature: standardMessageVerification(operators),
 		Version:                []byte(ver),
+		Versions:               wire.SupportedVersions,
+		Retries:                defaultRetries,
+		RetryBackoff:           defaultRetryBackoff,
+		PhaseTimeout:           defaultPhaseTimeout,
+		RequestTimeout:         defaultRequestTimeout,
 	}
 	return c, nil
 }
@@ -162,60 +194,154 @@ func ValidatedOperatorData(ids []uint64, operators wire.OperatorsCLI) ([]*wire.O
 	return ops, nil
 }
 
-// messageFlowHandling main steps of DKG at initiator
-func (c *Initiator) messageFlowHandling(init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
-	c.Logger.Info("phase 1: sending init message to operators")
-	results, err := c.SendInitMsg(init, id, operators)
+// messageFlowHandling main steps of DKG at initiator. Responses of operators are recorded at the journal,
+// each phase is sent only to operators which didn't acknowledge it before.
+func (c *Initiator) messageFlowHandling(ctx context.Context, j *Journal, init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+	initMsg, initMsgType, features := initMessage(init, j.OwnerSignature)
+	phaseCtx, cancel := c.phaseContext(ctx)
+	version, err := c.negotiateVersionAll(phaseCtx, operators, features...)
+	cancel()
 	if err != nil {
 		return nil, err
 	}
-	err = verifyMessageSignatures(id, results, c.VerifyMessageSignature)
+	c.Logger.Info("phase 1: sending init message to operators")
+	signedInitMsgBts, err := c.prepareAndSignMessage(initMsg, initMsgType, id, version)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.sendApprovalPhase(ctx, j, j.Exchanges, id, consts.API_INIT_URL, signedInitMsgBts, operators); err != nil {
+		return nil, err
+	}
 	c.Logger.Info("phase 1: ✅ verified operator init responses signatures")
 
 	c.Logger.Info("phase 2: ➡️ sending operator data (exchange messages) required for dkg")
-	results, err = c.SendExchangeMsgs(results, id, operators)
+	exchangeMsgs, err := c.combineMessages(id, phaseResponses(j.Exchanges, operators))
 	if err != nil {
 		return nil, err
 	}
-	err = verifyMessageSignatures(id, results, c.VerifyMessageSignature)
-	if err != nil {
+	if err := c.sendPhase(ctx, j, j.Deals, id, consts.API_DKG_URL, exchangeMsgs, operators); err != nil {
 		return nil, err
 	}
 	c.Logger.Info("phase 2: ✅ verified operator responses (deal messages) signatures")
 	c.Logger.Info("phase 3: ➡️ sending deal dkg data to all operators")
-	dkgResult, err := c.SendKyberMsgs(results, id, operators)
+	kyberMsgs, err := c.combineMessages(id, phaseResponses(j.Deals, operators))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.sendPhase(ctx, j, j.Responses, id, consts.API_DKG_URL, kyberMsgs, operators); err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 3: ✅ verified operator responses (response messages) signatures")
+	c.Logger.Info("phase 4: ➡️ sending response dkg data to all operators")
+	responseMsgs, err := c.combineMessages(id, phaseResponses(j.Responses, operators))
 	if err != nil {
 		return nil, err
 	}
-	err = verifyMessageSignatures(id, results, c.VerifyMessageSignature)
+	if err := c.sendPhase(ctx, j, j.Results, id, consts.API_DKG_URL, responseMsgs, operators); err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 4: ✅ verified operator dkg results signatures")
+	return phaseResponses(j.Results, operators), nil
+}
+
+// combineMessages creates one combined message signed by initiator. A retried phase combines the same
+// recorded responses, so operators recognize it as a retry of the message they processed before.
+func (c *Initiator) combineMessages(id [24]byte, msgs [][]byte) ([]byte, error) {
+	mltpl, err := makeMultipleSignedTransports(c.PrivateKey, id, msgs)
 	if err != nil {
 		return nil, err
 	}
-	c.Logger.Info("phase 2: ✅ verified operator dkg results signatures")
-	return dkgResult, nil
+	return mltpl.MarshalSSZ()
+}
+
+// phaseContext limits a ceremony phase with the phase deadline
+func (c *Initiator) phaseContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return c.timeoutContext(ctx, c.PhaseTimeout)
 }
 
-// StartDKG starts DKG ceremony at initiator with requested parameters
-func (c *Initiator) StartDKG(id [24]byte, withdraw []byte, ids []uint64, network eth2_key_manager_core.Network, owner common.Address, nonce uint64) (*wire.DepositDataCLI, *wire.KeySharesCLI, []*wire.SignedProof, error) {
-	if len(withdraw) != len(common.Address{}) {
-		return nil, nil, nil, fmt.Errorf("incorrect withdrawal address length")
+// timeoutContext limits the context with the timeout scaled by the number of validators created by the running
+// ceremony, as operators scale timeouts of DKG phases. The context isn't limited if the timeout is zero.
+func (c *Initiator) timeoutContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
 	}
-	ops, err := ValidatedOperatorData(ids, c.Operators)
+	if c.validators > 1 {
+		timeout *= time.Duration(c.validators)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// StartDKG starts DKG ceremony at initiator with requested parameters. Deposit data is signed with withdrawal
+// credentials of the withdrawal prefix (0x00, 0x01 or 0x02) and the deposit amount in Gwei. The withdrawal is
+// a BLS withdrawal public key for 0x00 prefix and a withdrawal address otherwise.
+// Cancelling the context aborts the ceremony and in-flight requests to operators.
+func (c *Initiator) StartDKG(ctx context.Context, id [24]byte, withdraw []byte, withdrawalPrefix byte, amount phase0.Gwei, ids []uint64, network eth2_key_manager_core.Network, owner common.Address, nonce uint64) (*wire.DepositDataCLI, *wire.KeySharesCLI, []*wire.SignedProof, error) {
+	res, err := c.startDKG(ctx, id, withdraw, withdrawalPrefix, amount, ids, network, owner, nonce, 1)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	return c.singleResult(res)
+}
+
+// StartBatchDKG starts DKG ceremony creating several validators at once: operators run a DKG protocol
+// per validator, sharing the rest of the ceremony. Owner nonces of the validators are assigned incrementally
+// starting at nonce, as well as validator indices of pre-signed voluntary exits.
+// Threshold tolerant ceremonies create one validator only.
+func (c *Initiator) StartBatchDKG(ctx context.Context, id [24]byte, withdraw []byte, withdrawalPrefix byte, amount phase0.Gwei, ids []uint64, network eth2_key_manager_core.Network, owner common.Address, nonce uint64, validators int) ([]*CeremonyResult, error) {
+	return c.startDKG(ctx, id, withdraw, withdrawalPrefix, amount, ids, network, owner, nonce, validators)
+}
+
+func (c *Initiator) startDKG(ctx context.Context, id [24]byte, withdraw []byte, withdrawalPrefix byte, amount phase0.Gwei, ids []uint64, network eth2_key_manager_core.Network, owner common.Address, nonce uint64, validators int) ([]*CeremonyResult, error) {
+	init, err := c.ConstructInit(withdraw, withdrawalPrefix, amount, ids, network, owner, nonce, validators)
+	if err != nil {
+		return nil, err
+	}
+	ownerSignature, err := c.signInit(id, init)
+	if err != nil {
+		return nil, err
+	}
 
 	pkBytes, err := crypto.EncodeRSAPublicKey(&c.PrivateKey.PublicKey)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	instanceIDField := zap.String("init ID", hex.EncodeToString(id[:]))
-	c.Logger.Info("🚀 Starting dkg ceremony", zap.String("initiator public key", string(pkBytes)), zap.Uint64s("operator IDs", ids), instanceIDField)
+	c.Logger.Info("🚀 Starting dkg ceremony", zap.String("initiator public key", string(pkBytes)), zap.Uint64s("operator IDs", ids), zap.Int("validators", validators), instanceIDField)
+	c.Logger = c.Logger.With(instanceIDField)
+
+	if c.ThresholdTolerant {
+		return c.startTolerantDKG(ctx, init, ownerSignature, id)
+	}
+	j, err := c.newJournal(id, init, ownerSignature)
+	if err != nil {
+		return nil, err
+	}
+	return c.runDKG(ctx, j, init, id)
+}
 
+// ConstructInit validates parameters of a DKG ceremony and returns its init message, including the phase timeout
+// and the voluntary exit request of the initiator. Owner signature is made over hash tree root of the init message.
+func (c *Initiator) ConstructInit(withdraw []byte, withdrawalPrefix byte, amount phase0.Gwei, ids []uint64, network eth2_key_manager_core.Network, owner common.Address, nonce uint64, validators int) (*wire.Init, error) {
+	if validators < 1 || validators > spec.MaxCeremonyValidators {
+		return nil, fmt.Errorf("amount of validators of a ceremony should be 1 to %d", spec.MaxCeremonyValidators)
+	}
+	if c.ThresholdTolerant && validators > 1 {
+		return nil, fmt.Errorf("threshold tolerant ceremony creates one validator only")
+	}
+	if withdrawalPrefix != crypto.BLSWithdrawalPrefixByte && len(withdraw) != len(common.Address{}) {
+		return nil, fmt.Errorf("incorrect withdrawal address length")
+	}
+	if _, err := crypto.WithdrawalCredentials(withdrawalPrefix, withdraw); err != nil {
+		return nil, err
+	}
+	if err := crypto.ValidateDepositAmount(withdrawalPrefix, amount); err != nil {
+		return nil, err
+	}
+	ops, err := ValidatedOperatorData(ids, c.Operators)
+	if err != nil {
+		return nil, err
+	}
 	// compute threshold (3f+1)
 	threshold := len(ids) - ((len(ids) - 1) / 3)
 	// make init message
@@ -226,94 +352,264 @@ func (c *Initiator) StartDKG(id [24]byte, withdraw []byte, ids []uint64, network
 		Fork:                  network.GenesisForkVersion(),
 		Owner:                 owner,
 		Nonce:                 nonce,
+		WithdrawalPrefix:      withdrawalPrefix,
+		Amount:                uint64(amount),
+		PhaseTimeout:          uint64(c.DKGPhaseTimeout.Milliseconds()),
+		ThresholdTolerant:     c.ThresholdTolerant,
 	}
-	c.Logger = c.Logger.With(instanceIDField)
+	// init message of a single validator ceremony is kept the same as before multi-validator ceremonies
+	if validators > 1 {
+		init.Validators = uint64(validators)
+	}
+	if err := spec.ValidatePhaseTimeout(init.PhaseTimeout); err != nil {
+		return nil, err
+	}
+	if c.Exit != nil {
+		if err := c.Exit.setInit(init, network); err != nil {
+			return nil, err
+		}
+	}
+	return init, nil
+}
 
-	dkgResultsBytes, err := c.messageFlowHandling(init, id, ops)
+// signInit returns owner signature of the init message of the ceremony with the ID, nil if the owner signer isn't set
+func (c *Initiator) signInit(id [24]byte, init *wire.Init) ([]byte, error) {
+	if c.OwnerSigner == nil {
+		return nil, nil
+	}
+	signature, err := c.OwnerSigner(id, init)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, fmt.Errorf("failed to sign init message by the owner: %w", err)
+	}
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("owner signature of init message is empty")
+	}
+	return signature, nil
+}
+
+// initMessage returns the init message sent to operators, signed by the owner if the signature is set,
+// and protocol features required to process it
+func initMessage(init *wire.Init, ownerSignature []byte) (wire.SSZMarshaller, wire.TransportType, []string) {
+	features := initFeatures(init)
+	if ownerSignature == nil {
+		return init, wire.InitMessageType, features
 	}
-	dkgResults, err := parseDKGResultsFromBytes(dkgResultsBytes, id)
+	return &wire.SignedInit{Init: *init, Signature: ownerSignature}, wire.SignedInitMessageType, append(features, wire.FeatureOwnerSignedInit)
+}
+
+// singleResult returns results of a ceremony creating one validator
+func (c *Initiator) singleResult(res []*CeremonyResult) (*wire.DepositDataCLI, *wire.KeySharesCLI, []*wire.SignedProof, error) {
+	if len(res) != 1 {
+		return nil, nil, nil, fmt.Errorf("ceremony created %d validators, expected one", len(res))
+	}
+	c.SignedExit = res[0].Exit
+	return res[0].DepositData, res[0].KeyShares, res[0].Proofs, nil
+}
+
+// ResumeDKG continues a failed DKG ceremony from its journal. Phases acknowledged by all operators are skipped,
+// the rest are sent only to operators which didn't respond.
+func (c *Initiator) ResumeDKG(ctx context.Context, id [24]byte) (*wire.DepositDataCLI, *wire.KeySharesCLI, []*wire.SignedProof, error) {
+	res, err := c.ResumeBatchDKG(ctx, id)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	c.Logger.Info("🏁 DKG completed, verifying deposit data and ssv payload")
-	depositDataJson, keyshares, err := c.processDKGResultResponseInitial(dkgResults, init, id)
+	return c.singleResult(res)
+}
+
+// ResumeBatchDKG continues a failed DKG ceremony from its journal the same way as ResumeDKG,
+// returning results of all validators created by the ceremony. The initiator private key should be the key
+// the ceremony was started with.
+func (c *Initiator) ResumeBatchDKG(ctx context.Context, id [24]byte) ([]*CeremonyResult, error) {
+	if c.Journal == nil {
+		return nil, fmt.Errorf("journal store is not set")
+	}
+	j, err := c.Journal.Load(id)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, fmt.Errorf("failed to load ceremony journal: %w", err)
 	}
-	c.Logger.Info("✅ verified master signature for ssv contract data")
-	if err := crypto.ValidateDepositDataCLI(depositDataJson, common.BytesToAddress(withdraw)); err != nil {
-		return nil, nil, nil, err
+	pubKey, err := crypto.EncodeRSAPublicKey(&c.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, err
 	}
-	if err := crypto.ValidateKeysharesCLI(keyshares, init.Operators, init.Owner, init.Nonce, depositDataJson.PubKey); err != nil {
-		return nil, nil, nil, err
+	if !bytes.Equal(pubKey, j.PublicKey) {
+		return nil, fmt.Errorf("ceremony was started by another initiator key, it can be resumed only with the same initiator private key")
 	}
-	// sending back to operators results
-	depositData, err := json.Marshal(depositDataJson)
+	init := &wire.Init{}
+	if err := init.UnmarshalSSZ(j.Init); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal init message: %w", err)
+	}
+	c.Logger = c.Logger.With(zap.String("init ID", hex.EncodeToString(id[:])))
+	c.Logger.Info("🔁 Resuming dkg ceremony", zap.Int("exchanges", len(j.Exchanges)), zap.Int("deals", len(j.Deals)), zap.Int("responses", len(j.Responses)), zap.Int("results", len(j.Results)))
+	return c.runDKG(ctx, j, init, id)
+}
+
+// runDKG runs DKG ceremony recording its progress at the journal. The journal is kept if the ceremony fails.
+func (c *Initiator) runDKG(ctx context.Context, j *Journal, init *wire.Init, id [24]byte) ([]*CeremonyResult, error) {
+	c.validators = spec.CeremonyValidators(init)
+	defer func() { c.validators = 0 }()
+	if err := c.saveJournal(j); err != nil {
+		return nil, err
+	}
+	dkgResultsBytes, err := c.messageFlowHandling(ctx, j, init, id, init.Operators)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, c.resumableError(id, err)
 	}
-	keysharesData, err := json.Marshal(keyshares)
+	dkgResults, err := parseDKGResultsFromBytes(dkgResultsBytes, id, spec.CeremonyValidators(init))
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
+	}
+	res, err := c.processAndSendResults(ctx, dkgResults, init, id)
+	if err != nil {
+		return nil, c.resumableError(id, err)
 	}
-	var proofsArray []*wire.SignedProof
-	for _, res := range dkgResults {
-		proofsArray = append(proofsArray, &res.SignedProof)
+	c.deleteJournal(id)
+	return res, nil
+}
+
+// resumableError logs that a failed ceremony can be resumed from the journal
+func (c *Initiator) resumableError(id [24]byte, err error) error {
+	if c.Journal != nil {
+		c.Logger.Warn("⚠️ DKG ceremony failed, it can be resumed from the last acknowledged phase", zap.String("resume ID", hex.EncodeToString(id[:])))
 	}
-	proofsData, err := json.Marshal(proofsArray)
+	return err
+}
+
+// startTolerantDKG runs threshold tolerant DKG ceremony. If some operators were excluded, key shares of the remaining
+// operators keep the threshold of the original cluster, so they are reshared to a cluster which can be registered
+// at the ssv.network. The resulting keyshares contain only the operators of that cluster.
+func (c *Initiator) startTolerantDKG(ctx context.Context, init *wire.Init, ownerSignature []byte, id [24]byte) ([]*CeremonyResult, error) {
+	c.ExcludedOperators = nil
+	dkgResults, excluded, err := c.tolerantMessageFlowHandling(ctx, init, ownerSignature, id, init.Operators)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
+	}
+	c.ExcludedOperators = excluded
+	if len(excluded) > 0 {
+		c.Logger.Warn("⚠️ DKG ceremony finished without some of the operators", zap.Uint64s("excluded operator IDs", excluded))
+		dkgResults, init, err = c.reshareQualified(ctx, init, id, dkgResults)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.processAndSendResults(ctx, [][]*wire.Result{dkgResults}, init, id)
+}
+
+// processAndSendResults verifies DKG results of each validator created by the ceremony, builds deposit data
+// and ssv payload and sends them back to operators
+func (c *Initiator) processAndSendResults(ctx context.Context, dkgResults [][]*wire.Result, init *wire.Init, id [24]byte) ([]*CeremonyResult, error) {
+	c.Logger.Info("🏁 DKG completed, verifying deposit data and ssv payload")
+	withdrawalCredentials, err := crypto.WithdrawalCredentials(init.WithdrawalPrefix, init.WithdrawalCredentials)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*CeremonyResult, len(dkgResults))
+	for i, results := range dkgResults {
+		validatorInit := spec.ValidatorInit(init, i)
+		depositDataJson, keyshares, exit, err := c.processDKGResultResponseInitial(results, validatorInit, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := crypto.ValidateDepositDataCLICredentials(depositDataJson, withdrawalCredentials, phase0.Gwei(init.Amount)); err != nil {
+			return nil, err
+		}
+		if err := crypto.ValidateKeysharesCLI(keyshares, init.Operators, init.Owner, validatorInit.Nonce, depositDataJson.PubKey); err != nil {
+			return nil, err
+		}
+		proofs := make([]*wire.SignedProof, len(results))
+		for j, result := range results {
+			proofs[j] = &result.SignedProof
+		}
+		res[i] = &CeremonyResult{
+			ID:                    id,
+			Owner:                 init.Owner,
+			Nonce:                 validatorInit.Nonce,
+			WithdrawalCredentials: withdrawalCredentials,
+			Amount:                phase0.Gwei(init.Amount),
+			DepositData:           depositDataJson,
+			KeyShares:             keyshares,
+			Proofs:                proofs,
+			ExcludedOperators:     c.ExcludedOperators,
+			Exit:                  exit,
+		}
+	}
+	c.Logger.Info("✅ verified master signature for ssv contract data")
+	// sending back to operators results
+	batch := BatchResult{Ceremonies: res}
+	depositData, err := json.Marshal(batch.DepositData())
+	if err != nil {
+		return nil, err
+	}
+	keysharesData, err := json.Marshal(batch.KeyShares())
+	if err != nil {
+		return nil, err
+	}
+	proofsData, err := json.Marshal(batch.Proofs())
+	if err != nil {
+		return nil, err
 	}
 	resultMsg := &wire.ResultData{
-		Operators:     ops,
+		Operators:     init.Operators,
 		Identifier:    id,
 		DepositData:   depositData,
 		KeysharesData: keysharesData,
 		Proofs:        proofsData,
 	}
-	err = c.sendResult(resultMsg, ops, consts.API_RESULTS_URL, id)
+	err = c.sendResult(ctx, resultMsg, init.Operators, consts.API_RESULTS_URL, id)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("🤖 Error storing results at operators %w", err)
+		return nil, fmt.Errorf("🤖 Error storing results at operators %w", err)
 	}
-	return depositDataJson, keyshares, proofsArray, nil
+	return res, nil
 }
 
-// processDKGResultResponseInitial deserializes incoming DKG result messages from operators after successful initiation ceremony
-func (c *Initiator) processDKGResultResponseInitial(dkgResults []*wire.Result, init *wire.Init, requestID [24]byte) (*wire.DepositDataCLI, *wire.KeySharesCLI, error) {
+// processDKGResultResponseInitial deserializes incoming DKG result messages from operators after successful initiation ceremony.
+// Voluntary exit is reconstructed if requested by the init message.
+func (c *Initiator) processDKGResultResponseInitial(dkgResults []*wire.Result, init *wire.Init, requestID [24]byte) (*wire.DepositDataCLI, *wire.KeySharesCLI, *phase0.SignedVoluntaryExit, error) {
 	// check results sorted by operatorID
 	sorted := sort.SliceIsSorted(dkgResults, func(p, q int) bool {
 		return dkgResults[p].OperatorID < dkgResults[q].OperatorID
 	})
 	if !sorted {
-		return nil, nil, fmt.Errorf("slice is not sorted")
+		return nil, nil, nil, fmt.Errorf("slice is not sorted")
 	}
 	validatorPK, err := spec.RecoverValidatorPKFromResults(dkgResults)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	_, depositData, masterSigOwnerNonce, err := spec.ValidateResults(init.Operators, init.WithdrawalCredentials, validatorPK, init.Fork, init.Owner, init.Nonce, requestID, dkgResults)
+	_, depositData, masterSigOwnerNonce, err := spec.ValidateResults(init.Operators, init.WithdrawalCredentials, init.WithdrawalPrefix, phase0.Gwei(init.Amount), validatorPK, init.Fork, init.Owner, init.Nonce, requestID, dkgResults)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	var exit *phase0.SignedVoluntaryExit
+	if init.SignExit {
+		exit, err = spec.ValidateExitResults(spec.VoluntaryExit(init), init.ExitForkVersion, init.GenesisValidatorsRoot, validatorPK, dkgResults)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.Logger.Info("✅ voluntary exit was successfully reconstructed")
 	}
 	network, err := utils.GetNetworkByFork(init.Fork)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	depositDataJson, err := crypto.BuildDepositDataCLI(network, depositData, wire.DepositCliVersion)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create deposit data json: %v", err)
+		return nil, nil, nil, fmt.Errorf("failed to create deposit data json: %v", err)
 	}
 	c.Logger.Info("✅ deposit data was successfully reconstructed")
 	keyshares, err := c.generateSSVKeysharesPayload(init.Operators, dkgResults, masterSigOwnerNonce, init.Owner, init.Nonce)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return depositDataJson, keyshares, nil
+	return depositDataJson, keyshares, exit, nil
 }
 
-func parseDKGResultsFromBytes(responseResult [][]byte, id [24]byte) (dkgResults []*wire.Result, finalErr error) {
+// parseDKGResultsFromBytes parses results of operators, returning results of each validator created by the ceremony sorted by operator ID
+func parseDKGResultsFromBytes(responseResult [][]byte, id [24]byte, validators int) (dkgResults [][]*wire.Result, finalErr error) {
+	expType := wire.OutputMessageType
+	if validators > 1 {
+		expType = wire.MultipleOutputMessageType
+	}
+	dkgResults = make([][]*wire.Result, validators)
 	for i := 0; i < len(responseResult); i++ {
 		msg := responseResult[i]
 		tsp := &wire.SignedTransport{}
@@ -325,49 +621,72 @@ func parseDKGResultsFromBytes(responseResult [][]byte, id [24]byte) (dkgResults
 			finalErr = errors.Join(finalErr, fmt.Errorf("%s", string(tsp.Message.Data)))
 			continue
 		}
-		if tsp.Message.Type != wire.OutputMessageType {
-			finalErr = errors.Join(finalErr, fmt.Errorf("wrong DKG result message type: exp %s, got %s ", wire.OutputMessageType.String(), tsp.Message.Type.String()))
+		if tsp.Message.Type != expType {
+			finalErr = errors.Join(finalErr, fmt.Errorf("wrong DKG result message type: exp %s, got %s ", expType.String(), tsp.Message.Type.String()))
 			continue
 		}
-		result := &wire.Result{}
-		if err := result.UnmarshalSSZ(tsp.Message.Data); err != nil {
-			finalErr = errors.Join(finalErr, err)
-			continue
+		var results []*wire.Result
+		if expType == wire.OutputMessageType {
+			result := &wire.Result{}
+			if err := result.UnmarshalSSZ(tsp.Message.Data); err != nil {
+				finalErr = errors.Join(finalErr, err)
+				continue
+			}
+			results = []*wire.Result{result}
+		} else {
+			multiple := &wire.MultipleResults{}
+			if err := multiple.UnmarshalSSZ(tsp.Message.Data); err != nil {
+				finalErr = errors.Join(finalErr, err)
+				continue
+			}
+			if len(multiple.Results) != validators {
+				finalErr = errors.Join(finalErr, fmt.Errorf("DKG results of %d validators, expected %d", len(multiple.Results), validators))
+				continue
+			}
+			results = multiple.Results
 		}
-		if !bytes.Equal(result.RequestID[:], id[:]) {
-			finalErr = errors.Join(finalErr, fmt.Errorf("DKG result has wrong ID, sender ID: %d, message type: %s", tsp.Signer, tsp.Message.Type.String()))
-			continue
+		for j, result := range results {
+			if !bytes.Equal(result.RequestID[:], id[:]) {
+				finalErr = errors.Join(finalErr, fmt.Errorf("DKG result has wrong ID, sender ID: %d, message type: %s", tsp.Signer, tsp.Message.Type.String()))
+				break
+			}
+			dkgResults[j] = append(dkgResults[j], result)
 		}
-		dkgResults = append(dkgResults, result)
 	}
 	if finalErr != nil {
 		return nil, finalErr
 	}
-	// sort the results by operatorID
-	sort.SliceStable(dkgResults, func(i, j int) bool {
-		return dkgResults[i].OperatorID < dkgResults[j].OperatorID
-	})
-	for i := 0; i < len(dkgResults); i++ {
-		if len(dkgResults[i].SignedProof.Proof.ValidatorPubKey) == 0 ||
-			!bytes.Equal(dkgResults[i].SignedProof.Proof.ValidatorPubKey,
-				dkgResults[0].SignedProof.Proof.ValidatorPubKey) {
-			return nil, fmt.Errorf("operator %d sent wrong validator public key", dkgResults[i].OperatorID)
+	for _, results := range dkgResults {
+		// sort the results by operatorID
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].OperatorID < results[j].OperatorID
+		})
+		for i := 0; i < len(results); i++ {
+			if len(results[i].SignedProof.Proof.ValidatorPubKey) == 0 ||
+				!bytes.Equal(results[i].SignedProof.Proof.ValidatorPubKey,
+					results[0].SignedProof.Proof.ValidatorPubKey) {
+				return nil, fmt.Errorf("operator %d sent wrong validator public key", results[i].OperatorID)
+			}
 		}
 	}
 	return dkgResults, nil
 }
 
 // SendInitMsg sends initial DKG ceremony message to participating operators from initiator
-func (c *Initiator) SendInitMsg(init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
-	signedInitMsgBts, err := c.prepareAndSignMessage(init, wire.InitMessageType, id, c.Version)
+func (c *Initiator) SendInitMsg(ctx context.Context, init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+	version, err := c.negotiateVersionAll(ctx, operators, initFeatures(init)...)
+	if err != nil {
+		return nil, err
+	}
+	signedInitMsgBts, err := c.prepareAndSignMessage(init, wire.InitMessageType, id, version)
 	if err != nil {
 		return nil, err
 	}
-	return c.SendToAll(consts.API_INIT_URL, signedInitMsgBts, operators, false)
+	return c.SendToAll(ctx, consts.API_INIT_URL, signedInitMsgBts, operators, false)
 }
 
 // SendExchangeMsgs sends combined exchange messages to each operator participating in DKG ceremony
-func (c *Initiator) SendExchangeMsgs(exchangeMsgs [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+func (c *Initiator) SendExchangeMsgs(ctx context.Context, exchangeMsgs [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
 	mltpl, err := makeMultipleSignedTransports(c.PrivateKey, id, exchangeMsgs)
 	if err != nil {
 		return nil, err
@@ -376,11 +695,11 @@ func (c *Initiator) SendExchangeMsgs(exchangeMsgs [][]byte, id [24]byte, operato
 	if err != nil {
 		return nil, err
 	}
-	return c.SendToAll(consts.API_DKG_URL, mltplbyts, operators, false)
+	return c.SendToAll(ctx, consts.API_DKG_URL, mltplbyts, operators, false)
 }
 
 // SendKyberMsgs sends combined kyber messages to each operator participating in DKG ceremony
-func (c *Initiator) SendKyberMsgs(kyberDeals [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+func (c *Initiator) SendKyberMsgs(ctx context.Context, kyberDeals [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
 	mltpl2, err := makeMultipleSignedTransports(c.PrivateKey, id, kyberDeals)
 	if err != nil {
 		return nil, err
@@ -390,26 +709,33 @@ func (c *Initiator) SendKyberMsgs(kyberDeals [][]byte, id [24]byte, operators []
 	if err != nil {
 		return nil, err
 	}
-	return c.SendToAll(consts.API_DKG_URL, mltpl2byts, operators, false)
+	return c.SendToAll(ctx, consts.API_DKG_URL, mltpl2byts, operators, false)
 }
 
-func (c *Initiator) sendResult(resData *wire.ResultData, operators []*wire.Operator, method string, id [24]byte) error {
-	signedMsgBts, err := c.prepareAndSignMessage(resData, wire.ResultMessageType, id, c.Version)
+// sendResult sends results of the ceremony to operators, signed with the protocol version negotiated with them
+func (c *Initiator) sendResult(ctx context.Context, resData *wire.ResultData, operators []*wire.Operator, method string, id [24]byte) error {
+	ctx, cancel := c.phaseContext(ctx)
+	defer cancel()
+	version, err := c.negotiateVersionAll(ctx, operators)
 	if err != nil {
 		return err
 	}
-	_, err = c.SendToAll(method, signedMsgBts, operators, true)
+	signedMsgBts, err := c.prepareAndSignMessage(resData, wire.ResultMessageType, id, version)
+	if err != nil {
+		return err
+	}
+	_, err = c.SendToAll(ctx, method, signedMsgBts, operators, true)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *Initiator) Ping(ips []string) error {
+func (c *Initiator) Ping(ctx context.Context, ips []string) error {
 	resc := make(chan wire.PongResult, len(ips))
 	for _, ip := range ips {
 		go func(ip string) {
-			resdata, err := c.GetAndCollect(wire.OperatorCLI{Addr: ip}, consts.API_HEALTH_CHECK_URL)
+			resdata, err := c.GetAndCollect(ctx, wire.OperatorCLI{Addr: ip}, consts.API_HEALTH_CHECK_URL)
 			resc <- wire.PongResult{
 				IP:     ip,
 				Err:    err,
@@ -471,33 +797,10 @@ func (c *Initiator) processPongMessage(res wire.PongResult) error {
 	if res.Err != nil {
 		return res.Err
 	}
-	signedPongMsg := &wire.SignedTransport{}
-	if err := signedPongMsg.UnmarshalSSZ(res.Result); err != nil {
-		errmsg, parseErr := wire.ParseAsError(res.Result)
-		if parseErr == nil {
-			return fmt.Errorf("operator returned err: %v", errmsg)
-		}
-		return err
-	}
-	// Validate that incoming message is an pong message
-	if signedPongMsg.Message.Type != wire.PongMessageType {
-		return fmt.Errorf("wrong incoming message type from operator")
-	}
-	pong := &wire.Pong{}
-	if err := pong.UnmarshalSSZ(signedPongMsg.Message.Data); err != nil {
-		return err
-	}
-	pongBytes, err := signedPongMsg.Message.MarshalSSZ()
+	signedPongMsg, pong, err := parsePong(res.Result)
 	if err != nil {
 		return err
 	}
-	pub, err := crypto.ParseRSAPublicKey(pong.PubKey)
-	if err != nil {
-		return err
-	}
-	if err := crypto.VerifyRSA(pub, pongBytes, signedPongMsg.Signature); err != nil {
-		return err
-	}
-	c.Logger.Info("🍎 operator online and healthy", zap.Uint64("ID", pong.ID), zap.String("IP", res.IP), zap.String("Version", string(signedPongMsg.Message.Version)), zap.String("Public key", string(pong.PubKey)))
+	c.Logger.Info("🍎 operator online and healthy", zap.Uint64("ID", pong.ID), zap.String("IP", res.IP), zap.String("Version", string(signedPongMsg.Message.Version)), zap.String("Protocol versions", fmt.Sprintf("%s-%s", pong.MinVersion, pong.MaxVersion)), zap.ByteStrings("Features", pong.Features), zap.String("Public key", string(pong.PubKey)))
 	return nil
 }
@@ -1,6 +1,7 @@
 package initiator_test
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/hex"
 	"encoding/json"
@@ -76,32 +77,32 @@ func TestStartDKG(t *testing.T) {
 		intr, err := initiator.New(ops, logger, "test.version", rootCert)
 		require.NoError(t, err)
 		id := crypto.NewID()
-		depositData, keyshares, _, err := intr.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
+		depositData, keyshares, _, err := intr.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "mainnet", owner, 0)
 		require.NoError(t, err)
 		err = test_utils.VerifySharesData([]uint64{1, 2, 3, 4}, []*rsa.PrivateKey{srv1.PrivKey, srv2.PrivKey, srv3.PrivKey, srv4.PrivKey}, keyshares, owner, 0)
 		require.NoError(t, err)
-		err = crypto.ValidateDepositDataCLI(depositData, withdraw)
+		err = crypto.ValidateDepositDataCLI(depositData, crypto.ETH1WithdrawalPrefixByte, withdraw, crypto.MaxEffectiveBalanceInGwei)
 		require.NoError(t, err)
 	})
 	t.Run("test wrong amount of opeators < 4", func(t *testing.T) {
 		intr, err := initiator.New(ops, logger, "test.version", rootCert)
 		require.NoError(t, err)
 		id := crypto.NewID()
-		_, _, _, err = intr.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3}, "mainnet", owner, 0)
+		_, _, _, err = intr.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3}, "mainnet", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: < 4")
 	})
 	t.Run("test wrong amount of opeators > 13", func(t *testing.T) {
 		intr, err := initiator.New(ops, logger, "test.version", rootCert)
 		require.NoError(t, err)
 		id := crypto.NewID()
-		_, _, _, err = intr.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, "prater", owner, 0)
+		_, _, _, err = intr.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, "prater", owner, 0)
 		require.ErrorContains(t, err, "wrong operators len: > 13")
 	})
 	t.Run("test opeators not unique", func(t *testing.T) {
 		intr, err := initiator.New(ops, logger, "test.version", rootCert)
 		require.NoError(t, err)
 		id := crypto.NewID()
-		_, _, _, err = intr.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 7, 9, 10, 11, 12, 12}, "holesky", owner, 0)
+		_, _, _, err = intr.StartDKG(context.Background(), id, withdraw.Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4, 5, 6, 7, 7, 9, 10, 11, 12, 12}, "holesky", owner, 0)
 		require.ErrorContains(t, err, "operator is not in given operator data list")
 	})
 
@@ -389,7 +390,7 @@ func TestDepositDataSigningAndVerification(t *testing.T) {
 
 			depositDataCLI, err := crypto.BuildDepositDataCLI(test.network, depositData, wire.DepositCliVersion)
 			require.NoError(t, err)
-			err = crypto.ValidateDepositDataCLIBLS(depositDataCLI, test.withdrawalPubKey)
+			err = crypto.ValidateDepositDataCLIBLS(depositDataCLI, test.withdrawalPubKey, crypto.MaxEffectiveBalanceInGwei)
 			if test.expectedErr != nil {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), test.expectedErr.Error())
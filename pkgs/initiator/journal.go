@@ -0,0 +1,190 @@
+package initiator
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// Journal records progress of a DKG ceremony at initiator: the messages needed to continue the ceremony and
+// responses of operators at each phase. A failed ceremony is resumed from the last phase acknowledged by every operator.
+type Journal struct {
+	RequestID string `json:"request_id"`
+	// initiator's RSA public key of the ceremony, operators accept ceremony messages signed only by its private key,
+	// so the ceremony is resumed with the same initiator private key. The private key isn't journaled.
+	PublicKey []byte `json:"public_key"`
+	// SSZ encoded init message
+	Init []byte `json:"init"`
+	// owner signature of the init message, the init message isn't signed by the owner if empty
+	OwnerSignature []byte `json:"owner_signature,omitempty"`
+	// responses of operators to init message (phase 1)
+	Exchanges map[uint64][]byte `json:"exchanges"`
+	// responses of operators to exchange messages (phase 2)
+	Deals map[uint64][]byte `json:"deals"`
+	// responses of operators to deal messages (phase 3)
+	Responses map[uint64][]byte `json:"responses"`
+	// responses of operators to response messages (phase 4)
+	Results map[uint64][]byte `json:"results"`
+}
+
+// JournalStore keeps a JSON journal file per ceremony at a directory
+type JournalStore struct {
+	Dir string
+}
+
+// NewJournalStore creates a JournalStore writing journals to the directory
+func NewJournalStore(dir string) *JournalStore {
+	return &JournalStore{Dir: dir}
+}
+
+// Save writes the journal to a temporary file and renames it, so a crash doesn't leave a partially written journal
+func (s *JournalStore) Save(j *Journal) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	path := filepath.Join(s.Dir, j.RequestID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the journal of the ceremony
+func (s *JournalStore) Load(id [24]byte) (*Journal, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, err
+	}
+	j := &Journal{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal: %w", err)
+	}
+	// responses of a phase are recorded to its map, journals of older releases miss the phases added since
+	if j.Exchanges == nil || j.Deals == nil || j.Responses == nil || j.Results == nil {
+		return nil, fmt.Errorf("journal of ceremony %s misses responses of some phases, it was written by an incompatible release", j.RequestID)
+	}
+	return j, nil
+}
+
+// Delete removes the journal of the ceremony
+func (s *JournalStore) Delete(id [24]byte) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *JournalStore) path(id [24]byte) string {
+	return filepath.Join(s.Dir, hex.EncodeToString(id[:])+".json")
+}
+
+// newJournal creates an empty journal of a new ceremony
+func (c *Initiator) newJournal(id [24]byte, init *wire.Init, ownerSignature []byte) (*Journal, error) {
+	initBytes, err := init.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := crypto.EncodeRSAPublicKey(&c.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{
+		RequestID:      hex.EncodeToString(id[:]),
+		PublicKey:      pubKey,
+		Init:           initBytes,
+		OwnerSignature: ownerSignature,
+		Exchanges:      make(map[uint64][]byte),
+		Deals:          make(map[uint64][]byte),
+		Responses:      make(map[uint64][]byte),
+		Results:        make(map[uint64][]byte),
+	}, nil
+}
+
+// saveJournal persists the journal if a store is set, phases of ceremonies without a journal aren't saved
+func (c *Initiator) saveJournal(j *Journal) error {
+	if c.Journal == nil || j == nil {
+		return nil
+	}
+	if err := c.Journal.Save(j); err != nil {
+		return fmt.Errorf("failed to save ceremony journal: %w", err)
+	}
+	return nil
+}
+
+// deleteJournal removes the journal of a finished ceremony
+func (c *Initiator) deleteJournal(id [24]byte) {
+	if c.Journal == nil {
+		return
+	}
+	if err := c.Journal.Delete(id); err != nil {
+		c.Logger.Error("failed to delete ceremony journal", zap.Error(err))
+	}
+}
+
+// sendPhase sends the phase message to operators which haven't acknowledged the phase yet. Verified responses are
+// recorded at the journal, so a failed phase is resumed only with operators which didn't respond.
+func (c *Initiator) sendPhase(ctx context.Context, j *Journal, responses map[uint64][]byte, id [24]byte, method string, msg []byte, operators []*wire.Operator) error {
+	var pending []*wire.Operator
+	for _, op := range operators {
+		if _, ok := responses[op.ID]; !ok {
+			pending = append(pending, op)
+		}
+	}
+	if len(pending) == 0 {
+		c.Logger.Info("all operators acknowledged the phase before, skipping")
+		return nil
+	}
+	ctx, cancel := c.phaseContext(ctx)
+	defer cancel()
+	results, errs := c.SendToAllTolerant(ctx, method, msg, pending)
+	for opID, res := range results {
+		if err := verifyMessageSignatures(id, [][]byte{res}, c.VerifyMessageSignature); err != nil {
+			errs[opID] = err
+			continue
+		}
+		responses[opID] = res
+	}
+	if err := c.saveJournal(j); err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	failed := make([]uint64, 0, len(errs))
+	for opID := range errs {
+		failed = append(failed, opID)
+	}
+	sort.Slice(failed, func(i, k int) bool { return failed[i] < failed[k] })
+	var finalErr error
+	for _, opID := range failed {
+		finalErr = errors.Join(finalErr, fmt.Errorf("operator ID: %d, %w", opID, errs[opID]))
+	}
+	return finalErr
+}
+
+// phaseResponses returns recorded responses of operators ordered by operator ID
+func phaseResponses(responses map[uint64][]byte, operators []*wire.Operator) [][]byte {
+	res := make([][]byte, 0, len(operators))
+	for _, op := range operators {
+		if resp, ok := responses[op.ID]; ok {
+			res = append(res, resp)
+		}
+	}
+	return res
+}
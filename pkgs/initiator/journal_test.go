@@ -0,0 +1,43 @@
+package initiator_test
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+)
+
+func TestJournalStore(t *testing.T) {
+	store := initiator.NewJournalStore(filepath.Join(t.TempDir(), "journal"))
+	id := crypto.NewID()
+	_, err := store.Load(id)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	j := &initiator.Journal{
+		RequestID: hex.EncodeToString(id[:]),
+		PublicKey: []byte("key"),
+		Init:      []byte("init"),
+		Exchanges: map[uint64][]byte{1: []byte("exchange")},
+		Deals:     map[uint64][]byte{},
+		Responses: map[uint64][]byte{},
+		Results:   map[uint64][]byte{},
+	}
+	require.NoError(t, store.Save(j))
+	loaded, err := store.Load(id)
+	require.NoError(t, err)
+	require.Equal(t, j, loaded)
+	require.NoError(t, store.Delete(id))
+	_, err = store.Load(id)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	// deleting a missing journal isn't an error
+	require.NoError(t, store.Delete(id))
+	// journal without responses of a phase can't be resumed
+	j.Responses = nil
+	require.NoError(t, store.Save(j))
+	_, err = store.Load(id)
+	require.ErrorContains(t, err, "misses responses of some phases")
+}
@@ -0,0 +1,77 @@
+package initiator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
+)
+
+// nonceBlockRange is the number of blocks searched for events by one request, RPC providers limit the range of a log query
+const nonceBlockRange = 50000
+
+// validatorAddedTopic is the topic of the SSV network contract event emitted when owner registers a validator
+var validatorAddedTopic = eth_crypto.Keccak256Hash([]byte("ValidatorAdded(address,uint64[],bytes,bytes,(uint32,uint64,uint64,bool,uint256))"))
+
+// SSVNetworkContract is a deployment of the SSV network contract
+type SSVNetworkContract struct {
+	Address     common.Address
+	DeployBlock uint64 // events are searched starting at this block
+}
+
+var ssvNetworkContracts = map[eth2_key_manager_core.Network]SSVNetworkContract{
+	eth2_key_manager_core.MainNetwork: {
+		Address:     common.HexToAddress("0xDD9BC35aE942eF0cFa76930954a156B3fF30a4E1"),
+		DeployBlock: 17507487,
+	},
+	eth2_key_manager_core.HoleskyNetwork: {
+		Address:     common.HexToAddress("0x38A4794cCEd47d3baf7370CcC43B560D3a1beEFA"),
+		DeployBlock: 181612,
+	},
+}
+
+// SSVNetworkContractOf returns the SSV network contract deployed at the network
+func SSVNetworkContractOf(network eth2_key_manager_core.Network) (SSVNetworkContract, error) {
+	contract, ok := ssvNetworkContracts[network]
+	if !ok {
+		return SSVNetworkContract{}, fmt.Errorf("SSV network contract is unknown at network %s", network)
+	}
+	return contract, nil
+}
+
+// FetchOwnerNonce returns the current owner nonce at the SSV network contract, the number of validators registered by
+// the owner. ValidatorAdded events of the owner are counted from the contract deployment up to the latest block.
+func FetchOwnerNonce(ctx context.Context, client eip1271.ETHClient, contract SSVNetworkContract, owner common.Address) (uint64, error) {
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block number: %w", err)
+	}
+	var nonce uint64
+	for from := contract.DeployBlock; from <= latest; from += nonceBlockRange {
+		to := from + nonceBlockRange - 1
+		if to > latest {
+			to = latest
+		}
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{contract.Address},
+			Topics:    [][]common.Hash{{validatorAddedTopic}, {common.BytesToHash(owner.Bytes())}},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to filter ValidatorAdded events of blocks %d-%d: %w", from, to, err)
+		}
+		for _, log := range logs {
+			if !log.Removed {
+				nonce++
+			}
+		}
+	}
+	return nonce, nil
+}
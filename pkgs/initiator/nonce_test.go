@@ -0,0 +1,96 @@
+package initiator_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+)
+
+// validatorAddedEmitter returns code of a contract emitting ValidatorAdded event of the caller on each call
+func validatorAddedEmitter() []byte {
+	topic := eth_crypto.Keccak256Hash([]byte("ValidatorAdded(address,uint64[],bytes,bytes,(uint32,uint64,uint64,bool,uint256))"))
+	// CALLER PUSH32 topic PUSH1 0 PUSH1 0 LOG2 STOP
+	runtime := append([]byte{0x33, 0x7f}, topic.Bytes()...)
+	runtime = append(runtime, 0x60, 0x00, 0x60, 0x00, 0xa2, 0x00)
+	// copy the runtime code to memory and return it
+	code := []byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}
+	return append(code, runtime...)
+}
+
+// simulatedClient implements eip1271.ETHClient with the simulated backend
+type simulatedClient struct {
+	*backends.SimulatedBackend
+}
+
+func (c simulatedClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.Blockchain().CurrentBlock().Number.Uint64(), nil
+}
+
+func TestFetchOwnerNonce(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 2)
+	alloc := core.GenesisAlloc{}
+	for i := range keys {
+		key, err := eth_crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		alloc[eth_crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: big.NewInt(1e18)}
+	}
+	sim := simulatedClient{backends.NewSimulatedBackend(alloc, 10000000)}
+	defer sim.Close()
+	transactor := func(key *ecdsa.PrivateKey) *bind.TransactOpts {
+		opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+		require.NoError(t, err)
+		return opts
+	}
+	address, _, contract, err := bind.DeployContract(transactor(keys[0]), abi.ABI{}, validatorAddedEmitter(), sim)
+	require.NoError(t, err)
+	sim.Commit()
+	deployBlock, err := sim.BlockNumber(context.Background())
+	require.NoError(t, err)
+	// the first owner registers 3 validators, the second one registers 1 validator
+	for _, key := range []*ecdsa.PrivateKey{keys[0], keys[1], keys[0], keys[0]} {
+		_, err := contract.RawTransact(transactor(key), nil)
+		require.NoError(t, err)
+		sim.Commit()
+	}
+	ssvNetwork := initiator.SSVNetworkContract{Address: address, DeployBlock: deployBlock}
+
+	t.Run("owners with registered validators", func(t *testing.T) {
+		nonce, err := initiator.FetchOwnerNonce(context.Background(), sim, ssvNetwork, eth_crypto.PubkeyToAddress(keys[0].PublicKey))
+		require.NoError(t, err)
+		require.EqualValues(t, 3, nonce)
+		nonce, err = initiator.FetchOwnerNonce(context.Background(), sim, ssvNetwork, eth_crypto.PubkeyToAddress(keys[1].PublicKey))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, nonce)
+	})
+	t.Run("new owner", func(t *testing.T) {
+		nonce, err := initiator.FetchOwnerNonce(context.Background(), sim, ssvNetwork, common.HexToAddress("0x0000001"))
+		require.NoError(t, err)
+		require.EqualValues(t, 0, nonce)
+	})
+	t.Run("events of another contract", func(t *testing.T) {
+		nonce, err := initiator.FetchOwnerNonce(context.Background(), sim, initiator.SSVNetworkContract{Address: common.HexToAddress("0x0000002")}, eth_crypto.PubkeyToAddress(keys[0].PublicKey))
+		require.NoError(t, err)
+		require.EqualValues(t, 0, nonce)
+	})
+	t.Run("known networks", func(t *testing.T) {
+		_, err := initiator.SSVNetworkContractOf(e2m_core.MainNetwork)
+		require.NoError(t, err)
+		_, err = initiator.SSVNetworkContractOf(e2m_core.HoleskyNetwork)
+		require.NoError(t, err)
+		_, err = initiator.SSVNetworkContractOf(e2m_core.PraterNetwork)
+		require.ErrorContains(t, err, "SSV network contract is unknown at network")
+	})
+}
@@ -0,0 +1,123 @@
+package initiator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// operatorAPIJSON is the operator data returned by SSV API
+type operatorAPIJSON struct {
+	ID         uint64 `json:"id"`
+	PubKey     string `json:"public_key"`
+	DKGAddress string `json:"dkg_address"`
+}
+
+// FetchOperators fetches IDs, public keys and DKG endpoints of the operators from an SSV API compatible endpoint,
+// e.g. https://api.ssv.network/api/v4/mainnet. Operator data is requested at <apiURL>/operators/<ID>.
+func FetchOperators(ctx context.Context, apiURL string, ids []uint64) (wire.OperatorsCLI, error) {
+	client := req.C()
+	client.SetTimeout(30 * time.Second)
+	apiURL = strings.TrimRight(apiURL, "/")
+	operators := make(wire.OperatorsCLI, 0, len(ids))
+	for _, id := range ids {
+		resp, err := client.R().SetContext(ctx).Get(fmt.Sprintf("%s/operators/%d", apiURL, id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch operator %d: %w", id, err)
+		}
+		if !resp.IsSuccessState() {
+			return nil, fmt.Errorf("failed to fetch operator %d: status %s", id, resp.Status)
+		}
+		var data operatorAPIJSON
+		if err := json.Unmarshal(resp.Bytes(), &data); err != nil {
+			return nil, fmt.Errorf("failed to parse operator %d: %w", id, err)
+		}
+		if data.ID != id {
+			return nil, fmt.Errorf("requested operator %d, got operator %d", id, data.ID)
+		}
+		if data.DKGAddress == "" {
+			return nil, fmt.Errorf("operator %d has no DKG endpoint", id)
+		}
+		if _, err := url.ParseRequestURI(data.DKGAddress); err != nil {
+			return nil, fmt.Errorf("invalid DKG endpoint of operator %d: %w", id, err)
+		}
+		pk, err := wire.ParseRSAPublicKey([]byte(data.PubKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of operator %d: %w", id, err)
+		}
+		op := wire.OperatorCLI{
+			Addr:   strings.TrimRight(data.DKGAddress, "/"),
+			ID:     id,
+			PubKey: pk,
+		}
+		operators = append(operators, op)
+	}
+	return operators, nil
+}
+
+// PinOperators checks public keys of the operators against the keys pinned at the cache file, and pins keys of new
+// operators. IDs of operators with a key changed since it was pinned are returned, callers shouldn't start a ceremony
+// with them. The pinned key is kept until the operator is removed from the cache file. The file has the format of
+// operators info file.
+func PinOperators(logger *zap.Logger, cachePath string, operators wire.OperatorsCLI) ([]uint64, error) {
+	var pinned wire.OperatorsCLI
+	data, err := os.ReadFile(filepath.Clean(cachePath))
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &pinned); err != nil {
+			return nil, fmt.Errorf("failed to parse operators cache: %w", err)
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return nil, fmt.Errorf("failed to read operators cache: %w", err)
+	}
+	var changed []uint64
+	updated := false
+	for _, op := range operators {
+		pinnedOp := pinned.ByID(op.ID)
+		if pinnedOp == nil {
+			pinned = append(pinned, op)
+			updated = true
+			continue
+		}
+		pinnedKey, err := crypto.EncodeRSAPublicKey(pinnedOp.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		key, err := crypto.EncodeRSAPublicKey(op.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		if string(pinnedKey) != string(key) {
+			logger.Error("😥 operator public key changed since it was pinned, remove the operator from the cache if the change is expected",
+				zap.Uint64("ID", op.ID),
+				zap.String("pinned public key", string(pinnedKey)),
+				zap.String("public key", string(key)),
+				zap.String("cache", cachePath))
+			changed = append(changed, op.ID)
+		}
+	}
+	if !updated {
+		return changed, nil
+	}
+	data, err = json.MarshalIndent(pinned, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(cachePath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write operators cache: %w", err)
+	}
+	return changed, nil
+}
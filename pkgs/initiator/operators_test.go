@@ -1,11 +1,20 @@
 package initiator
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
@@ -91,3 +100,86 @@ func mustParseRSAPublicKey(t *testing.T, key string) *rsa.PublicKey {
 	}
 	return pk
 }
+
+// operatorsAPI stands in for SSV API serving the operators
+func operatorsAPI(t *testing.T, operators map[uint64]map[string]interface{}) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/api/v4/holesky/operators/"), 10, 64)
+		if err != nil || operators[id] == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(operators[id]))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func encodedRSAPublicKey(t *testing.T) string {
+	_, pk, err := crypto.GenerateRSAKeys()
+	require.NoError(t, err)
+	encoded, err := crypto.EncodeRSAPublicKey(pk)
+	require.NoError(t, err)
+	return string(encoded)
+}
+
+func TestFetchOperators(t *testing.T) {
+	keys := []string{encodedRSAPublicKey(t), encodedRSAPublicKey(t)}
+	srv := operatorsAPI(t, map[uint64]map[string]interface{}{
+		1: {"id": 1, "public_key": keys[0], "dkg_address": "https://operator1:3030/"},
+		2: {"id": 2, "public_key": keys[1], "dkg_address": "https://operator2:3030"},
+		3: {"id": 3, "public_key": keys[1], "dkg_address": ""},
+		4: {"id": 4, "public_key": "invalid", "dkg_address": "https://operator4:3030"},
+	})
+	apiURL := srv.URL + "/api/v4/holesky/"
+
+	t.Run("happy flow", func(t *testing.T) {
+		ops, err := FetchOperators(context.Background(), apiURL, []uint64{1, 2})
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+		for i, op := range ops {
+			require.EqualValues(t, i+1, op.ID)
+			require.Equal(t, fmt.Sprintf("https://operator%d:3030", i+1), op.Addr)
+			require.Equal(t, mustParseRSAPublicKey(t, keys[i]), op.PubKey)
+		}
+	})
+	t.Run("unknown operator", func(t *testing.T) {
+		_, err := FetchOperators(context.Background(), apiURL, []uint64{1, 5})
+		require.ErrorContains(t, err, "failed to fetch operator 5")
+	})
+	t.Run("operator without DKG endpoint", func(t *testing.T) {
+		_, err := FetchOperators(context.Background(), apiURL, []uint64{3})
+		require.ErrorContains(t, err, "operator 3 has no DKG endpoint")
+	})
+	t.Run("invalid public key", func(t *testing.T) {
+		_, err := FetchOperators(context.Background(), apiURL, []uint64{4})
+		require.ErrorContains(t, err, "invalid public key of operator 4")
+	})
+}
+
+func TestPinOperators(t *testing.T) {
+	logger := zap.NewNop()
+	cachePath := filepath.Join(t.TempDir(), "operators_info_cache.json")
+	operator := func(id uint64, key string) wire.OperatorCLI {
+		return wire.OperatorCLI{ID: id, Addr: fmt.Sprintf("https://operator%d:3030", id), PubKey: mustParseRSAPublicKey(t, key)}
+	}
+	keys := []string{encodedRSAPublicKey(t), encodedRSAPublicKey(t), encodedRSAPublicKey(t)}
+
+	changed, err := PinOperators(logger, cachePath, wire.OperatorsCLI{operator(1, keys[0])})
+	require.NoError(t, err)
+	require.Empty(t, changed)
+	// a new operator is pinned, a changed key is reported
+	changed, err = PinOperators(logger, cachePath, wire.OperatorsCLI{operator(1, keys[2]), operator(2, keys[1])})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, changed)
+	// the pinned key is kept
+	changed, err = PinOperators(logger, cachePath, wire.OperatorsCLI{operator(1, keys[2]), operator(2, keys[1])})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, changed)
+
+	data, err := os.ReadFile(cachePath)
+	require.NoError(t, err)
+	var pinned wire.OperatorsCLI
+	require.NoError(t, json.Unmarshal(data, &pinned))
+	require.Equal(t, wire.OperatorsCLI{operator(1, keys[0]), operator(2, keys[1])}, pinned)
+}
@@ -0,0 +1,150 @@
+package initiator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// PreflightResult is the state of an operator checked before ceremonies start
+type PreflightResult struct {
+	ID       uint64
+	Addr     string
+	Version  string            // release version of the operator
+	Versions wire.VersionRange // protocol versions supported by the operator
+	Err      error             // reason the operator can't participate in ceremonies, nil if the check passed
+}
+
+// PreflightReport holds results of the preflight check ordered by operator ID
+type PreflightReport []PreflightResult
+
+// Failed returns results of operators which failed the check
+func (r PreflightReport) Failed() PreflightReport {
+	var failed PreflightReport
+	for _, res := range r {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err returns an error describing each operator which failed the check, nil if all of them passed
+func (r PreflightReport) Err() error {
+	var err error
+	for _, res := range r.Failed() {
+		err = errors.Join(err, fmt.Errorf("operator ID: %d, %s: %w", res.ID, res.Addr, res.Err))
+	}
+	return err
+}
+
+// Preflight checks operators before ceremonies start. Each operator should answer the health check with the public key
+// it has at operators info, support a protocol version of the initiator and present a valid TLS certificate.
+func (c *Initiator) Preflight(ctx context.Context, ids []uint64) PreflightReport {
+	resc := make(chan PreflightResult, len(ids))
+	for _, id := range ids {
+		go func(id uint64) {
+			_, res := c.checkOperator(ctx, id)
+			resc <- res
+		}(id)
+	}
+	report := make(PreflightReport, 0, len(ids))
+	for range ids {
+		res := <-resc
+		if res.Err != nil {
+			c.Logger.Error("😥 operator failed preflight check", zap.Uint64("ID", res.ID), zap.String("IP", res.Addr), zap.Error(res.Err))
+		} else {
+			c.Logger.Info("🍎 operator passed preflight check", zap.Uint64("ID", res.ID), zap.String("IP", res.Addr), zap.String("Version", res.Version), zap.String("Protocol versions", res.Versions.String()))
+		}
+		report = append(report, res)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].ID < report[j].ID })
+	return report
+}
+
+// checkOperator requests the health check of the operator and verifies its pong
+func (c *Initiator) checkOperator(ctx context.Context, id uint64) (*wire.Pong, PreflightResult) {
+	res := PreflightResult{ID: id}
+	op := c.Operators.ByID(id)
+	if op == nil {
+		res.Err = fmt.Errorf("operator is not in operators info")
+		return nil, res
+	}
+	res.Addr = op.Addr
+	ctx, cancel := c.timeoutContext(ctx, c.RequestTimeout)
+	defer cancel()
+	resp, err := c.Client.R().SetContext(ctx).Get(fmt.Sprintf("%v/%v", op.Addr, consts.API_HEALTH_CHECK_URL))
+	if err != nil {
+		res.Err = fmt.Errorf("health check failed: %w", err)
+		return nil, res
+	}
+	if resp.Response != nil {
+		if err := checkTLSCertificate(resp.Response.TLS, c.Client.GetTLSClientConfig().RootCAs, time.Now()); err != nil {
+			res.Err = err
+			return nil, res
+		}
+	}
+	signedPongMsg, pong, err := parsePong(resp.Bytes())
+	if err != nil {
+		res.Err = fmt.Errorf("health check failed: %w", err)
+		return nil, res
+	}
+	res.Version = string(signedPongMsg.Message.Version)
+	res.Versions = wire.VersionRange{Min: string(pong.MinVersion), Max: string(pong.MaxVersion)}
+	if pong.ID != id {
+		res.Err = fmt.Errorf("health check is answered by a different operator %d", pong.ID)
+		return nil, res
+	}
+	pubKey, err := crypto.EncodeRSAPublicKey(op.PubKey)
+	if err != nil {
+		res.Err = err
+		return nil, res
+	}
+	if !bytes.Equal(pong.PubKey, pubKey) {
+		res.Err = fmt.Errorf("operator public key doesn't match operators info")
+		return nil, res
+	}
+	if _, err := wire.NegotiateVersion(c.Versions, res.Versions); err != nil {
+		res.Err = fmt.Errorf("wrong version: operator supports %s, initiator supports %s", res.Versions, c.Versions)
+		return nil, res
+	}
+	return pong, res
+}
+
+// checkTLSCertificate checks that the operator TLS certificate is valid at the time and, if CA certificates of
+// operators are set, that its chain is signed by one of them. Without CA certificates only the validity period
+// is checked. Plain HTTP operators aren't checked.
+func checkTLSCertificate(state *tls.ConnectionState, roots *x509.CertPool, now time.Time) error {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	cert := state.PeerCertificates[0]
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("TLS certificate isn't valid before %s", cert.NotBefore)
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("TLS certificate expired at %s", cert.NotAfter)
+	}
+	if roots == nil {
+		return nil
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range state.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, CurrentTime: now}); err != nil {
+		return fmt.Errorf("TLS certificate isn't signed by CA certificates of operators: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package initiator
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTLSCertificate(t *testing.T) {
+	now := time.Now()
+	state := func(notBefore, notAfter time.Time) *tls.ConnectionState {
+		return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{NotBefore: notBefore, NotAfter: notAfter}}}
+	}
+	require.NoError(t, checkTLSCertificate(state(now.Add(-time.Hour), now.Add(time.Hour)), nil, now))
+	require.ErrorContains(t, checkTLSCertificate(state(now.Add(-2*time.Hour), now.Add(-time.Hour)), nil, now), "TLS certificate expired")
+	require.ErrorContains(t, checkTLSCertificate(state(now.Add(time.Hour), now.Add(2*time.Hour)), nil, now), "TLS certificate isn't valid before")
+	require.NoError(t, checkTLSCertificate(nil, nil, now))
+	require.NoError(t, checkTLSCertificate(&tls.ConnectionState{}, nil, now))
+
+	t.Run("certificate chain", func(t *testing.T) {
+		cert := readCertificate(t, "../../integration_test/certs/localhost.crt")
+		certState := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		validAt := cert.NotBefore.Add(time.Hour)
+		roots := x509.NewCertPool()
+		roots.AddCert(readCertificate(t, "../../integration_test/certs/rootCA.crt"))
+		require.NoError(t, checkTLSCertificate(certState, roots, validAt))
+		require.ErrorContains(t, checkTLSCertificate(certState, x509.NewCertPool(), validAt), "TLS certificate isn't signed by CA certificates of operators")
+	})
+}
+
+func readCertificate(t *testing.T, path string) *x509.Certificate {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestPreflightReport(t *testing.T) {
+	report := PreflightReport{
+		{ID: 1, Addr: "https://1.1.1.1:3030"},
+		{ID: 2, Addr: "https://2.2.2.2:3030", Err: errors.New("health check failed")},
+		{ID: 3, Addr: "https://3.3.3.3:3030", Err: errors.New("operator public key doesn't match operators info")},
+	}
+	require.Len(t, report.Failed(), 2)
+	err := report.Err()
+	require.EqualError(t, err, "operator ID: 2, https://2.2.2.2:3030: health check failed\noperator ID: 3, https://3.3.3.3:3030: operator public key doesn't match operators info")
+	require.NoError(t, report[:1].Err())
+	require.Empty(t, report[:1].Failed())
+}
@@ -1,9 +1,12 @@
 package initiator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -17,34 +20,69 @@ type opReqResult struct {
 	result     []byte
 }
 
-// SendAndCollect ssends http message to operator and read the response
-func (c *Initiator) SendAndCollect(op wire.OperatorCLI, method string, data []byte, checkError bool) ([]byte, error) {
-	r := c.Client.R()
+// SendAndCollect ssends http message to operator and read the response. Requests failed with a transient error,
+// a network failure or a server error, are retried with exponential backoff. The request is aborted when the context is done.
+func (c *Initiator) SendAndCollect(ctx context.Context, op wire.OperatorCLI, method string, data []byte, checkError bool) ([]byte, error) {
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resdata, status, err := c.post(ctx, op, method, data)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if attempt < c.Retries && (err != nil || isTransientStatus(status)) {
+			c.Logger.Warn("request to operator failed, retrying", zap.Uint64("operator", op.ID), zap.String("method", method), zap.Int("status", status), zap.Error(err), zap.Duration("backoff", backoff))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.Logger.Debug("operator responded", zap.Uint64("operator", op.ID), zap.String("method", method))
+		if checkError {
+			if status < 200 || status >= 300 {
+				errmsg, parseErr := wire.ParseAsError(resdata)
+				if parseErr == nil {
+					return nil, fmt.Errorf("%v", errmsg)
+				}
+				return nil, fmt.Errorf("operator %d failed with: %w", op.ID, errors.New(string(resdata)))
+			}
+		}
+		return resdata, nil
+	}
+}
+
+// post sends http message to operator and returns the response body and status code
+func (c *Initiator) post(ctx context.Context, op wire.OperatorCLI, method string, data []byte) ([]byte, int, error) {
+	ctx, cancel := c.timeoutContext(ctx, c.RequestTimeout)
+	defer cancel()
+	r := c.Client.R().SetContext(ctx)
 	r.SetBodyBytes(data)
 	res, err := r.Post(fmt.Sprintf("%v/%v", op.Addr, method))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	resdata, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
-	}
-	c.Logger.Debug("operator responded", zap.Uint64("operator", op.ID), zap.String("method", method))
-	if checkError {
-		if res.StatusCode < 200 || res.StatusCode >= 300 {
-			errmsg, parseErr := wire.ParseAsError(resdata)
-			if parseErr == nil {
-				return nil, fmt.Errorf("%v", errmsg)
-			}
-			return nil, fmt.Errorf("operator %d failed with: %w", op.ID, errors.New(string(resdata)))
-		}
+		return nil, 0, err
 	}
-	return resdata, nil
+	return resdata, res.StatusCode, nil
+}
+
+// isTransientStatus checks if a request failed with the status code can succeed if retried
+func isTransientStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
 }
 
 // GetAndCollect request Get at operator route
-func (c *Initiator) GetAndCollect(op wire.OperatorCLI, method string) ([]byte, error) {
-	r := c.Client.R()
+func (c *Initiator) GetAndCollect(ctx context.Context, op wire.OperatorCLI, method string) ([]byte, error) {
+	ctx, cancel := c.timeoutContext(ctx, c.RequestTimeout)
+	defer cancel()
+	r := c.Client.R().SetContext(ctx)
 	res, err := r.Get(fmt.Sprintf("%v/%v", op.Addr, method))
 	if err != nil {
 		return nil, err
@@ -58,7 +96,7 @@ func (c *Initiator) GetAndCollect(op wire.OperatorCLI, method string) ([]byte, e
 }
 
 // SendToAll sends http messages to all operators. Makes sure that all responses are received
-func (c *Initiator) SendToAll(method string, msg []byte, operators []*wire.Operator, checkError bool) ([][]byte, error) {
+func (c *Initiator) SendToAll(ctx context.Context, method string, msg []byte, operators []*wire.Operator, checkError bool) ([][]byte, error) {
 	resc := make(chan opReqResult, len(operators))
 	for _, wireOp := range operators {
 		operator := c.Operators.ByID(wireOp.ID)
@@ -66,7 +104,7 @@ func (c *Initiator) SendToAll(method string, msg []byte, operators []*wire.Opera
 			return nil, fmt.Errorf("operator ID: %d not found in operators list", wireOp.ID)
 		}
 		go func() {
-			res, err := c.SendAndCollect(*operator, method, msg, checkError)
+			res, err := c.SendAndCollect(ctx, *operator, method, msg, checkError)
 			resc <- opReqResult{
 				operatorID: operator.ID,
 				err:        err,
@@ -95,3 +133,37 @@ func (c *Initiator) SendToAll(method string, msg []byte, operators []*wire.Opera
 
 	return final, finalerr
 }
+
+// SendToAllTolerant sends http messages to all operators. Unlike SendToAll it doesn't fail if some of operators fail,
+// responses and errors are returned per operator ID
+func (c *Initiator) SendToAllTolerant(ctx context.Context, method string, msg []byte, operators []*wire.Operator) (map[uint64][]byte, map[uint64]error) {
+	resc := make(chan opReqResult, len(operators))
+	errs := make(map[uint64]error)
+	sent := 0
+	for _, wireOp := range operators {
+		operator := c.Operators.ByID(wireOp.ID)
+		if operator == nil {
+			errs[wireOp.ID] = fmt.Errorf("operator ID: %d not found in operators list", wireOp.ID)
+			continue
+		}
+		sent++
+		go func() {
+			res, err := c.SendAndCollect(ctx, *operator, method, msg, true)
+			resc <- opReqResult{
+				operatorID: operator.ID,
+				err:        err,
+				result:     res,
+			}
+		}()
+	}
+	final := make(map[uint64][]byte, len(operators))
+	for i := 0; i < sent; i++ {
+		res := <-resc
+		if res.err != nil {
+			errs[res.operatorID] = res.err
+			continue
+		}
+		final[res.operatorID] = res.result
+	}
+	return final, errs
+}
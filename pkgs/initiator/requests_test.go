@@ -0,0 +1,98 @@
+package initiator_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func TestSendAndCollectRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	op := wire.OperatorCLI{Addr: srv.URL, ID: 1}
+	clnt, err := initiator.New(wire.OperatorsCLI{op}, zap.L().Named("retry-tests"), "test.version", rootCert)
+	require.NoError(t, err)
+	clnt.RetryBackoff = time.Millisecond
+	t.Run("test transient errors retried", func(t *testing.T) {
+		res, err := clnt.SendAndCollect(context.Background(), op, "dkg", []byte("msg"), true)
+		require.NoError(t, err)
+		require.Equal(t, []byte("ok"), res)
+		require.Equal(t, int32(3), calls.Load())
+	})
+	t.Run("test retries exhausted", func(t *testing.T) {
+		calls.Store(0)
+		clnt.Retries = 1
+		_, err := clnt.SendAndCollect(context.Background(), op, "dkg", []byte("msg"), true)
+		require.Error(t, err)
+		require.Equal(t, int32(2), calls.Load())
+	})
+}
+
+func TestSendAndCollectContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	var ops wire.OperatorsCLI
+	for id := uint64(1); id <= 4; id++ {
+		_, pk, err := crypto.GenerateRSAKeys()
+		require.NoError(t, err)
+		ops = append(ops, wire.OperatorCLI{Addr: srv.URL, ID: id, PubKey: pk})
+	}
+	clnt, err := initiator.New(ops, zap.L().Named("context-tests"), "test.version", rootCert)
+	require.NoError(t, err)
+	t.Run("test cancelled request isn't retried", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := clnt.SendAndCollect(ctx, ops[0], "dkg", []byte("msg"), true)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Less(t, time.Since(start), clnt.RetryBackoff)
+	})
+	t.Run("test phase deadline aborts the ceremony", func(t *testing.T) {
+		clnt.PhaseTimeout = 100 * time.Millisecond
+		start := time.Now()
+		_, _, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), common.HexToAddress("0x0000001").Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "mainnet", common.HexToAddress("0x0000002"), 0)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), clnt.RetryBackoff)
+	})
+	t.Run("test phase deadline scales with validators of the ceremony", func(t *testing.T) {
+		clnt.PhaseTimeout = 100 * time.Millisecond
+		start := time.Now()
+		_, err := clnt.StartBatchDKG(context.Background(), crypto.NewID(), common.HexToAddress("0x0000001").Bytes(), crypto.ETH1WithdrawalPrefixByte, crypto.MaxEffectiveBalanceInGwei, []uint64{1, 2, 3, 4}, "mainnet", common.HexToAddress("0x0000002"), 0, 5)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.GreaterOrEqual(t, time.Since(start), 5*clnt.PhaseTimeout)
+	})
+	t.Run("test request timeout is retried", func(t *testing.T) {
+		clnt.RequestTimeout = 100 * time.Millisecond
+		clnt.RetryBackoff = 10 * time.Millisecond
+		clnt.Retries = 1
+		start := time.Now()
+		_, err := clnt.SendAndCollect(context.Background(), ops[0], "dkg", []byte("msg"), true)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.GreaterOrEqual(t, time.Since(start), 2*clnt.RequestTimeout)
+	})
+}
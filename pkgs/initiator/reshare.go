@@ -0,0 +1,288 @@
+package initiator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+)
+
+// ConstructReshareMessage validates keyshares and proofs of a previous ceremony and creates a reshare message
+// to redistribute key shares of the validator from old operators to new operators.
+// The new cluster can be of a different size than the old one, thresholds are computed for each cluster separately.
+// Old operators staying in the new cluster deal their shares and receive new ones as well. If there are at least
+// old threshold of them, old operators leaving the cluster don't take part in the ceremony.
+// Nonce is the current owner nonce at SSV contract to sign the new keyshares. Deposit data is signed again
+// with withdrawal credentials of the withdrawal prefix and the deposit amount.
+// Owner should sign hash tree root of the returned reshare before starting the ceremony: an ECDSA signature
+// for EOA owners or a signature accepted by EIP-1271 isValidSignature for smart contract wallets.
+func (c *Initiator) ConstructReshareMessage(newOpIDs []uint64, keyshares *wire.KeySharesCLI, proofs []*wire.SignedProof, withdraw []byte, withdrawalPrefix byte, amount phase0.Gwei, network eth2_key_manager_core.Network, nonce uint64) (*wire.ReshareMessage, error) {
+	if withdrawalPrefix != crypto.BLSWithdrawalPrefixByte && len(withdraw) != len(common.Address{}) {
+		return nil, fmt.Errorf("incorrect withdrawal address length")
+	}
+	if _, err := crypto.WithdrawalCredentials(withdrawalPrefix, withdraw); err != nil {
+		return nil, err
+	}
+	if err := crypto.ValidateDepositAmount(withdrawalPrefix, amount); err != nil {
+		return nil, err
+	}
+	oldOps, validatorPK, owner, err := c.validatedKeyshares(keyshares, proofs)
+	if err != nil {
+		return nil, err
+	}
+	newOps, err := ValidatedOperatorData(newOpIDs, c.Operators)
+	if err != nil {
+		return nil, err
+	}
+	if spec.EqualOperators(oldOps, newOps) {
+		return nil, fmt.Errorf("new operators should differ from old operators")
+	}
+
+	return &wire.ReshareMessage{
+		SignedReshare: &wire.SignedReshare{
+			Reshare: wire.Reshare{
+				ValidatorPubKey: validatorPK,
+				OldOperators:    oldOps,
+				NewOperators:    newOps,
+				OldT:            uint64(len(oldOps) - ((len(oldOps) - 1) / 3)),
+				NewT:            uint64(len(newOps) - ((len(newOps) - 1) / 3)),
+				Owner:           owner,
+				Nonce:           nonce,
+			},
+		},
+		Proofs:                proofs,
+		WithdrawalCredentials: withdraw,
+		Fork:                  network.GenesisForkVersion(),
+		WithdrawalPrefix:      withdrawalPrefix,
+		Amount:                uint64(amount),
+		PhaseTimeout:          uint64(c.DKGPhaseTimeout.Milliseconds()),
+	}, nil
+}
+
+// validatedKeyshares checks keyshares of one validator and proofs of the ceremony which created them,
+// proofs are expected in the same order as operators. Returns operators, validator public key and owner of the keyshares.
+func (c *Initiator) validatedKeyshares(keyshares *wire.KeySharesCLI, proofs []*wire.SignedProof) ([]*wire.Operator, []byte, common.Address, error) {
+	if len(keyshares.Shares) != 1 {
+		return nil, nil, common.Address{}, fmt.Errorf("keyshares should contain exactly one validator share")
+	}
+	shareData := keyshares.Shares[0]
+	ops, err := ValidatedOperatorData(shareData.Payload.OperatorIDs, c.Operators)
+	if err != nil {
+		return nil, nil, common.Address{}, err
+	}
+	if !spec.EqualOperators(ops, shareData.ShareData.Operators) {
+		return nil, nil, common.Address{}, fmt.Errorf("operators info doesn't match keyshares")
+	}
+	if len(proofs) != len(ops) {
+		return nil, nil, common.Address{}, fmt.Errorf("proofs count doesn't match operators count")
+	}
+	validatorPK, err := hex.DecodeString(strings.TrimPrefix(shareData.ShareData.PublicKey, "0x"))
+	if err != nil {
+		return nil, nil, common.Address{}, fmt.Errorf("failed to decode validator public key: %w", err)
+	}
+	if !common.IsHexAddress(shareData.ShareData.OwnerAddress) {
+		return nil, nil, common.Address{}, fmt.Errorf("invalid owner address at keyshares")
+	}
+	owner := common.HexToAddress(shareData.ShareData.OwnerAddress)
+	for i, op := range ops {
+		if err := spec.ValidateCeremonyProof(owner, validatorPK, op, *proofs[i]); err != nil {
+			return nil, nil, common.Address{}, fmt.Errorf("proof of operator %d is invalid: %w", op.ID, err)
+		}
+	}
+	return ops, validatorPK, owner, nil
+}
+
+// StartReshare starts resharing ceremony at initiator: key shares of a validator created at a previous ceremony
+// are redistributed from old operators to new operators. Validator public key stays the same.
+// Cancelling the context aborts the ceremony and in-flight requests to operators.
+func (c *Initiator) StartReshare(ctx context.Context, id [24]byte, reshare *wire.ReshareMessage) (*wire.DepositDataCLI, *wire.KeySharesCLI, []*wire.SignedProof, error) {
+	if reshare.SignedReshare == nil || len(reshare.SignedReshare.Signature) == 0 {
+		return nil, nil, nil, fmt.Errorf("reshare message should be signed by the owner")
+	}
+	oldOps := reshare.SignedReshare.Reshare.OldOperators
+	newOps := reshare.SignedReshare.Reshare.NewOperators
+	validatorPK := reshare.SignedReshare.Reshare.ValidatorPubKey
+	pkBytes, err := crypto.EncodeRSAPublicKey(&c.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	instanceIDField := zap.String("reshare ID", hex.EncodeToString(id[:]))
+	c.Logger.Info("🚀 Starting resharing ceremony", zap.String("initiator public key", string(pkBytes)), zap.Uint64s("old operator IDs", operatorIDs(oldOps)), zap.Uint64s("new operator IDs", operatorIDs(newOps)), instanceIDField)
+	c.Logger = c.Logger.With(instanceIDField)
+
+	dkgResultsBytes, err := c.reshareMessageFlowHandling(ctx, reshare, id, oldOps, newOps)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dkgResults, err := parseDKGResultsFromBytes(dkgResultsBytes, id, 1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !bytes.Equal(dkgResults[0][0].SignedProof.Proof.ValidatorPubKey, validatorPK) {
+		return nil, nil, nil, fmt.Errorf("resharing resulted in a different validator public key")
+	}
+	// results of resharing are verified the same way as results of a new DKG ceremony with new operators
+	init := &wire.Init{
+		Operators:             newOps,
+		T:                     reshare.SignedReshare.Reshare.NewT,
+		WithdrawalCredentials: reshare.WithdrawalCredentials,
+		Fork:                  reshare.Fork,
+		Owner:                 reshare.SignedReshare.Reshare.Owner,
+		Nonce:                 reshare.SignedReshare.Reshare.Nonce,
+		WithdrawalPrefix:      reshare.WithdrawalPrefix,
+		Amount:                reshare.Amount,
+	}
+	res, err := c.processAndSendResults(ctx, dkgResults, init, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return res[0].DepositData, res[0].KeyShares, res[0].Proofs, nil
+}
+
+// reshareMessageFlowHandling main steps of resharing at initiator. Old operators which don't deal aren't contacted,
+// so an operator leaving the cluster can be offline.
+func (c *Initiator) reshareMessageFlowHandling(ctx context.Context, reshare *wire.ReshareMessage, id [24]byte, oldOps, newOps []*wire.Operator) ([][]byte, error) {
+	dealers := spec.ReshareDealers(&reshare.SignedReshare.Reshare)
+	if len(dealers) < len(oldOps) {
+		c.Logger.Info("old operators leaving the cluster don't deal", zap.Uint64s("dealer IDs", operatorIDs(dealers)))
+	}
+	allOps := append([]*wire.Operator{}, dealers...)
+	var joiningOps, stayingOps []*wire.Operator
+	for _, op := range newOps {
+		if spec.GetOperator(oldOps, op.ID) != nil {
+			stayingOps = append(stayingOps, op)
+			continue
+		}
+		joiningOps = append(joiningOps, op)
+		allOps = append(allOps, op)
+	}
+	c.Logger.Info("phase 1: sending reshare message to dealing old operators and new operators")
+	exchanges, err := c.SendReshareMsg(ctx, reshare, id, allOps)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyMessageSignatures(id, exchanges, c.VerifyMessageSignature)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 1: ✅ verified operator reshare responses signatures")
+
+	c.Logger.Info("phase 2: ➡️ sending exchange messages to dealing old operators")
+	phaseCtx, cancel := c.phaseContext(ctx)
+	deals, err := c.SendExchangeMsgs(phaseCtx, exchanges, id, dealers)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	err = verifyMessageSignatures(id, deals, c.VerifyMessageSignature)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 2: ✅ verified dealing old operator responses (deal messages) signatures")
+
+	c.Logger.Info("phase 3: ➡️ sending exchange and deal messages to new operators")
+	// old operators staying in the cluster already have all exchanges from phase 2, they receive only deals
+	phaseCtx, cancel = c.phaseContext(ctx)
+	responses, err := c.sendReshareKyberMsgs(phaseCtx, id, append(append([][]byte{}, exchanges...), deals...), joiningOps, deals, stayingOps)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	err = verifyMessageSignatures(id, responses, c.VerifyMessageSignature)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 3: ✅ verified new operator responses (response messages) signatures")
+
+	c.Logger.Info("phase 4: ➡️ sending response messages to new operators")
+	phaseCtx, cancel = c.phaseContext(ctx)
+	dkgResult, err := c.SendKyberMsgs(phaseCtx, responses, id, newOps)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	err = verifyMessageSignatures(id, dkgResult, c.VerifyMessageSignature)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 4: ✅ verified new operator results signatures")
+	return dkgResult, nil
+}
+
+// sendReshareKyberMsgs concurrently sends kyber messages to operators joining the cluster and to old operators staying in the cluster
+func (c *Initiator) sendReshareKyberMsgs(ctx context.Context, id [24]byte, joiningMsgs [][]byte, joiningOps []*wire.Operator, stayingMsgs [][]byte, stayingOps []*wire.Operator) ([][]byte, error) {
+	type sendResult struct {
+		results [][]byte
+		err     error
+	}
+	resc := make(chan sendResult, 2)
+	send := func(msgs [][]byte, ops []*wire.Operator) {
+		if len(ops) == 0 {
+			resc <- sendResult{}
+			return
+		}
+		res, err := c.SendKyberMsgs(ctx, msgs, id, ops)
+		resc <- sendResult{results: res, err: err}
+	}
+	go send(joiningMsgs, joiningOps)
+	go send(stayingMsgs, stayingOps)
+	var results [][]byte
+	var errs []error
+	for i := 0; i < 2; i++ {
+		res := <-resc
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		results = append(results, res.results...)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return results, nil
+}
+
+// SendReshareMsg sends initial resharing ceremony message to old and new operators from initiator, operators waiting for
+// approval get it again. Reshare message which isn't signed by the owner finishes a threshold tolerant ceremony,
+// it doesn't need the reshare feature.
+func (c *Initiator) SendReshareMsg(ctx context.Context, reshare *wire.ReshareMessage, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+	feature := wire.FeatureReshare
+	if reshare.SignedReshare != nil && len(reshare.SignedReshare.Signature) == 0 {
+		feature = wire.FeatureThresholdTolerant
+	}
+	phaseCtx, cancel := c.phaseContext(ctx)
+	version, err := c.negotiateVersionAll(phaseCtx, operators, feature)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	signedReshareMsgBts, err := c.prepareAndSignMessage(reshare, wire.ReshareMessageType, id, version)
+	if err != nil {
+		return nil, err
+	}
+	responses := make(map[uint64][]byte, len(operators))
+	if err := c.sendApprovalPhase(ctx, nil, responses, id, consts.API_RESHARE_URL, signedReshareMsgBts, operators); err != nil {
+		return nil, err
+	}
+	return phaseResponses(responses, operators), nil
+}
+
+func operatorIDs(ops []*wire.Operator) []uint64 {
+	ids := make([]uint64, len(ops))
+	for i, op := range ops {
+		ids[i] = op.ID
+	}
+	return ids
+}
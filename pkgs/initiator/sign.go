@@ -0,0 +1,145 @@
+package initiator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+)
+
+// ConstructBlsSignMessage validates keyshares and proofs of a ceremony and creates a request to sign a beacon message
+// with key shares of the validator kept by operators. Supported messages are *phase0.VoluntaryExit, signed with the
+// Capella fork version of the network, and *capella.BLSToExecutionChange from the validator public key.
+// Owner should sign hash tree root of the returned request before sending it: an ECDSA signature
+// for EOA owners or a signature accepted by EIP-1271 isValidSignature for smart contract wallets.
+func (c *Initiator) ConstructBlsSignMessage(keyshares *wire.KeySharesCLI, proofs []*wire.SignedProof, msg wire.SSZMarshaller, network eth2_key_manager_core.Network) (*wire.BlsSignMessage, error) {
+	ops, validatorPK, owner, err := c.validatedKeyshares(keyshares, proofs)
+	if err != nil {
+		return nil, err
+	}
+	var msgType wire.BeaconMessageType
+	var forkVersion phase0.Version
+	switch msg.(type) {
+	case *phase0.VoluntaryExit:
+		msgType = wire.VoluntaryExitBeaconMessage
+		forkVersion, err = crypto.ExitForkVersion(network)
+		if err != nil {
+			return nil, err
+		}
+	case *capella.BLSToExecutionChange:
+		msgType = wire.BLSToExecutionChangeBeaconMessage
+		forkVersion = network.GenesisForkVersion()
+	default:
+		return nil, fmt.Errorf("unsupported beacon message %T", msg)
+	}
+	data, err := msg.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal beacon message: %w", err)
+	}
+	req := wire.BlsSignRequest{
+		ValidatorPubKey:       validatorPK,
+		Operators:             ops,
+		Owner:                 owner,
+		MessageType:           msgType,
+		Message:               data,
+		ForkVersion:           forkVersion,
+		GenesisValidatorsRoot: network.GenesisValidatorsRoot(),
+	}
+	if _, err := spec.BlsSignRequestSigningRoot(&req); err != nil {
+		return nil, err
+	}
+	return &wire.BlsSignMessage{
+		SignedRequest: &wire.SignedBlsSignRequest{Request: req},
+		Proofs:        proofs,
+	}, nil
+}
+
+// SignBeaconMessage sends the owner signed request to operators and reconstructs the signature of the beacon message
+// from partial signatures. Responses of threshold operators are enough, failed operators are logged.
+func (c *Initiator) SignBeaconMessage(ctx context.Context, id [24]byte, msg *wire.BlsSignMessage) (phase0.BLSSignature, error) {
+	if msg.SignedRequest == nil || len(msg.SignedRequest.Signature) == 0 {
+		return phase0.BLSSignature{}, fmt.Errorf("sign request should be signed by the owner")
+	}
+	req := &msg.SignedRequest.Request
+	c.Logger.Info("🚀 Requesting operators to sign beacon message", zap.String("type", req.MessageType.String()), zap.Uint64s("operator IDs", operatorIDs(req.Operators)), zap.String("sign ID", hex.EncodeToString(id[:])))
+	ctx, cancel := c.phaseContext(ctx)
+	defer cancel()
+	// operators failing the health check are asked to sign anyway, responses of threshold operators are enough
+	version, versionErrs, err := c.negotiateVersion(ctx, req.Operators, wire.FeatureBlsSign)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	signedMsg, err := c.prepareAndSignMessage(msg, wire.BlsSignRequestType, id, version)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	approvalTimeout, err := c.approvalTimeout()
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	results, errs := c.sendSignRequest(ctx, signedMsg, req.Operators, approvalTimeout)
+	for opID, err := range versionErrs {
+		c.Logger.Warn("⚠️ operator failed the health check", zap.Uint64("operator", opID), zap.Error(err))
+	}
+	var responses []*wire.BlsSignResponse
+	for _, op := range req.Operators {
+		res, ok := results[op.ID]
+		if !ok {
+			continue
+		}
+		resp, err := parseBlsSignResponse(id, op, res, c.VerifyMessageSignature)
+		if err != nil {
+			errs[op.ID] = err
+			continue
+		}
+		responses = append(responses, resp)
+	}
+	for opID, err := range errs {
+		c.Logger.Error("😥 operator failed to sign beacon message", zap.Uint64("operator", opID), zap.Error(err))
+	}
+	sig, err := spec.ValidateBlsSignResponses(req, msg.Proofs, responses)
+	if err != nil {
+		var opErrs error
+		for opID, opErr := range errs {
+			opErrs = errors.Join(opErrs, fmt.Errorf("operator ID: %d, %w", opID, opErr))
+		}
+		return phase0.BLSSignature{}, errors.Join(err, opErrs)
+	}
+	c.Logger.Info("✅ beacon message signature was successfully reconstructed")
+	return sig, nil
+}
+
+// parseBlsSignResponse verifies signature of the operator over the response and returns the partial signature
+func parseBlsSignResponse(id [24]byte, op *wire.Operator, res []byte, verify VerifyMessageSignatureFunc) (*wire.BlsSignResponse, error) {
+	if err := verifyMessageSignatures(id, [][]byte{res}, verify); err != nil {
+		return nil, err
+	}
+	tsp := &wire.SignedTransport{}
+	if err := tsp.UnmarshalSSZ(res); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tsp.Signer, op.PubKey) {
+		return nil, fmt.Errorf("response isn't signed by the operator")
+	}
+	if tsp.Message.Type != wire.BlsSignResponseType {
+		return nil, fmt.Errorf("wrong sign response message type: exp %s, got %s", wire.BlsSignResponseType.String(), tsp.Message.Type.String())
+	}
+	resp := &wire.BlsSignResponse{}
+	if err := resp.UnmarshalSSZ(tsp.Message.Data); err != nil {
+		return nil, err
+	}
+	if resp.OperatorID != op.ID {
+		return nil, fmt.Errorf("wrong operator ID %d at response", resp.OperatorID)
+	}
+	return resp, nil
+}
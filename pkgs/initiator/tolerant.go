@@ -0,0 +1,327 @@
+package initiator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+)
+
+// maxRelayRounds is the number of times kyber bundles are relayed between operators: response bundles of all operators
+// and justification bundles answering complaints
+const maxRelayRounds = 2
+
+// tolerantCeremony holds the state of a threshold tolerant DKG ceremony at initiator
+type tolerantCeremony struct {
+	ctx      context.Context
+	c        *Initiator
+	id       [24]byte
+	init     *wire.Init
+	included []*wire.Operator // operators still participating in the ceremony
+	excluded []uint64         // IDs of operators excluded from the ceremony
+}
+
+// tolerantMessageFlowHandling main steps of a threshold tolerant DKG at initiator. Operators which fail to respond
+// at any phase are excluded, the ceremony goes on while at least threshold operators remain.
+// Response and justification bundles sent by operators are relayed to the rest of operators, so all of them
+// agree on the set of qualified dealers. Returns results of included operators and IDs of excluded operators.
+func (c *Initiator) tolerantMessageFlowHandling(ctx context.Context, init *wire.Init, ownerSignature []byte, id [24]byte, operators []*wire.Operator) ([]*wire.Result, []uint64, error) {
+	tc := &tolerantCeremony{
+		ctx:      ctx,
+		c:        c,
+		id:       id,
+		init:     init,
+		included: operators,
+	}
+	initMsg, initMsgType, features := initMessage(init, ownerSignature)
+	phaseCtx, cancel := c.phaseContext(ctx)
+	version, errs, err := c.negotiateVersion(phaseCtx, tc.included, features...)
+	cancel()
+	if err != nil {
+		return nil, nil, err
+	}
+	for opID, err := range errs {
+		tc.exclude(opID, err)
+	}
+	if uint64(len(tc.included)) < init.T {
+		return nil, nil, fmt.Errorf("not enough operators to finish DKG: %d left, threshold %d, excluded operators %v", len(tc.included), init.T, tc.excluded)
+	}
+	c.Logger.Info("phase 1: sending init message to operators")
+	signedInitMsgBts, err := c.prepareAndSignMessage(initMsg, initMsgType, id, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	exchanges, err := tc.sendToIncluded(consts.API_INIT_URL, signedInitMsgBts, tc.included)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Logger.Info("phase 1: ✅ verified operator init responses signatures")
+
+	c.Logger.Info("phase 2: ➡️ sending operator data (exchange messages) required for dkg")
+	deals, err := tc.sendMultiple(tc.collect(exchanges), tc.included)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Logger.Info("phase 2: ✅ verified operator responses (deal messages) signatures")
+
+	c.Logger.Info("phase 3: ➡️ sending deal dkg data to all operators")
+	results := make(map[uint64]*wire.Result)
+	pending := tc.included
+	msgs := tc.collect(deals)
+	for round := 0; ; round++ {
+		replies, err := tc.sendMultiple(msgs, pending)
+		if err != nil {
+			return nil, nil, err
+		}
+		var bundles [][]byte
+		var next []*wire.Operator
+		for _, op := range pending {
+			tsp, ok := replies[op.ID]
+			if !ok {
+				continue
+			}
+			switch tsp.Message.Type {
+			case wire.OutputMessageType:
+				result := &wire.Result{}
+				if err := result.UnmarshalSSZ(tsp.Message.Data); err != nil {
+					tc.exclude(op.ID, err)
+					continue
+				}
+				if result.OperatorID != op.ID || !bytes.Equal(result.RequestID[:], id[:]) {
+					tc.exclude(op.ID, fmt.Errorf("DKG result has wrong operator ID or request ID"))
+					continue
+				}
+				results[op.ID] = result
+			case wire.KyberMessageType:
+				if round == maxRelayRounds {
+					tc.exclude(op.ID, fmt.Errorf("operator didn't finish DKG after %d relay rounds", maxRelayRounds))
+					continue
+				}
+				bts, err := tsp.MarshalSSZ()
+				if err != nil {
+					return nil, nil, err
+				}
+				bundles = append(bundles, bts)
+				next = append(next, op)
+			default:
+				tc.exclude(op.ID, fmt.Errorf("wrong DKG message type: %s", tsp.Message.Type.String()))
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		c.Logger.Info("phase 3: ➡️ relaying kyber messages to operators", zap.Int("round", round+1), zap.Uint64s("operator IDs", operatorIDs(next)))
+		msgs = bundles
+		pending = next
+	}
+	dkgResults, err := tc.qualifiedResults(results)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Logger.Info("phase 3: ✅ verified operator dkg results signatures")
+	sort.Slice(tc.excluded, func(i, j int) bool { return tc.excluded[i] < tc.excluded[j] })
+	return dkgResults, tc.excluded, nil
+}
+
+// reshareQualified reshares key shares of qualified operators of a threshold tolerant ceremony, which excluded some
+// of its operators, to the largest registrable cluster of them ordered by operator ID. The reshare message isn't signed
+// by the owner: operators accept it only from the initiator of the ceremony under the same request ID, so it keeps
+// parameters of the ceremony. Returns results of the new cluster and the init message of the ceremony with its operators.
+func (c *Initiator) reshareQualified(ctx context.Context, init *wire.Init, id [24]byte, results []*wire.Result) ([]*wire.Result, *wire.Init, error) {
+	qualified := make([]*wire.Operator, len(results))
+	proofs := make([]*wire.SignedProof, len(results))
+	for i, result := range results {
+		qualified[i] = spec.GetOperator(init.Operators, result.OperatorID)
+		proofs[i] = &results[i].SignedProof
+	}
+	size := spec.RegistrableClusterSize(len(qualified))
+	if size == 0 {
+		return nil, nil, fmt.Errorf("DKG ceremony finished without operators %v: keyshares of the remaining %d operators can't be registered at the ssv.network", c.ExcludedOperators, len(qualified))
+	}
+	newOps := qualified[:size]
+	validatorPK := results[0].SignedProof.Proof.ValidatorPubKey
+	reshare := &wire.ReshareMessage{
+		SignedReshare: &wire.SignedReshare{
+			Reshare: wire.Reshare{
+				ValidatorPubKey: validatorPK,
+				OldOperators:    qualified,
+				NewOperators:    newOps,
+				OldT:            init.T,
+				NewT:            uint64(size - ((size - 1) / 3)),
+				Owner:           init.Owner,
+				Nonce:           init.Nonce,
+			},
+		},
+		Proofs:                proofs,
+		WithdrawalCredentials: init.WithdrawalCredentials,
+		Fork:                  init.Fork,
+		WithdrawalPrefix:      init.WithdrawalPrefix,
+		Amount:                init.Amount,
+		PhaseTimeout:          init.PhaseTimeout,
+	}
+	c.Logger.Info("♻️ resharing key shares to a registrable cluster of the remaining operators", zap.Uint64s("operator IDs", operatorIDs(newOps)))
+	dkgResultsBytes, err := c.reshareMessageFlowHandling(ctx, reshare, id, qualified, newOps)
+	if err != nil {
+		return nil, nil, err
+	}
+	dkgResults, err := parseDKGResultsFromBytes(dkgResultsBytes, id, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(dkgResults[0][0].SignedProof.Proof.ValidatorPubKey, validatorPK) {
+		return nil, nil, fmt.Errorf("resharing resulted in a different validator public key")
+	}
+	qualInit := *init
+	qualInit.Operators = newOps
+	qualInit.T = reshare.SignedReshare.Reshare.NewT
+	return dkgResults[0], &qualInit, nil
+}
+
+// sendMultiple combines messages into one signed message and sends it to operators
+func (tc *tolerantCeremony) sendMultiple(msgs [][]byte, operators []*wire.Operator) (map[uint64]*wire.SignedTransport, error) {
+	mltpl, err := makeMultipleSignedTransports(tc.c.PrivateKey, tc.id, msgs)
+	if err != nil {
+		return nil, err
+	}
+	mltplbyts, err := mltpl.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return tc.sendToIncluded(consts.API_DKG_URL, mltplbyts, operators)
+}
+
+// sendToIncluded sends a message to operators and verifies their responses. Operators which fail,
+// respond with an error or with a message with invalid signature are excluded from the ceremony.
+func (tc *tolerantCeremony) sendToIncluded(method string, msg []byte, operators []*wire.Operator) (map[uint64]*wire.SignedTransport, error) {
+	ctx, cancel := tc.c.phaseContext(tc.ctx)
+	defer cancel()
+	responses, errs := tc.c.SendToAllTolerant(ctx, method, msg, operators)
+	// operators aren't excluded for requests aborted by initiator
+	if err := tc.ctx.Err(); err != nil {
+		return nil, err
+	}
+	for opID, err := range errs {
+		tc.exclude(opID, err)
+	}
+	verified := make(map[uint64]*wire.SignedTransport, len(responses))
+	for opID, resp := range responses {
+		tsp, err := tc.verifyResponse(opID, resp)
+		if err != nil {
+			tc.exclude(opID, err)
+			continue
+		}
+		verified[opID] = tsp
+	}
+	if uint64(len(tc.included)) < tc.init.T {
+		return nil, fmt.Errorf("not enough operators to finish DKG: %d left, threshold %d, excluded operators %v", len(tc.included), tc.init.T, tc.excluded)
+	}
+	return verified, nil
+}
+
+// verifyResponse checks that the response is signed by the operator it was sent to and belongs to the ceremony
+func (tc *tolerantCeremony) verifyResponse(opID uint64, resp []byte) (*wire.SignedTransport, error) {
+	tsp := &wire.SignedTransport{}
+	if err := tsp.UnmarshalSSZ(resp); err != nil {
+		errmsg, parseErr := wire.ParseAsError(resp)
+		if parseErr == nil {
+			return nil, fmt.Errorf("%v", errmsg)
+		}
+		return nil, err
+	}
+	if !bytes.Equal(tc.id[:], tsp.Message.Identifier[:]) {
+		return nil, fmt.Errorf("incoming message has wrong ID %x", tsp.Message.Identifier[:])
+	}
+	op := tc.c.Operators.ByID(opID)
+	if op == nil {
+		return nil, fmt.Errorf("operator not found in operators list")
+	}
+	encPubKey, err := crypto.EncodeRSAPublicKey(op.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(encPubKey, tsp.Signer) {
+		return nil, fmt.Errorf("message is signed by a different operator")
+	}
+	signedBytes, err := tsp.Message.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.c.VerifyMessageSignature(op.PubKey, signedBytes, tsp.Signature); err != nil {
+		return nil, fmt.Errorf("failed to verify RSA signature: %w", err)
+	}
+	if tsp.Message.Type == wire.ErrorMessageType {
+		return nil, fmt.Errorf("%s", string(tsp.Message.Data))
+	}
+	return tsp, nil
+}
+
+// collect returns marshaled messages of included operators ordered by operator ID
+func (tc *tolerantCeremony) collect(msgs map[uint64]*wire.SignedTransport) [][]byte {
+	var res [][]byte
+	for _, op := range tc.included {
+		tsp, ok := msgs[op.ID]
+		if !ok {
+			continue
+		}
+		bts, err := tsp.MarshalSSZ()
+		if err != nil {
+			tc.exclude(op.ID, err)
+			continue
+		}
+		res = append(res, bts)
+	}
+	return res
+}
+
+// qualifiedResults returns results of the largest group of operators agreeing on validator public key.
+// Operators with a different validator public key are excluded.
+func (tc *tolerantCeremony) qualifiedResults(results map[uint64]*wire.Result) ([]*wire.Result, error) {
+	groups := make(map[string][]*wire.Result)
+	var largest string
+	for _, op := range tc.included {
+		result, ok := results[op.ID]
+		if !ok {
+			continue
+		}
+		pk := string(result.SignedProof.Proof.ValidatorPubKey)
+		groups[pk] = append(groups[pk], result)
+		if len(groups[pk]) > len(groups[largest]) {
+			largest = pk
+		}
+	}
+	for pk, group := range groups {
+		if pk == largest {
+			continue
+		}
+		for _, result := range group {
+			tc.exclude(result.OperatorID, fmt.Errorf("operator %d sent wrong validator public key", result.OperatorID))
+		}
+	}
+	qualified := groups[largest]
+	if uint64(len(qualified)) < tc.init.T {
+		return nil, fmt.Errorf("not enough operators agree on validator public key: %d, threshold %d, excluded operators %v", len(qualified), tc.init.T, tc.excluded)
+	}
+	sort.SliceStable(qualified, func(i, j int) bool {
+		return qualified[i].OperatorID < qualified[j].OperatorID
+	})
+	return qualified, nil
+}
+
+// exclude removes operator from the ceremony
+func (tc *tolerantCeremony) exclude(opID uint64, err error) {
+	for i, op := range tc.included {
+		if op.ID == opID {
+			tc.c.Logger.Warn("⚠️ excluding operator from the ceremony", zap.Uint64("operator ID", opID), zap.Error(err))
+			tc.included = append(append([]*wire.Operator{}, tc.included[:i]...), tc.included[i+1:]...)
+			tc.excluded = append(tc.excluded, opID)
+			return
+		}
+	}
+}
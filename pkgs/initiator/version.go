@@ -0,0 +1,136 @@
+package initiator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// parsePong verifies the signed pong message of the health check response
+func parsePong(data []byte) (*wire.SignedTransport, *wire.Pong, error) {
+	signedPongMsg := &wire.SignedTransport{}
+	if err := signedPongMsg.UnmarshalSSZ(data); err != nil {
+		errmsg, parseErr := wire.ParseAsError(data)
+		if parseErr == nil {
+			return nil, nil, fmt.Errorf("operator returned err: %v", errmsg)
+		}
+		return nil, nil, err
+	}
+	// Validate that incoming message is an pong message
+	if signedPongMsg.Message.Type != wire.PongMessageType {
+		return nil, nil, fmt.Errorf("wrong incoming message type from operator")
+	}
+	pong := &wire.Pong{}
+	if err := pong.UnmarshalSSZ(signedPongMsg.Message.Data); err != nil {
+		return nil, nil, err
+	}
+	pongBytes, err := signedPongMsg.Message.MarshalSSZ()
+	if err != nil {
+		return nil, nil, err
+	}
+	pub, err := crypto.ParseRSAPublicKey(pong.PubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := crypto.VerifyRSA(pub, pongBytes, signedPongMsg.Signature); err != nil {
+		return nil, nil, err
+	}
+	return signedPongMsg, pong, nil
+}
+
+// operatorVersions requests the health check of the operator and returns protocol versions supported by the operator.
+// The operator should support the features.
+func (c *Initiator) operatorVersions(ctx context.Context, op *wire.Operator, features []string) (wire.VersionRange, error) {
+	pong, res := c.checkOperator(ctx, op.ID)
+	if res.Err != nil {
+		return wire.VersionRange{}, res.Err
+	}
+	for _, feature := range features {
+		supported := false
+		for _, f := range pong.Features {
+			if string(f) == feature {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return wire.VersionRange{}, fmt.Errorf("operator doesn't support %s", feature)
+		}
+	}
+	return res.Versions, nil
+}
+
+// negotiateVersion returns the highest protocol version supported by initiator and operators. Operators which fail
+// the health check, don't support any version of initiator or the features are returned with their errors,
+// the version is negotiated with the rest of them.
+func (c *Initiator) negotiateVersion(ctx context.Context, operators []*wire.Operator, features ...string) ([]byte, map[uint64]error, error) {
+	type result struct {
+		id       uint64
+		versions wire.VersionRange
+		err      error
+	}
+	resc := make(chan result, len(operators))
+	for _, op := range operators {
+		go func(op *wire.Operator) {
+			versions, err := c.operatorVersions(ctx, op, features)
+			resc <- result{id: op.ID, versions: versions, err: err}
+		}(op)
+	}
+	ranges := []wire.VersionRange{c.Versions}
+	errs := make(map[uint64]error)
+	for range operators {
+		res := <-resc
+		if res.err != nil {
+			errs[res.id] = res.err
+			continue
+		}
+		ranges = append(ranges, res.versions)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	v, err := wire.NegotiateVersion(ranges...)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Logger.Debug("negotiated protocol version", zap.String("version", v))
+	return []byte(v), errs, nil
+}
+
+// negotiateVersionAll returns the highest protocol version supported by initiator and all operators
+func (c *Initiator) negotiateVersionAll(ctx context.Context, operators []*wire.Operator, features ...string) ([]byte, error) {
+	v, errs, err := c.negotiateVersion(ctx, operators, features...)
+	if err != nil {
+		return nil, err
+	}
+	var opErrs error
+	for _, op := range operators {
+		if err, ok := errs[op.ID]; ok {
+			opErrs = errors.Join(opErrs, fmt.Errorf("operator ID: %d, %w", op.ID, err))
+		}
+	}
+	if opErrs != nil {
+		return nil, opErrs
+	}
+	return v, nil
+}
+
+// initFeatures returns protocol features required by the ceremony
+func initFeatures(init *wire.Init) []string {
+	var features []string
+	if init.Validators > 1 {
+		features = append(features, wire.FeatureMultipleValidators)
+	}
+	if init.SignExit {
+		features = append(features, wire.FeatureSignedExit)
+	}
+	if init.ThresholdTolerant {
+		features = append(features, wire.FeatureThresholdTolerant)
+	}
+	return features
+}
@@ -0,0 +1,152 @@
+package operator
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+)
+
+// adminErrorJSON is an error response of the admin API
+type adminErrorJSON struct {
+	Error string `json:"error"`
+}
+
+// NewAdminRouter creates routes of the operator admin API. Requests should be authorized with the token
+// at Authorization: Bearer header.
+func NewAdminRouter(logger *zap.Logger, state *Switch, token string) chi.Router {
+	r := chi.NewRouter()
+	r.Use(adminAuth(token))
+	r.Get("/"+consts.API_ADMIN_APPROVALS_URL, func(writer http.ResponseWriter, request *http.Request) {
+		pending := state.PendingCeremonies()
+		res := make([]*PendingCeremonyJSON, 0, len(pending))
+		for i := range pending {
+			p, err := pending[i].JSON()
+			if err != nil {
+				writeAdminError(logger, writer, err, http.StatusInternalServerError)
+				return
+			}
+			res = append(res, p)
+		}
+		writeAdminJSON(logger, writer, res)
+	})
+	r.Post("/"+consts.API_ADMIN_APPROVALS_URL+"/{id}/approve", func(writer http.ResponseWriter, request *http.Request) {
+		adminAction(logger, writer, request, state.Approve)
+	})
+	r.Post("/"+consts.API_ADMIN_APPROVALS_URL+"/{id}/reject", func(writer http.ResponseWriter, request *http.Request) {
+		adminAction(logger, writer, request, state.Reject)
+	})
+	r.Get("/"+consts.API_ADMIN_INSTANCES_URL, func(writer http.ResponseWriter, request *http.Request) {
+		now := time.Now()
+		instances := state.RunningInstances()
+		res := make([]*InstanceInfoJSON, 0, len(instances))
+		for _, inst := range instances {
+			i, err := inst.JSON(now)
+			if err != nil {
+				writeAdminError(logger, writer, err, http.StatusInternalServerError)
+				return
+			}
+			res = append(res, i)
+		}
+		writeAdminJSON(logger, writer, res)
+	})
+	r.Get("/"+consts.API_ADMIN_INSTANCES_URL+"/{id}", func(writer http.ResponseWriter, request *http.Request) {
+		id, err := parseAdminID(chi.URLParam(request, "id"))
+		if err != nil {
+			writeAdminError(logger, writer, err, http.StatusBadRequest)
+			return
+		}
+		inst, err := state.Instance(id)
+		if err != nil {
+			writeAdminError(logger, writer, err, http.StatusNotFound)
+			return
+		}
+		res, err := inst.JSON(time.Now())
+		if err != nil {
+			writeAdminError(logger, writer, err, http.StatusInternalServerError)
+			return
+		}
+		writeAdminJSON(logger, writer, res)
+	})
+	r.Post("/"+consts.API_ADMIN_INSTANCES_URL+"/{id}/cancel", func(writer http.ResponseWriter, request *http.Request) {
+		adminAction(logger, writer, request, state.CancelInstance)
+	})
+	return r
+}
+
+// StartAdmin runs the admin API at the address, e.g. 127.0.0.1:3031. The admin API serves plain HTTP,
+// so it should listen on a loopback or another private interface only.
+func (s *Server) StartAdmin(addr, token string) error {
+	if token == "" {
+		return fmt.Errorf("admin API token is not set")
+	}
+	srv := &http.Server{Addr: addr, Handler: NewAdminRouter(s.Logger, s.State, token), ReadHeaderTimeout: 10_000 * time.Millisecond}
+	s.AdminServer = srv
+	s.Logger.Info("🛠️ Admin API is listening for requests", zap.String("address", addr))
+	return srv.ListenAndServe()
+}
+
+// adminAuth checks the bearer token of admin API requests
+func adminAuth(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			reqToken, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) != 1 {
+				writer.Header().Set("Content-Type", "application/json")
+				writer.WriteHeader(http.StatusUnauthorized)
+				_ = json.NewEncoder(writer).Encode(adminErrorJSON{Error: "unauthorized"})
+				return
+			}
+			next.ServeHTTP(writer, request)
+		})
+	}
+}
+
+// adminAction runs an action of the admin API on the ceremony, e.g. approves it or cancels its instance
+func adminAction(logger *zap.Logger, writer http.ResponseWriter, request *http.Request, action func(reqID [24]byte) error) {
+	id, err := parseAdminID(chi.URLParam(request, "id"))
+	if err != nil {
+		writeAdminError(logger, writer, err, http.StatusBadRequest)
+		return
+	}
+	if err := action(id); err != nil {
+		writeAdminError(logger, writer, err, http.StatusNotFound)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// parseAdminID parses a hex encoded ceremony ID
+func parseAdminID(s string) ([24]byte, error) {
+	var id [24]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != len(id) {
+		return id, fmt.Errorf("invalid ceremony ID %s", s)
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+func writeAdminJSON(logger *zap.Logger, writer http.ResponseWriter, v any) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(v); err != nil {
+		logger.Error("error writing admin response: " + err.Error())
+	}
+}
+
+func writeAdminError(logger *zap.Logger, writer http.ResponseWriter, err error, statusCode int) {
+	logger.Error("admin request error: " + err.Error())
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	if err := json.NewEncoder(writer).Encode(adminErrorJSON{Error: err.Error()}); err != nil {
+		logger.Error("error writing admin response: " + err.Error())
+	}
+}
@@ -0,0 +1,90 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+)
+
+// AdminClient sends requests to the admin API of a running operator
+type AdminClient struct {
+	URL    string // admin API endpoint, e.g. http://127.0.0.1:3031
+	Token  string // bearer token of the admin API
+	Client *req.Client
+}
+
+// NewAdminClient creates a client of the operator admin API
+func NewAdminClient(url, token string) *AdminClient {
+	client := req.C()
+	client.SetTimeout(30 * time.Second)
+	return &AdminClient{URL: strings.TrimRight(url, "/"), Token: token, Client: client}
+}
+
+// PendingCeremonies returns ceremonies waiting for approval of the operator
+func (c *AdminClient) PendingCeremonies(ctx context.Context) ([]*PendingCeremonyJSON, error) {
+	var res []*PendingCeremonyJSON
+	if err := c.do(ctx, http.MethodGet, consts.API_ADMIN_APPROVALS_URL, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Approve approves the ceremony waiting for approval of the operator
+func (c *AdminClient) Approve(ctx context.Context, id [24]byte) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("%s/%s/approve", consts.API_ADMIN_APPROVALS_URL, hex.EncodeToString(id[:])), nil)
+}
+
+// Reject rejects the ceremony waiting for approval of the operator
+func (c *AdminClient) Reject(ctx context.Context, id [24]byte) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("%s/%s/reject", consts.API_ADMIN_APPROVALS_URL, hex.EncodeToString(id[:])), nil)
+}
+
+// Instances returns instances running at the operator
+func (c *AdminClient) Instances(ctx context.Context) ([]*InstanceInfoJSON, error) {
+	var res []*InstanceInfoJSON
+	if err := c.do(ctx, http.MethodGet, consts.API_ADMIN_INSTANCES_URL, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Instance returns the instance running at the operator
+func (c *AdminClient) Instance(ctx context.Context, id [24]byte) (*InstanceInfoJSON, error) {
+	res := &InstanceInfoJSON{}
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/%s", consts.API_ADMIN_INSTANCES_URL, hex.EncodeToString(id[:])), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelInstance stops the instance running at the operator
+func (c *AdminClient) CancelInstance(ctx context.Context, id [24]byte) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("%s/%s/cancel", consts.API_ADMIN_INSTANCES_URL, hex.EncodeToString(id[:])), nil)
+}
+
+// do sends a request to the admin API and decodes the JSON response to res if it's set
+func (c *AdminClient) do(ctx context.Context, method, path string, res any) error {
+	resp, err := c.Client.R().SetContext(ctx).SetBearerAuthToken(c.Token).Send(method, fmt.Sprintf("%s/%s", c.URL, path))
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccessState() {
+		var errResp adminErrorJSON
+		if err := json.Unmarshal(resp.Bytes(), &errResp); err != nil || errResp.Error == "" {
+			return fmt.Errorf("admin API responded with status %s", resp.Status)
+		}
+		return fmt.Errorf("admin API responded with error: %s", errResp.Error)
+	}
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Bytes(), res)
+}
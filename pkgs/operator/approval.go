@@ -0,0 +1,250 @@
+package operator
+
+import (
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// ApprovalStatus is the state of a ceremony waiting for approval of the operator
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// ApprovalRule selects ceremonies which wait for manual approval of the operator before the operator joins them
+type ApprovalRule struct {
+	All           bool             `json:"all"`            // every ceremony waits for approval
+	Owners        []common.Address `json:"owners"`         // ceremonies of the owners wait for approval
+	MinValidators uint64           `json:"min_validators"` // ceremonies creating at least this number of validators wait for approval, ignored if zero
+}
+
+// matches checks that the ceremony waits for approval by the rule
+func (r *ApprovalRule) matches(init *wire.Init) bool {
+	if r.All || containsAddress(r.Owners, common.Address(init.Owner)) {
+		return true
+	}
+	validators := init.Validators
+	if validators == 0 {
+		validators = 1
+	}
+	return r.MinValidators > 0 && validators >= r.MinValidators
+}
+
+// PendingCeremony is a DKG ceremony, a resharing or a sign request parked until the operator approves or rejects it.
+// The initiator repeats the message until it's approved, it expires after MaxInstanceTime.
+type PendingCeremony struct {
+	ID   InstanceID
+	Type wire.TransportType // type of the message waiting for approval: init, reshare or BLS sign request
+	// Init holds parameters of the ceremony, of the new cluster for resharing and of the validator for sign requests
+	Init         *wire.Init
+	Root         [32]byte // hash tree root of the message waiting for approval
+	InitiatorPub *rsa.PublicKey
+	ReceivedAt   time.Time
+	Status       ApprovalStatus
+}
+
+// PendingCeremonyJSON is the JSON representation of a pending ceremony at the admin API
+type PendingCeremonyJSON struct {
+	ID           string         `json:"id"`
+	Type         string         `json:"type"`
+	Status       ApprovalStatus `json:"status"`
+	Owner        string         `json:"owner"`
+	Nonce        uint64         `json:"nonce"`
+	Validators   uint64         `json:"validators"`
+	Operators    []uint64       `json:"operators"`
+	Withdrawal   string         `json:"withdrawal_credentials"`
+	Fork         string         `json:"fork_version"`
+	Amount       uint64         `json:"amount"`
+	InitiatorKey string         `json:"initiator_public_key"`
+	ReceivedAt   time.Time      `json:"received_at"`
+}
+
+// JSON returns the JSON representation of the pending ceremony
+func (p *PendingCeremony) JSON() (*PendingCeremonyJSON, error) {
+	pub, err := crypto.EncodeRSAPublicKey(p.InitiatorPub)
+	if err != nil {
+		return nil, err
+	}
+	validators := p.Init.Validators
+	if validators == 0 {
+		validators = 1
+	}
+	res := &PendingCeremonyJSON{
+		ID:           hex.EncodeToString(p.ID[:]),
+		Type:         requestKind(p.Type),
+		Status:       p.Status,
+		Owner:        common.Address(p.Init.Owner).Hex(),
+		Nonce:        p.Init.Nonce,
+		Validators:   validators,
+		Withdrawal:   fmt.Sprintf("0x%02x%x", p.Init.WithdrawalPrefix, p.Init.WithdrawalCredentials),
+		Fork:         "0x" + hex.EncodeToString(p.Init.Fork[:]),
+		Amount:       p.Init.Amount,
+		InitiatorKey: string(pub),
+		ReceivedAt:   p.ReceivedAt,
+	}
+	for _, op := range p.Init.Operators {
+		res.Operators = append(res.Operators, op.ID)
+	}
+	return res, nil
+}
+
+// checkPolicy evaluates the operator policy for a new ceremony. Ceremonies which wait for approval by the policy
+// are parked until the operator approves them, they're evaluated only when they're received for the first time.
+func (s *Switch) checkPolicy(reqID [24]byte, init *wire.Init, initiatorPub *rsa.PublicKey, logger *zap.Logger) error {
+	root, err := init.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	req := &PendingCeremony{ID: reqID, Type: wire.InitMessageType, Init: init, Root: root, InitiatorPub: initiatorPub}
+	return s.checkApproval(req, logger, func(now time.Time) error {
+		return s.Policy.Check(init, initiatorPub, s.OperatorID, now)
+	})
+}
+
+// checkResharePolicy evaluates the operator policy for a new resharing ceremony the same way as for a new ceremony
+func (s *Switch) checkResharePolicy(reqID [24]byte, reshare *wire.ReshareMessage, initiatorPub *rsa.PublicKey, logger *zap.Logger) error {
+	root, err := reshare.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	r := &reshare.SignedReshare.Reshare
+	init := &wire.Init{
+		Operators:             r.NewOperators,
+		T:                     r.NewT,
+		WithdrawalCredentials: reshare.WithdrawalCredentials,
+		Fork:                  reshare.Fork,
+		Owner:                 r.Owner,
+		Nonce:                 r.Nonce,
+		WithdrawalPrefix:      reshare.WithdrawalPrefix,
+		Amount:                reshare.Amount,
+	}
+	req := &PendingCeremony{ID: reqID, Type: wire.ReshareMessageType, Init: init, Root: root, InitiatorPub: initiatorPub}
+	return s.checkApproval(req, logger, func(now time.Time) error {
+		return s.Policy.CheckReshare(reshare, initiatorPub, s.OperatorID, now)
+	})
+}
+
+// checkSignPolicy evaluates the operator policy for a request to sign a beacon message the same way as for a new ceremony
+func (s *Switch) checkSignPolicy(reqID [24]byte, signReq *wire.BlsSignRequest, initiatorPub *rsa.PublicKey, logger *zap.Logger) error {
+	root, err := signReq.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	init := &wire.Init{Operators: signReq.Operators, Fork: signReq.ForkVersion, Owner: signReq.Owner}
+	req := &PendingCeremony{ID: reqID, Type: wire.BlsSignRequestType, Init: init, Root: root, InitiatorPub: initiatorPub}
+	return s.checkApproval(req, logger, func(time.Time) error {
+		return s.Policy.CheckSign(signReq, initiatorPub, s.OperatorID)
+	})
+}
+
+// checkApproval evaluates the policy for the request received for the first time and parks it if it waits for
+// approval, repeated requests get the approval status
+func (s *Switch) checkApproval(req *PendingCeremony, logger *zap.Logger, check func(now time.Time) error) error {
+	s.Mtx.Lock()
+	defer s.Mtx.Unlock()
+	if s.Approvals == nil {
+		s.Approvals = make(map[InstanceID]*PendingCeremony)
+	}
+	s.cleanApprovals(time.Now())
+	pending, ok := s.Approvals[req.ID]
+	if !ok {
+		if err := check(time.Now()); err != nil {
+			logger.Warn("🚫 ceremony is rejected by the policy", zap.String("type", requestKind(req.Type)), zap.Error(err))
+			return err
+		}
+		if s.Policy.RequireApproval == nil || !s.Policy.RequireApproval.matches(req.Init) {
+			return nil
+		}
+		req.ReceivedAt = time.Now()
+		req.Status = ApprovalPending
+		s.Approvals[req.ID] = req
+		logger.Info("⏸️ ceremony is waiting for approval of the operator", zap.String("type", requestKind(req.Type)), zap.String("owner", common.Address(req.Init.Owner).Hex()))
+		return wire.ErrPendingApproval
+	}
+	// the repeated message should be the one which is approved
+	if pending.Type != req.Type || pending.Root != req.Root || !pending.InitiatorPub.Equal(req.InitiatorPub) {
+		return fmt.Errorf("%s message doesn't match the ceremony waiting for approval", requestKind(req.Type))
+	}
+	switch pending.Status {
+	case ApprovalApproved:
+		delete(s.Approvals, req.ID)
+		return nil
+	case ApprovalRejected:
+		return &wire.PolicyError{Rule: wire.PolicyRuleApproval, Reason: "ceremony is rejected by the operator"}
+	default:
+		return wire.ErrPendingApproval
+	}
+}
+
+// requestKind names the type of the message waiting for approval at logs and at the admin API
+func requestKind(t wire.TransportType) string {
+	switch t {
+	case wire.ReshareMessageType:
+		return "reshare"
+	case wire.BlsSignRequestType:
+		return "sign"
+	default:
+		return "init"
+	}
+}
+
+// cleanApprovals removes ceremonies received more than MaxInstanceTime ago
+func (s *Switch) cleanApprovals(now time.Time) {
+	for id, pending := range s.Approvals {
+		if now.After(pending.ReceivedAt.Add(MaxInstanceTime)) {
+			delete(s.Approvals, id)
+		}
+	}
+}
+
+// PendingCeremonies returns ceremonies waiting for approval, approved and rejected ceremonies which weren't
+// repeated by initiator yet, ordered by the time they were received
+func (s *Switch) PendingCeremonies() []PendingCeremony {
+	s.Mtx.Lock()
+	defer s.Mtx.Unlock()
+	s.cleanApprovals(time.Now())
+	res := make([]PendingCeremony, 0, len(s.Approvals))
+	for _, pending := range s.Approvals {
+		res = append(res, *pending)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ReceivedAt.Before(res[j].ReceivedAt) })
+	return res
+}
+
+// Approve lets the operator join the ceremony waiting for approval when the initiator repeats the init message
+func (s *Switch) Approve(reqID [24]byte) error {
+	return s.setApproval(reqID, ApprovalApproved)
+}
+
+// Reject refuses the ceremony waiting for approval
+func (s *Switch) Reject(reqID [24]byte) error {
+	return s.setApproval(reqID, ApprovalRejected)
+}
+
+func (s *Switch) setApproval(reqID [24]byte, status ApprovalStatus) error {
+	s.Mtx.Lock()
+	defer s.Mtx.Unlock()
+	s.cleanApprovals(time.Now())
+	pending, ok := s.Approvals[reqID]
+	if !ok {
+		return fmt.Errorf("ceremony %x is not waiting for approval", reqID)
+	}
+	if pending.Status != ApprovalPending {
+		return fmt.Errorf("ceremony %x is already %s", reqID, pending.Status)
+	}
+	pending.Status = status
+	s.Logger.Info("📝 ceremony approval is set", zap.String("reqid", hex.EncodeToString(reqID[:])), zap.String("status", string(status)))
+	return nil
+}
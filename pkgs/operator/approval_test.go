@@ -0,0 +1,132 @@
+package operator
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func TestCheckPolicyApproval(t *testing.T) {
+	initiatorKey := singleOperatorKeys(t)
+	_, ops := generateOperatorsData(t, 4)
+	owner := common.HexToAddress("0x01")
+	s := &Switch{
+		Logger:     zap.NewNop(),
+		OperatorID: 1,
+		Policy: &Policy{
+			MaxCeremoniesPerOwner: 2,
+			RequireApproval:       &ApprovalRule{Owners: []common.Address{owner}},
+		},
+	}
+	init := &wire.Init{Operators: ops, T: 3, Owner: owner}
+	approvedID := [24]byte{1}
+	rejectedID := [24]byte{2}
+
+	// ceremonies of other owners don't wait for approval
+	require.NoError(t, s.checkPolicy([24]byte{3}, &wire.Init{Operators: ops, T: 3}, &initiatorKey.PublicKey, zap.NewNop()))
+
+	for _, id := range [][24]byte{approvedID, rejectedID} {
+		err := s.checkPolicy(id, init, &initiatorKey.PublicKey, zap.NewNop())
+		require.ErrorIs(t, err, wire.ErrPendingApproval)
+		// repeated init messages wait for approval as well, they aren't counted by the policy again
+		err = s.checkPolicy(id, init, &initiatorKey.PublicKey, zap.NewNop())
+		require.ErrorIs(t, err, wire.ErrPendingApproval)
+	}
+	pending := s.PendingCeremonies()
+	require.Len(t, pending, 2)
+	require.Equal(t, ApprovalPending, pending[0].Status)
+
+	// init message of the pending ceremony can't be changed
+	otherInit := &wire.Init{Operators: ops, T: 3, Owner: owner, Nonce: 1}
+	require.ErrorContains(t, s.checkPolicy(approvedID, otherInit, &initiatorKey.PublicKey, zap.NewNop()), "doesn't match the ceremony waiting for approval")
+
+	require.NoError(t, s.Approve(approvedID))
+	require.ErrorContains(t, s.Reject(approvedID), "is already approved")
+	require.NoError(t, s.checkPolicy(approvedID, init, &initiatorKey.PublicKey, zap.NewNop()))
+	require.ErrorContains(t, s.Approve(approvedID), "is not waiting for approval")
+
+	require.NoError(t, s.Reject(rejectedID))
+	err := s.checkPolicy(rejectedID, init, &initiatorKey.PublicKey, zap.NewNop())
+	require.Equal(t, wire.PolicyRuleApproval, wire.ParsePolicyError(err).Rule)
+	require.Len(t, s.PendingCeremonies(), 1)
+}
+
+func TestAdminAPI(t *testing.T) {
+	initiatorKey := singleOperatorKeys(t)
+	_, ops := generateOperatorsData(t, 4)
+	s := &Switch{
+		Logger:     zap.NewNop(),
+		OperatorID: 1,
+		Policy:     &Policy{RequireApproval: &ApprovalRule{All: true}},
+	}
+	id := [24]byte{1}
+	err := s.checkPolicy(id, &wire.Init{Operators: ops, T: 3, Nonce: 5}, &initiatorKey.PublicKey, zap.NewNop())
+	require.ErrorIs(t, err, wire.ErrPendingApproval)
+	srv := httptest.NewServer(NewAdminRouter(zap.NewNop(), s, "secret"))
+	defer srv.Close()
+
+	_, err = NewAdminClient(srv.URL, "wrong").PendingCeremonies(context.Background())
+	require.ErrorContains(t, err, "unauthorized")
+	client := NewAdminClient(srv.URL, "secret")
+	pending, err := client.PendingCeremonies(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, "010000000000000000000000000000000000000000000000", pending[0].ID)
+	require.Equal(t, uint64(5), pending[0].Nonce)
+	require.Equal(t, uint64(1), pending[0].Validators)
+	require.Equal(t, []uint64{1, 2, 3, 4}, pending[0].Operators)
+	require.Equal(t, ApprovalPending, pending[0].Status)
+	require.NoError(t, client.Approve(context.Background(), id))
+	require.ErrorContains(t, client.Reject(context.Background(), id), "is already approved")
+	require.ErrorContains(t, client.Approve(context.Background(), [24]byte{2}), "is not waiting for approval")
+}
+
+func TestCheckReshareAndSignApproval(t *testing.T) {
+	initiatorKey := singleOperatorKeys(t)
+	_, ops := generateOperatorsData(t, 8)
+	owner := common.HexToAddress("0x01")
+	s := &Switch{
+		Logger:     zap.NewNop(),
+		OperatorID: 1,
+		Policy:     &Policy{RequireApproval: &ApprovalRule{Owners: []common.Address{owner}}},
+	}
+	reshare := &wire.ReshareMessage{
+		SignedReshare: &wire.SignedReshare{
+			Reshare: wire.Reshare{ValidatorPubKey: make([]byte, 48), OldOperators: ops[:4], NewOperators: ops[4:], OldT: 3, NewT: 3, Owner: owner, Nonce: 2},
+		},
+		WithdrawalCredentials: owner.Bytes(),
+	}
+	signReq := &wire.BlsSignRequest{ValidatorPubKey: make([]byte, 48), Operators: ops[:4], Owner: owner}
+	reshareID := [24]byte{1}
+	signID := [24]byte{2}
+	require.ErrorIs(t, s.checkResharePolicy(reshareID, reshare, &initiatorKey.PublicKey, zap.NewNop()), wire.ErrPendingApproval)
+	require.ErrorIs(t, s.checkSignPolicy(signID, signReq, &initiatorKey.PublicKey, zap.NewNop()), wire.ErrPendingApproval)
+	pending := make(map[string]*PendingCeremonyJSON)
+	for _, p := range s.PendingCeremonies() {
+		res, err := p.JSON()
+		require.NoError(t, err)
+		pending[res.Type] = res
+	}
+	require.Len(t, pending, 2)
+	require.Equal(t, uint64(2), pending["reshare"].Nonce)
+	require.Equal(t, []uint64{5, 6, 7, 8}, pending["reshare"].Operators)
+	require.Equal(t, []uint64{1, 2, 3, 4}, pending["sign"].Operators)
+
+	// the request waiting for approval can't be replaced by another request under the same ID
+	otherReshare := *reshare
+	otherReshare.WithdrawalCredentials = common.HexToAddress("0x02").Bytes()
+	require.ErrorContains(t, s.checkResharePolicy(reshareID, &otherReshare, &initiatorKey.PublicKey, zap.NewNop()), "reshare message doesn't match the ceremony waiting for approval")
+	require.ErrorContains(t, s.checkPolicy(signID, &wire.Init{Operators: ops[:4], T: 3, Owner: owner}, &initiatorKey.PublicKey, zap.NewNop()), "init message doesn't match the ceremony waiting for approval")
+
+	require.NoError(t, s.Approve(reshareID))
+	require.NoError(t, s.checkResharePolicy(reshareID, reshare, &initiatorKey.PublicKey, zap.NewNop()))
+	require.NoError(t, s.Reject(signID))
+	err := s.checkSignPolicy(signID, signReq, &initiatorKey.PublicKey, zap.NewNop())
+	require.Equal(t, wire.PolicyRuleApproval, wire.ParsePolicyError(err).Rule)
+}
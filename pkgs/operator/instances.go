@@ -0,0 +1,113 @@
+package operator
+
+import (
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+)
+
+// InstanceInfo describes a DKG or resharing instance running at the operator
+type InstanceInfo struct {
+	ID           InstanceID
+	InitiatorPub *rsa.PublicKey
+	CreatedAt    time.Time
+	*dkg.Info
+}
+
+// InstanceInfoJSON is the JSON representation of an instance at the admin API
+type InstanceInfoJSON struct {
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	Phase        string    `json:"phase"`
+	Owner        string    `json:"owner"`
+	Nonce        uint64    `json:"nonce"`
+	Operators    []uint64  `json:"operators"`
+	InitiatorKey string    `json:"initiator_public_key"`
+	CreatedAt    time.Time `json:"created_at"`
+	Age          string    `json:"age"`
+}
+
+// JSON returns the JSON representation of the instance
+func (i *InstanceInfo) JSON(now time.Time) (*InstanceInfoJSON, error) {
+	pub, err := crypto.EncodeRSAPublicKey(i.InitiatorPub)
+	if err != nil {
+		return nil, err
+	}
+	res := &InstanceInfoJSON{
+		ID:           hex.EncodeToString(i.ID[:]),
+		Type:         "dkg",
+		Phase:        i.Phase.String(),
+		Owner:        common.Address(i.Owner).Hex(),
+		Nonce:        i.Nonce,
+		InitiatorKey: string(pub),
+		CreatedAt:    i.CreatedAt,
+		Age:          now.Sub(i.CreatedAt).Round(time.Second).String(),
+	}
+	if i.Reshare {
+		res.Type = "reshare"
+	}
+	for _, op := range i.Operators {
+		res.Operators = append(res.Operators, op.ID)
+	}
+	return res, nil
+}
+
+// RunningInstances returns instances kept at the operator ordered by creation time. Finished instances are
+// kept until they expire after MaxInstanceTime.
+func (s *Switch) RunningInstances() []*InstanceInfo {
+	s.Mtx.RLock()
+	defer s.Mtx.RUnlock()
+	res := make([]*InstanceInfo, 0, len(s.Instances))
+	for id, inst := range s.Instances {
+		res = append(res, s.instanceInfo(id, inst))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].CreatedAt.Before(res[j].CreatedAt) })
+	return res
+}
+
+// Instance returns the instance with the ID
+func (s *Switch) Instance(reqID [24]byte) (*InstanceInfo, error) {
+	s.Mtx.RLock()
+	defer s.Mtx.RUnlock()
+	inst, ok := s.Instances[reqID]
+	if !ok {
+		return nil, fmt.Errorf("instance %x is not found", reqID)
+	}
+	return s.instanceInfo(reqID, inst), nil
+}
+
+// CancelInstance stops the instance and removes it with its persisted state, so a stuck ceremony can be
+// dropped without restarting the operator
+func (s *Switch) CancelInstance(reqID [24]byte) error {
+	s.Mtx.Lock()
+	inst, ok := s.Instances[reqID]
+	if ok {
+		delete(s.Instances, reqID)
+		delete(s.InstanceInitTime, reqID)
+	}
+	s.Mtx.Unlock()
+	if !ok {
+		return fmt.Errorf("instance %x is not found", reqID)
+	}
+	inst.GetLocalOwner().Cancel()
+	s.Logger.Info("🛑 instance is cancelled by the operator", zap.String("reqid", hex.EncodeToString(reqID[:])))
+	return nil
+}
+
+func (s *Switch) instanceInfo(reqID InstanceID, inst Instance) *InstanceInfo {
+	owner := inst.GetLocalOwner()
+	return &InstanceInfo{
+		ID:           reqID,
+		InitiatorPub: owner.InitiatorPublicKey,
+		CreatedAt:    s.InstanceInitTime[reqID],
+		Info:         owner.Info(),
+	}
+}
@@ -0,0 +1,75 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func TestAdminInstances(t *testing.T) {
+	privateKey, ops := generateOperatorsData(t, 4)
+	initiatorKey := singleOperatorKeys(t)
+	s, err := New(privateKey, zap.NewNop(), []byte("test.version"), 1, t.TempDir(), nil)
+	require.NoError(t, err)
+	store := NewFileStateStore(t.TempDir())
+	s.State.StateStore = store
+	init := &wire.Init{
+		Operators:             ops,
+		T:                     3,
+		Owner:                 common.HexToAddress("0x0000001"),
+		Nonce:                 7,
+		WithdrawalCredentials: common.HexToAddress("0x0000002").Bytes(),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	reqID := crypto.NewID()
+	inst, _, err := s.State.CreateInstance(reqID, init, &initiatorKey.PublicKey)
+	require.NoError(t, err)
+	s.State.storeInstance(reqID, inst)
+	srv := httptest.NewServer(NewAdminRouter(zap.NewNop(), s.State, "secret"))
+	defer srv.Close()
+	client := NewAdminClient(srv.URL, "secret")
+
+	t.Run("test list instances", func(t *testing.T) {
+		instances, err := client.Instances(context.Background())
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		initiatorPub, err := crypto.EncodeRSAPublicKey(&initiatorKey.PublicKey)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(reqID[:]), instances[0].ID)
+		require.Equal(t, "dkg", instances[0].Type)
+		require.Equal(t, dkg.ExchangePhase.String(), instances[0].Phase)
+		require.Equal(t, common.HexToAddress("0x0000001").Hex(), instances[0].Owner)
+		require.Equal(t, uint64(7), instances[0].Nonce)
+		require.Equal(t, []uint64{1, 2, 3, 4}, instances[0].Operators)
+		require.Equal(t, string(initiatorPub), instances[0].InitiatorKey)
+	})
+	t.Run("test show instance", func(t *testing.T) {
+		inst, err := client.Instance(context.Background(), reqID)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(reqID[:]), inst.ID)
+		_, err = client.Instance(context.Background(), crypto.NewID())
+		require.ErrorContains(t, err, "is not found")
+	})
+	t.Run("test cancel instance", func(t *testing.T) {
+		require.NoError(t, client.CancelInstance(context.Background(), reqID))
+		require.ErrorContains(t, client.CancelInstance(context.Background(), reqID), "is not found")
+		instances, err := client.Instances(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, instances)
+		states, err := store.LoadAll()
+		require.NoError(t, err)
+		require.Empty(t, states)
+		// the ceremony ID can be used again
+		require.NoError(t, s.State.checkInstance(reqID))
+	})
+}
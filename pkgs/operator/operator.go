@@ -16,6 +16,7 @@ import (
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
 )
 
 // request limits
@@ -27,11 +28,12 @@ const (
 
 // Server structure for operator to store http server and DKG ceremony instances
 type Server struct {
-	Logger     *zap.Logger  // logger
-	HttpServer *http.Server // http server
-	Router     chi.Router   // http router
-	State      *Switch      // structure to store instances of DKG ceremonies
-	OutputPath string
+	Logger      *zap.Logger  // logger
+	HttpServer  *http.Server // http server
+	AdminServer *http.Server // http server of the admin API
+	Router      chi.Router   // http router
+	State       *Switch      // structure to store instances of DKG ceremonies
+	OutputPath  string
 }
 
 // TODO: either do all json or all SSZ
@@ -57,7 +59,7 @@ func RegisterRoutes(s *Server) {
 			}
 
 			// Validate that incoming message is an init message
-			if signedInitMsg.Message.Type != wire.InitMessageType {
+			if signedInitMsg.Message.Type != wire.InitMessageType && signedInitMsg.Message.Type != wire.SignedInitMessageType {
 				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, received non-init message to init route, err: %v", s.State.OperatorID, errors.New("not init message to init route")), http.StatusBadRequest)
 				return
 			}
@@ -78,6 +80,76 @@ func RegisterRoutes(s *Server) {
 			}
 		})
 
+	s.Router.With(rateLimit(s.Logger, routeLimit)).
+		Post("/reshare", func(writer http.ResponseWriter, request *http.Request) {
+			s.Logger.Debug("incoming RESHARE msg")
+			rawdata, err := io.ReadAll(request.Body)
+			if err != nil {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, failed to read request body, err: %v", s.State.OperatorID, err), http.StatusBadRequest)
+				return
+			}
+			signedReshareMsg := &wire.SignedTransport{}
+			if err := signedReshareMsg.UnmarshalSSZ(rawdata); err != nil {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, failed to unmarshal SSZ, err: %v", s.State.OperatorID, err), http.StatusBadRequest)
+				return
+			}
+
+			// Validate that incoming message is a reshare message
+			if signedReshareMsg.Message.Type != wire.ReshareMessageType {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, received non-reshare message to reshare route, err: %v", s.State.OperatorID, errors.New("not reshare message to reshare route")), http.StatusBadRequest)
+				return
+			}
+			reqid := signedReshareMsg.Message.Identifier
+			logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(reqid[:])))
+			logger.Debug("initiating instance with reshare data")
+			b, err := s.State.InitInstanceReshare(reqid, signedReshareMsg.Message, signedReshareMsg.Signer, signedReshareMsg.Signature)
+			if err != nil {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, failed to initialize resharing instance, err: %v", s.State.OperatorID, err), http.StatusBadRequest)
+				return
+			}
+			logger.Info("✅ Resharing instance started successfully")
+
+			writer.WriteHeader(http.StatusOK)
+			if _, err := writer.Write(b); err != nil {
+				logger.Error("error writing reshare response: " + err.Error())
+				return
+			}
+		})
+
+	s.Router.With(rateLimit(s.Logger, routeLimit)).
+		Post("/sign", func(writer http.ResponseWriter, request *http.Request) {
+			s.Logger.Debug("incoming SIGN msg")
+			rawdata, err := io.ReadAll(request.Body)
+			if err != nil {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, failed to read request body, err: %v", s.State.OperatorID, err), http.StatusBadRequest)
+				return
+			}
+			signedSignMsg := &wire.SignedTransport{}
+			if err := signedSignMsg.UnmarshalSSZ(rawdata); err != nil {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, failed to unmarshal SSZ, err: %v", s.State.OperatorID, err), http.StatusBadRequest)
+				return
+			}
+
+			// Validate that incoming message is a BLS sign request
+			if signedSignMsg.Message.Type != wire.BlsSignRequestType {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, received non-sign message to sign route, err: %v", s.State.OperatorID, errors.New("not sign message to sign route")), http.StatusBadRequest)
+				return
+			}
+			reqid := signedSignMsg.Message.Identifier
+			logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(reqid[:])))
+			b, err := s.State.SignBeaconMessage(reqid, signedSignMsg.Message, signedSignMsg.Signer, signedSignMsg.Signature)
+			if err != nil {
+				utils.WriteErrorResponse(s.Logger, writer, fmt.Errorf("operator %d, failed to sign beacon message, err: %v", s.State.OperatorID, err), http.StatusBadRequest)
+				return
+			}
+
+			writer.WriteHeader(http.StatusOK)
+			if _, err := writer.Write(b); err != nil {
+				logger.Error("error writing sign response: " + err.Error())
+				return
+			}
+		})
+
 	s.Router.With(rateLimit(s.Logger, routeLimit)).
 		Post("/dkg", func(writer http.ResponseWriter, request *http.Request) {
 			s.Logger.Debug("received a dkg protocol message")
@@ -142,14 +214,14 @@ func RegisterRoutes(s *Server) {
 }
 
 // New creates Server structure using operator's RSA private key
-func New(key *rsa.PrivateKey, logger *zap.Logger, ver []byte, id uint64, outputPath string) (*Server, error) {
+func New(key *rsa.PrivateKey, logger *zap.Logger, ver []byte, id uint64, outputPath string, ethClient eip1271.ETHClient) (*Server, error) {
 	r := chi.NewRouter()
 	operatorPubKey := key.Public().(*rsa.PublicKey)
 	pkBytes, err := crypto.EncodeRSAPublicKey(operatorPubKey)
 	if err != nil {
 		return nil, err
 	}
-	swtch := NewSwitch(key, logger, ver, pkBytes, id)
+	swtch := NewSwitch(key, logger, ver, pkBytes, id, ethClient)
 	s := &Server{
 		Logger:     logger,
 		Router:     r,
@@ -2,6 +2,7 @@ package operator_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"encoding/hex"
 	"encoding/json"
@@ -77,6 +78,8 @@ func TestRateLimit(t *testing.T) {
 			Fork:                  [4]byte{0, 0, 0, 0},
 			Owner:                 common.HexToAddress("0x0000000000000000000000000000000000000007"),
 			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
 		}
 		sszinit, err := init.MarshalSSZ()
 		require.NoError(t, err)
@@ -85,7 +88,7 @@ func TestRateLimit(t *testing.T) {
 			Type:       wire.InitMessageType,
 			Identifier: [24]byte{},
 			Data:       sszinit,
-			Version:    []byte(version),
+			Version:    []byte(wire.ProtocolVersion),
 		}
 
 		tsssz, err := ts.MarshalSSZ()
@@ -217,6 +220,8 @@ func TestWrongInitiatorSignature(t *testing.T) {
 			Fork:                  [4]byte{0, 0, 0, 0},
 			Owner:                 owner,
 			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
 		}
 		id := crypto.NewID()
 		sszinit, err := init.MarshalSSZ()
@@ -225,7 +230,7 @@ func TestWrongInitiatorSignature(t *testing.T) {
 			Type:       wire.InitMessageType,
 			Identifier: id,
 			Data:       sszinit,
-			Version:    c.Version,
+			Version:    []byte(wire.ProtocolVersion),
 		}
 		sig, err := hex.DecodeString("a32d0f695aad4a546b5507bb6b7cf43be7c54385589bbc6616bb97e58e839b596e8e827f8309488e6adc86562f7662738f46ae57f166e226913d66d6134149e8c6d6c60676da480c3ace2ea18f031ca4cfb51fa11a0595e63fe5808440b46c45d90e020f77bf35e64d7886ecf2e6f825168c955110753f73b37a5492191bd60a1bc7779f550b60aa37150ca2d16c15d33f014bca3dcfbb7a937312a51eb8d059a95203492e669238e5effdd38893b851d04f70cd58ad7ba0da7b21cb826b7397dbdffcbf6d66a8bcbf4e081a568c6e647e8d942c838533907ab7190c8a63eac73bec612cc1c44686164e734abec87ae223959b0f09f0c21cd99945e5319cb5a9")
 		require.NoError(t, err)
@@ -236,7 +241,7 @@ func TestWrongInitiatorSignature(t *testing.T) {
 			Signature: sig}
 		signedInitMsgBts, err := signedInitMsg.MarshalSSZ()
 		require.NoError(t, err)
-		results, err := c.SendToAll(consts.API_INIT_URL, signedInitMsgBts, parts, false)
+		results, err := c.SendToAll(context.Background(), consts.API_INIT_URL, signedInitMsgBts, parts, false)
 		require.NoError(t, err)
 		var errs []error
 		for i := 0; i < len(results); i++ {
@@ -0,0 +1,279 @@
+package operator
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/ethereum/go-ethereum/common"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// policyWindow is the period ceremonies of an owner are counted in
+const policyWindow = 24 * time.Hour
+
+// policyFile is the JSON format of the operator policy file
+type policyFile struct {
+	Networks              []string         `json:"networks"`
+	ForkVersions          []string         `json:"fork_versions"`
+	Owners                []common.Address `json:"owners"`
+	WithdrawalAddresses   []common.Address `json:"withdrawal_addresses"`
+	AllowedOperators      []uint64         `json:"allowed_operators"`
+	DeniedOperators       []uint64         `json:"denied_operators"`
+	MaxCeremoniesPerOwner int              `json:"max_ceremonies_per_owner_per_day"`
+	InitiatorKeys         []string         `json:"initiator_keys"`
+	RequireApproval       *ApprovalRule    `json:"require_approval"`
+}
+
+// Policy decides which DKG ceremonies the operator joins. Each rule with an empty list allows any value.
+type Policy struct {
+	ForkVersions          [][4]byte        // genesis fork versions of allowed networks
+	Owners                []common.Address // allowed owners
+	WithdrawalAddresses   []common.Address // allowed withdrawal addresses, BLS withdrawal credentials are refused if set
+	AllowedOperators      []uint64         // operators allowed to participate in ceremonies with this operator
+	DeniedOperators       []uint64         // operators this operator doesn't participate in ceremonies with
+	MaxCeremoniesPerOwner int              // maximum number of ceremonies of an owner in 24 hours, unlimited if zero
+	InitiatorKeys         []*rsa.PublicKey // public keys of initiators allowed to start ceremonies
+	RequireApproval       *ApprovalRule    // ceremonies waiting for manual approval of the operator, none if not set
+
+	mtx             sync.Mutex
+	ownerCeremonies map[common.Address][]time.Time // start time of accepted ceremonies of each owner
+}
+
+// LoadPolicy reads the operator policy from a JSON file. Networks are given by name, e.g. mainnet or holesky, fork versions
+// and initiator keys are hex encoded and base64 encoded RSA public keys respectively.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var f policyFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	p := &Policy{
+		Owners:                f.Owners,
+		WithdrawalAddresses:   f.WithdrawalAddresses,
+		AllowedOperators:      f.AllowedOperators,
+		DeniedOperators:       f.DeniedOperators,
+		MaxCeremoniesPerOwner: f.MaxCeremoniesPerOwner,
+		RequireApproval:       f.RequireApproval,
+	}
+	for _, name := range f.Networks {
+		network := eth2_key_manager_core.NetworkFromString(name)
+		if network == "" {
+			return nil, fmt.Errorf("unsupported network in policy: %s", name)
+		}
+		p.ForkVersions = append(p.ForkVersions, network.GenesisForkVersion())
+	}
+	for _, v := range f.ForkVersions {
+		b, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil || len(b) != 4 {
+			return nil, fmt.Errorf("invalid fork version in policy: %s", v)
+		}
+		var fork [4]byte
+		copy(fork[:], b)
+		p.ForkVersions = append(p.ForkVersions, fork)
+	}
+	if p.MaxCeremoniesPerOwner < 0 {
+		return nil, fmt.Errorf("max ceremonies per owner can't be negative")
+	}
+	for _, key := range f.InitiatorKeys {
+		pub, err := wire.ParseRSAPublicKey([]byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid initiator key in policy: %w", err)
+		}
+		p.InitiatorKeys = append(p.InitiatorKeys, pub)
+	}
+	return p, nil
+}
+
+// Check evaluates the policy for a new ceremony of the operator. A ceremony passing the policy counts towards
+// the limit of its owner even if it fails later.
+func (p *Policy) Check(init *wire.Init, initiatorPub *rsa.PublicKey, operatorID uint64, now time.Time) error {
+	if err := p.checkNetwork(init.Fork); err != nil {
+		return err
+	}
+	if err := p.checkOwner(init.Owner); err != nil {
+		return err
+	}
+	if err := p.checkWithdrawal(init.WithdrawalPrefix, init.WithdrawalCredentials); err != nil {
+		return err
+	}
+	if err := p.checkOperators(init.Operators, operatorID); err != nil {
+		return err
+	}
+	if err := p.checkInitiator(initiatorPub); err != nil {
+		return err
+	}
+	return p.countCeremony(common.Address(init.Owner), now)
+}
+
+// CheckReshare evaluates the policy for a new resharing ceremony of the operator, both old and new operators of
+// the validator are checked. Resharing ceremonies count towards the limit of the owner the same way as new ceremonies.
+func (p *Policy) CheckReshare(reshare *wire.ReshareMessage, initiatorPub *rsa.PublicKey, operatorID uint64, now time.Time) error {
+	r := &reshare.SignedReshare.Reshare
+	if err := p.checkNetwork(reshare.Fork); err != nil {
+		return err
+	}
+	if err := p.checkOwner(r.Owner); err != nil {
+		return err
+	}
+	if err := p.checkWithdrawal(reshare.WithdrawalPrefix, reshare.WithdrawalCredentials); err != nil {
+		return err
+	}
+	if err := p.checkOperators(append(append([]*wire.Operator{}, r.OldOperators...), r.NewOperators...), operatorID); err != nil {
+		return err
+	}
+	if err := p.checkInitiator(initiatorPub); err != nil {
+		return err
+	}
+	return p.countCeremony(common.Address(r.Owner), now)
+}
+
+// CheckSign evaluates the policy for a request to sign a beacon message with the key share of a validator. BLS to
+// execution changes are checked against allowed withdrawal addresses. Sign requests don't count towards the limit of the owner.
+func (p *Policy) CheckSign(req *wire.BlsSignRequest, initiatorPub *rsa.PublicKey, operatorID uint64) error {
+	if err := p.checkNetwork(req.ForkVersion); err != nil {
+		return err
+	}
+	if err := p.checkOwner(req.Owner); err != nil {
+		return err
+	}
+	if req.MessageType == wire.BLSToExecutionChangeBeaconMessage {
+		change := &capella.BLSToExecutionChange{}
+		if err := change.UnmarshalSSZ(req.Message); err != nil {
+			return fmt.Errorf("failed to unmarshal BLS to execution change: %w", err)
+		}
+		if err := p.checkWithdrawal(crypto.ETH1WithdrawalPrefixByte, change.ToExecutionAddress[:]); err != nil {
+			return err
+		}
+	}
+	if err := p.checkOperators(req.Operators, operatorID); err != nil {
+		return err
+	}
+	return p.checkInitiator(initiatorPub)
+}
+
+func (p *Policy) checkNetwork(fork [4]byte) error {
+	if len(p.ForkVersions) > 0 && !containsFork(p.ForkVersions, fork) {
+		return &wire.PolicyError{Rule: wire.PolicyRuleNetwork, Reason: fmt.Sprintf("fork version 0x%x is not allowed", fork)}
+	}
+	return nil
+}
+
+func (p *Policy) checkOwner(owner [20]byte) error {
+	if len(p.Owners) > 0 && !containsAddress(p.Owners, owner) {
+		return &wire.PolicyError{Rule: wire.PolicyRuleOwner, Reason: fmt.Sprintf("owner %s is not allowed", common.Address(owner).Hex())}
+	}
+	return nil
+}
+
+func (p *Policy) checkWithdrawal(prefix uint8, credentials []byte) error {
+	if len(p.WithdrawalAddresses) == 0 {
+		return nil
+	}
+	if prefix == crypto.BLSWithdrawalPrefixByte || len(credentials) != len(common.Address{}) {
+		return &wire.PolicyError{Rule: wire.PolicyRuleWithdrawalAddress, Reason: "BLS withdrawal credentials are not allowed"}
+	}
+	withdrawal := common.BytesToAddress(credentials)
+	if !containsAddress(p.WithdrawalAddresses, withdrawal) {
+		return &wire.PolicyError{Rule: wire.PolicyRuleWithdrawalAddress, Reason: fmt.Sprintf("withdrawal address %s is not allowed", withdrawal.Hex())}
+	}
+	return nil
+}
+
+func (p *Policy) checkOperators(operators []*wire.Operator, operatorID uint64) error {
+	for _, op := range operators {
+		if op.ID == operatorID {
+			continue
+		}
+		if containsID(p.DeniedOperators, op.ID) {
+			return &wire.PolicyError{Rule: wire.PolicyRuleOperators, Reason: fmt.Sprintf("operator %d is denied", op.ID)}
+		}
+		if len(p.AllowedOperators) > 0 && !containsID(p.AllowedOperators, op.ID) {
+			return &wire.PolicyError{Rule: wire.PolicyRuleOperators, Reason: fmt.Sprintf("operator %d is not allowed", op.ID)}
+		}
+	}
+	return nil
+}
+
+func (p *Policy) checkInitiator(initiatorPub *rsa.PublicKey) error {
+	if len(p.InitiatorKeys) > 0 && !containsKey(p.InitiatorKeys, initiatorPub) {
+		return &wire.PolicyError{Rule: wire.PolicyRuleInitiator, Reason: "initiator key is not allowed"}
+	}
+	return nil
+}
+
+// countCeremony counts a new ceremony of the owner, ceremonies above the limit are refused
+func (p *Policy) countCeremony(owner common.Address, now time.Time) error {
+	if p.MaxCeremoniesPerOwner == 0 {
+		return nil
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.ownerCeremonies == nil {
+		p.ownerCeremonies = make(map[common.Address][]time.Time)
+	}
+	var recent []time.Time
+	for _, t := range p.ownerCeremonies[owner] {
+		if now.Sub(t) < policyWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= p.MaxCeremoniesPerOwner {
+		p.ownerCeremonies[owner] = recent
+		return &wire.PolicyError{Rule: wire.PolicyRuleOwnerRateLimit, Reason: fmt.Sprintf("owner %s reached the limit of %d ceremonies per day", owner.Hex(), p.MaxCeremoniesPerOwner)}
+	}
+	p.ownerCeremonies[owner] = append(recent, now)
+	return nil
+}
+
+func containsFork(forks [][4]byte, fork [4]byte) bool {
+	for _, f := range forks {
+		if f == fork {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKey(keys []*rsa.PublicKey, key *rsa.PublicKey) bool {
+	for _, k := range keys {
+		if k.Equal(key) {
+			return true
+		}
+	}
+	return false
+}
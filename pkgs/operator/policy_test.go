@@ -0,0 +1,209 @@
+package operator
+
+import (
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	initiatorKey := singleOperatorKeys(t)
+	encKey, err := crypto.EncodeRSAPublicKey(&initiatorKey.PublicKey)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	write := func(data string) string {
+		path := filepath.Join(dir, "policy.json")
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+		return path
+	}
+	p, err := LoadPolicy(write(`{
+		"networks": ["holesky"],
+		"fork_versions": ["0x00000000"],
+		"owners": ["0x0000000000000000000000000000000000000001"],
+		"allowed_operators": [1, 2, 3, 4],
+		"max_ceremonies_per_owner_per_day": 10,
+		"initiator_keys": ["` + string(encKey) + `"]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, [][4]byte{eth2_key_manager_core.HoleskyNetwork.GenesisForkVersion(), {0, 0, 0, 0}}, p.ForkVersions)
+	require.Equal(t, []common.Address{common.HexToAddress("0x01")}, p.Owners)
+	require.Equal(t, []uint64{1, 2, 3, 4}, p.AllowedOperators)
+	require.Equal(t, 10, p.MaxCeremoniesPerOwner)
+	require.Len(t, p.InitiatorKeys, 1)
+	require.True(t, p.InitiatorKeys[0].Equal(&initiatorKey.PublicKey))
+
+	_, err = LoadPolicy(write(`{"networks": ["devnet"]}`))
+	require.ErrorContains(t, err, "unsupported network in policy")
+	_, err = LoadPolicy(write(`{"fork_versions": ["0x0000"]}`))
+	require.ErrorContains(t, err, "invalid fork version in policy")
+	_, err = LoadPolicy(write(`{"owner": ["0x0000000000000000000000000000000000000001"]}`))
+	require.ErrorContains(t, err, "unknown field")
+	_, err = LoadPolicy(write(`{"initiator_keys": ["abc"]}`))
+	require.ErrorContains(t, err, "invalid initiator key in policy")
+}
+
+func TestPolicyCheck(t *testing.T) {
+	initiatorKey := singleOperatorKeys(t)
+	_, ops := generateOperatorsData(t, 4)
+	owner := common.HexToAddress("0x01")
+	withdrawal := common.HexToAddress("0x02")
+	newInit := func() *wire.Init {
+		return &wire.Init{
+			Operators:             ops,
+			T:                     3,
+			WithdrawalCredentials: withdrawal.Bytes(),
+			Fork:                  eth2_key_manager_core.HoleskyNetwork.GenesisForkVersion(),
+			Owner:                 owner,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		}
+	}
+	now := time.Now()
+	require.NoError(t, (&Policy{}).Check(newInit(), &initiatorKey.PublicKey, 1, now))
+
+	tests := []struct {
+		name   string
+		policy *Policy
+		modify func(init *wire.Init)
+		rule   string
+	}{
+		{"network", &Policy{ForkVersions: [][4]byte{eth2_key_manager_core.MainNetwork.GenesisForkVersion()}}, nil, wire.PolicyRuleNetwork},
+		{"owner", &Policy{Owners: []common.Address{withdrawal}}, nil, wire.PolicyRuleOwner},
+		{"withdrawal address", &Policy{WithdrawalAddresses: []common.Address{owner}}, nil, wire.PolicyRuleWithdrawalAddress},
+		{"BLS withdrawal credentials", &Policy{WithdrawalAddresses: []common.Address{withdrawal}}, func(init *wire.Init) {
+			init.WithdrawalPrefix = crypto.BLSWithdrawalPrefixByte
+			init.WithdrawalCredentials = make([]byte, 48)
+		}, wire.PolicyRuleWithdrawalAddress},
+		{"denied operator", &Policy{DeniedOperators: []uint64{3}}, nil, wire.PolicyRuleOperators},
+		{"not allowed operator", &Policy{AllowedOperators: []uint64{2, 3}}, nil, wire.PolicyRuleOperators},
+		{"initiator", &Policy{InitiatorKeys: []*rsa.PublicKey{&singleOperatorKeys(t).PublicKey}}, nil, wire.PolicyRuleInitiator},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			init := newInit()
+			if test.modify != nil {
+				test.modify(init)
+			}
+			err := test.policy.Check(init, &initiatorKey.PublicKey, 1, now)
+			policyErr := wire.ParsePolicyError(err)
+			require.NotNil(t, policyErr)
+			require.Equal(t, test.rule, policyErr.Rule)
+		})
+	}
+
+	t.Run("allowed ceremony", func(t *testing.T) {
+		p := &Policy{
+			ForkVersions:        [][4]byte{eth2_key_manager_core.HoleskyNetwork.GenesisForkVersion()},
+			Owners:              []common.Address{owner},
+			WithdrawalAddresses: []common.Address{withdrawal},
+			AllowedOperators:    []uint64{2, 3, 4},
+			DeniedOperators:     []uint64{5},
+			InitiatorKeys:       []*rsa.PublicKey{&initiatorKey.PublicKey},
+		}
+		require.NoError(t, p.Check(newInit(), &initiatorKey.PublicKey, 1, now))
+	})
+
+	t.Run("ceremonies per owner", func(t *testing.T) {
+		p := &Policy{MaxCeremoniesPerOwner: 2}
+		require.NoError(t, p.Check(newInit(), &initiatorKey.PublicKey, 1, now))
+		require.NoError(t, p.Check(newInit(), &initiatorKey.PublicKey, 1, now.Add(time.Hour)))
+		err := p.Check(newInit(), &initiatorKey.PublicKey, 1, now.Add(2*time.Hour))
+		require.Equal(t, wire.PolicyRuleOwnerRateLimit, wire.ParsePolicyError(err).Rule)
+		// ceremonies of other owners aren't counted
+		otherInit := newInit()
+		otherInit.Owner = withdrawal
+		require.NoError(t, p.Check(otherInit, &initiatorKey.PublicKey, 1, now.Add(2*time.Hour)))
+		// the first ceremony is out of the window
+		require.NoError(t, p.Check(newInit(), &initiatorKey.PublicKey, 1, now.Add(25*time.Hour)))
+	})
+}
+
+func TestPolicyCheckReshareAndSign(t *testing.T) {
+	initiatorKey := singleOperatorKeys(t)
+	_, ops := generateOperatorsData(t, 8)
+	owner := common.HexToAddress("0x01")
+	withdrawal := common.HexToAddress("0x02")
+	fork := eth2_key_manager_core.HoleskyNetwork.GenesisForkVersion()
+	reshare := &wire.ReshareMessage{
+		SignedReshare: &wire.SignedReshare{
+			Reshare: wire.Reshare{
+				OldOperators: ops[:4],
+				NewOperators: ops[4:],
+				OldT:         3,
+				NewT:         3,
+				Owner:        owner,
+			},
+		},
+		WithdrawalCredentials: withdrawal.Bytes(),
+		Fork:                  fork,
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	change, err := (&capella.BLSToExecutionChange{ValidatorIndex: 1, ToExecutionAddress: bellatrix.ExecutionAddress(withdrawal)}).MarshalSSZ()
+	require.NoError(t, err)
+	signReq := &wire.BlsSignRequest{
+		Operators:   ops[:4],
+		Owner:       owner,
+		MessageType: wire.BLSToExecutionChangeBeaconMessage,
+		Message:     change,
+		ForkVersion: fork,
+	}
+	now := time.Now()
+	require.NoError(t, (&Policy{}).CheckReshare(reshare, &initiatorKey.PublicKey, 1, now))
+	require.NoError(t, (&Policy{}).CheckSign(signReq, &initiatorKey.PublicKey, 1))
+
+	tests := []struct {
+		name   string
+		policy *Policy
+		rule   string
+	}{
+		{"network", &Policy{ForkVersions: [][4]byte{eth2_key_manager_core.MainNetwork.GenesisForkVersion()}}, wire.PolicyRuleNetwork},
+		{"owner", &Policy{Owners: []common.Address{withdrawal}}, wire.PolicyRuleOwner},
+		{"withdrawal address", &Policy{WithdrawalAddresses: []common.Address{owner}}, wire.PolicyRuleWithdrawalAddress},
+		{"denied operator", &Policy{DeniedOperators: []uint64{3}}, wire.PolicyRuleOperators},
+		{"initiator", &Policy{InitiatorKeys: []*rsa.PublicKey{&singleOperatorKeys(t).PublicKey}}, wire.PolicyRuleInitiator},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.CheckReshare(reshare, &initiatorKey.PublicKey, 1, now)
+			require.Equal(t, test.rule, wire.ParsePolicyError(err).Rule)
+			err = test.policy.CheckSign(signReq, &initiatorKey.PublicKey, 1)
+			require.Equal(t, test.rule, wire.ParsePolicyError(err).Rule)
+		})
+	}
+
+	t.Run("new operators of reshare", func(t *testing.T) {
+		err := (&Policy{DeniedOperators: []uint64{6}}).CheckReshare(reshare, &initiatorKey.PublicKey, 1, now)
+		require.Equal(t, wire.PolicyRuleOperators, wire.ParsePolicyError(err).Rule)
+	})
+
+	t.Run("withdrawal address isn't checked for voluntary exits", func(t *testing.T) {
+		exit, err := (&phase0.VoluntaryExit{Epoch: 1, ValidatorIndex: 1}).MarshalSSZ()
+		require.NoError(t, err)
+		exitReq := *signReq
+		exitReq.MessageType = wire.VoluntaryExitBeaconMessage
+		exitReq.Message = exit
+		require.NoError(t, (&Policy{WithdrawalAddresses: []common.Address{owner}}).CheckSign(&exitReq, &initiatorKey.PublicKey, 1))
+	})
+
+	t.Run("reshares per owner", func(t *testing.T) {
+		p := &Policy{MaxCeremoniesPerOwner: 1}
+		require.NoError(t, p.CheckReshare(reshare, &initiatorKey.PublicKey, 1, now))
+		err := p.CheckReshare(reshare, &initiatorKey.PublicKey, 1, now.Add(time.Hour))
+		require.Equal(t, wire.PolicyRuleOwnerRateLimit, wire.ParsePolicyError(err).Rule)
+		// sign requests aren't counted
+		require.NoError(t, p.CheckSign(signReq, &initiatorKey.PublicKey, 1))
+	})
+}
@@ -0,0 +1,108 @@
+package operator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+)
+
+// ShareStore stores BLS key shares created at ceremonies as EIP-2335 keystores, so operator keeps its
+// shares after the instances are cleaned. Keystores are encrypted with operator's password.
+type ShareStore struct {
+	Dir      string // directory to store keystores at
+	Password string // password to encrypt keystores
+}
+
+// NewShareStore creates a ShareStore writing keystores to the directory
+func NewShareStore(dir, password string) *ShareStore {
+	return &ShareStore{
+		Dir:      dir,
+		Password: password,
+	}
+}
+
+// Save writes key share of the validator created at the ceremony with request ID to a keystore file
+func (s *ShareStore) Save(reqID [24]byte, validatorPubKey []byte, share *bls.SecretKey, owner [20]byte, nonce uint64) error {
+	ks, err := crypto.EncryptShareKeystore(share, s.Password)
+	if err != nil {
+		return err
+	}
+	ks.ValidatorPubKey = hex.EncodeToString(validatorPubKey)
+	ks.RequestID = hex.EncodeToString(reqID[:])
+	ks.Owner = common.Address(owner).Hex()
+	ks.Nonce = nonce
+	ks.Description = fmt.Sprintf("ssv-dkg key share of validator 0x%s", ks.ValidatorPubKey)
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create keystores directory: %w", err)
+	}
+	return os.WriteFile(s.path(validatorPubKey, reqID), data, 0o600)
+}
+
+// Load reads and decrypts key share of the validator created at the ceremony with request ID
+func (s *ShareStore) Load(validatorPubKey []byte, reqID [24]byte) (*crypto.ShareKeystore, *bls.SecretKey, error) {
+	data, err := os.ReadFile(s.path(validatorPubKey, reqID))
+	if err != nil {
+		return nil, nil, err
+	}
+	ks, err := parseKeystore(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	share, err := crypto.DecryptShareKeystore(ks, s.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ks, share, nil
+}
+
+// LoadBySharePubKey reads and decrypts key share of the validator with the share public key. Operator can keep several
+// shares of a validator created at different ceremonies, e.g. after resharing.
+func (s *ShareStore) LoadBySharePubKey(validatorPubKey, sharePubKey []byte) (*crypto.ShareKeystore, *bls.SecretKey, error) {
+	paths, err := filepath.Glob(filepath.Join(s.Dir, fmt.Sprintf("keystore-%x-*.json", validatorPubKey)))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		ks, err := parseKeystore(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		// share public key is stored in plain text, only the matching keystore is decrypted
+		if ks.PubKey != hex.EncodeToString(sharePubKey) {
+			continue
+		}
+		share, err := crypto.DecryptShareKeystore(ks, s.Password)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ks, share, nil
+	}
+	return nil, nil, fmt.Errorf("key share %x of validator %x is not found", sharePubKey, validatorPubKey)
+}
+
+func parseKeystore(data []byte) (*crypto.ShareKeystore, error) {
+	ks := &crypto.ShareKeystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore: %w", err)
+	}
+	return ks, nil
+}
+
+func (s *ShareStore) path(validatorPubKey []byte, reqID [24]byte) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("keystore-%x-%x.json", validatorPubKey, reqID[:]))
+}
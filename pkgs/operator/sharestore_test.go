@@ -0,0 +1,56 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+)
+
+func TestShareStore(t *testing.T) {
+	share := &bls.SecretKey{}
+	share.SetByCSPRNG()
+	validatorSK := &bls.SecretKey{}
+	validatorSK.SetByCSPRNG()
+	validatorPubKey := validatorSK.GetPublicKey().Serialize()
+	owner := common.HexToAddress("0x81592c3de184a3e2c0dcb5a261bc107bfa91f494")
+	reqID := crypto.NewID()
+	store := NewShareStore(t.TempDir(), "12345678")
+	require.NoError(t, store.Save(reqID, validatorPubKey, share, owner, 7))
+	t.Run("test load share", func(t *testing.T) {
+		ks, loaded, err := store.Load(validatorPubKey, reqID)
+		require.NoError(t, err)
+		require.True(t, share.IsEqual(loaded))
+		require.Equal(t, uint(4), ks.Version)
+		require.Equal(t, owner.Hex(), ks.Owner)
+		require.Equal(t, uint64(7), ks.Nonce)
+		require.Equal(t, share.GetPublicKey().SerializeToHexStr(), ks.PubKey)
+		require.Equal(t, validatorSK.GetPublicKey().SerializeToHexStr(), ks.ValidatorPubKey)
+	})
+	t.Run("test wrong password", func(t *testing.T) {
+		_, _, err := NewShareStore(store.Dir, "87654321").Load(validatorPubKey, reqID)
+		require.ErrorContains(t, err, "failed to decrypt share")
+	})
+	t.Run("test load share by share public key", func(t *testing.T) {
+		otherShare := &bls.SecretKey{}
+		otherShare.SetByCSPRNG()
+		otherReqID := crypto.NewID()
+		require.NoError(t, store.Save(otherReqID, validatorPubKey, otherShare, owner, 8))
+		ks, loaded, err := store.LoadBySharePubKey(validatorPubKey, otherShare.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.True(t, otherShare.IsEqual(loaded))
+		require.Equal(t, uint64(8), ks.Nonce)
+		_, loaded, err = store.LoadBySharePubKey(validatorPubKey, share.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.True(t, share.IsEqual(loaded))
+		_, _, err = store.LoadBySharePubKey(share.GetPublicKey().Serialize(), share.GetPublicKey().Serialize())
+		require.ErrorContains(t, err, "is not found")
+	})
+	t.Run("test missing keystore", func(t *testing.T) {
+		_, _, err := store.Load(validatorPubKey, crypto.NewID())
+		require.Error(t, err)
+	})
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	kyber_bls12381 "github.com/drand/kyber-bls12381"
 	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
@@ -20,6 +22,7 @@ import (
 	"github.com/bloxapp/ssv-dkg/pkgs/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 	"github.com/bloxapp/ssv-dkg/spec"
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 )
 
@@ -29,10 +32,13 @@ const MaxInstanceTime = 5 * time.Minute
 // Instance interface to process messages at DKG instances incoming from initiator
 type Instance interface {
 	Process(*wire.SignedTransport) error
+	StartWithReceivedExchanges() error
 	ReadResponse() []byte
 	ReadError() error
 	VerifyInitiatorMessage(msg, sig []byte) error
 	GetLocalOwner() *dkg.LocalOwner
+	ProcessOnce(msg []byte, process func() ([]byte, error)) ([]byte, error)
+	FirstResponse(msg []byte) ([]byte, bool)
 }
 
 // instWrapper wraps LocalOwner instance with RSA public key
@@ -41,6 +47,66 @@ type instWrapper struct {
 	InitiatorPublicKey *rsa.PublicKey // initiator's RSA public key to verify its identity. Makes sure that in the DKG process messages received only from one initiator who started it.
 	respChan           chan []byte    // channel to receive response
 	errChan            chan error     // channel to receive error
+	mtx                sync.Mutex
+	processed          map[[32]byte]*processedMessage // responses to messages from initiator by message hash
+}
+
+// processedMessage is a response to a message from initiator
+type processedMessage struct {
+	done chan struct{}
+	resp []byte
+	err  error
+}
+
+func newInstWrapper(owner *dkg.LocalOwner, initiatorPublicKey *rsa.PublicKey, bchan chan []byte) *instWrapper {
+	return &instWrapper{
+		LocalOwner:         owner,
+		InitiatorPublicKey: initiatorPublicKey,
+		respChan:           bchan,
+		errChan:            owner.ErrorChan,
+		processed:          make(map[[32]byte]*processedMessage),
+	}
+}
+
+// ProcessOnce processes a message from initiator only once. Initiator retrying a request, which response was lost,
+// gets the response to the first request. Failed messages are processed again.
+func (iw *instWrapper) ProcessOnce(msg []byte, process func() ([]byte, error)) ([]byte, error) {
+	key := sha256.Sum256(msg)
+	iw.mtx.Lock()
+	pm, ok := iw.processed[key]
+	if !ok {
+		pm = &processedMessage{done: make(chan struct{})}
+		iw.processed[key] = pm
+	}
+	iw.mtx.Unlock()
+	if ok {
+		<-pm.done
+		iw.Logger.Info("Responding to a retried message", zap.Error(pm.err))
+		return pm.resp, pm.err
+	}
+	pm.resp, pm.err = process()
+	if pm.err != nil {
+		iw.mtx.Lock()
+		delete(iw.processed, key)
+		iw.mtx.Unlock()
+	}
+	close(pm.done)
+	return pm.resp, pm.err
+}
+
+// FirstResponse returns the response to the message if it's the only message processed by the instance. Messages
+// retried after the instance processed the next ones aren't responded.
+func (iw *instWrapper) FirstResponse(msg []byte) ([]byte, bool) {
+	key := sha256.Sum256(msg)
+	iw.mtx.Lock()
+	pm, ok := iw.processed[key]
+	only := len(iw.processed) == 1
+	iw.mtx.Unlock()
+	if !ok || !only {
+		return nil, false
+	}
+	<-pm.done
+	return pm.resp, pm.err == nil
 }
 
 // VerifyInitiatorMessage verifies initiator message signature
@@ -76,15 +142,71 @@ type Switch struct {
 	InstanceInitTime map[InstanceID]time.Time // mapping to store DKG instance creation time
 	Instances        map[InstanceID]Instance  // mapping to store DKG instances
 	PrivateKey       *rsa.PrivateKey          // operator RSA private key
-	Version          []byte
+	Version          []byte                   // release version of the operator
+	Versions         wire.VersionRange        // protocol versions accepted from initiators
 	PubKeyBytes      []byte
 	OperatorID       uint64
+	EthClient        eip1271.ETHClient // ethereum client to verify owner signatures, reshare is refused if not set
+	ShareStore       *ShareStore       // store to keep key shares created at ceremonies, not stored if not set
+	StateStore       StateStore        // store to persist instances, so they can be restored after restart
+	PhaseTimeout     time.Duration     // fallback timeout of DKG protocol phases if initiator doesn't request another one
+	// TrustedInitiators are public keys of initiators allowed to start ceremonies, reshare and sign, any initiator is allowed if empty
+	TrustedInitiators []*rsa.PublicKey
+	// RequireOwnerSignature refuses init messages which aren't signed by the owner, requires ethereum client
+	RequireOwnerSignature bool
+	// Policy decides which DKG ceremonies the operator joins, any ceremony is joined if not set
+	Policy *Policy
+	// Approvals are ceremonies waiting for approval of the operator by the policy
+	Approvals map[InstanceID]*PendingCeremony
 }
 
 // CreateInstance creates a LocalOwner instance with the DKG ceremony ID, that we can identify it later. Initiator public key identifies an initiator for
 // new instance. There cant be two instances with the same ID, but one initiator can start several DKG ceremonies.
 func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublicKey *rsa.PublicKey) (Instance, []byte, error) {
-	operatorID, err := spec.OperatorIDByPubKey(init.Operators, s.PubKeyBytes)
+	owner, bchan, err := s.newLocalOwner(reqID, init.Operators, initiatorPublicKey, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	// wait for exchange msg
+	resp, err := owner.Init(reqID, init)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := owner.Broadcast(resp); err != nil {
+		return nil, nil, err
+	}
+	res := <-bchan
+	return newInstWrapper(owner, initiatorPublicKey, bchan), res, nil
+}
+
+// CreateInstanceReshare creates a LocalOwner instance for the resharing ceremony. Operator can be a dealing old operator
+// or a member of new operators set. Init message of a threshold tolerant ceremony is set only for resharing finishing it.
+func (s *Switch) CreateInstanceReshare(reqID [24]byte, reshare *wire.ReshareMessage, tolerantInit *wire.Init, initiatorPublicKey *rsa.PublicKey) (Instance, []byte, error) {
+	ops := append(append([]*wire.Operator{}, spec.ReshareDealers(&reshare.SignedReshare.Reshare)...), reshare.SignedReshare.Reshare.NewOperators...)
+	owner, bchan, err := s.newLocalOwner(reqID, ops, initiatorPublicKey, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	// wait for exchange msg
+	var resp *wire.Transport
+	if tolerantInit != nil {
+		resp, err = owner.InitTolerantReshare(reqID, reshare, tolerantInit)
+	} else {
+		resp, err = owner.InitReshare(reqID, reshare)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := owner.Broadcast(resp); err != nil {
+		return nil, nil, err
+	}
+	res := <-bchan
+	return newInstWrapper(owner, initiatorPublicKey, bchan), res, nil
+}
+
+// newLocalOwner creates a LocalOwner for this operator and a channel to receive its broadcasted messages
+func (s *Switch) newLocalOwner(reqID [24]byte, ops []*wire.Operator, initiatorPublicKey *rsa.PublicKey, createdAt time.Time) (*dkg.LocalOwner, chan []byte, error) {
+	operatorID, err := spec.OperatorIDByPubKey(ops, s.PubKeyBytes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,6 +214,19 @@ func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublic
 	if s.OperatorID != operatorID {
 		return nil, nil, fmt.Errorf("wrong operator ID")
 	}
+	opts, bchan, err := s.ownerOpts(reqID, initiatorPublicKey, createdAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dkg.New(opts), bchan, nil
+}
+
+// ownerOpts creates LocalOwner options for this operator and a channel to receive its broadcasted messages
+func (s *Switch) ownerOpts(reqID [24]byte, initiatorPublicKey *rsa.PublicKey, createdAt time.Time) (*dkg.OwnerOpts, chan []byte, error) {
+	encInitiatorPubKey, err := crypto.EncodeRSAPublicKey(initiatorPublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
 	bchan := make(chan []byte, 1)
 	broadcast := func(msg []byte) error {
 		bchan <- msg
@@ -104,22 +239,90 @@ func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublic
 		EncryptFunc:        s.Encrypt,
 		DecryptFunc:        s.Decrypt,
 		Suite:              kyber_bls12381.NewBLS12381Suite(),
-		ID:                 operatorID,
+		ID:                 s.OperatorID,
 		InitiatorPublicKey: initiatorPublicKey,
 		OperatorPublicKey:  &s.PrivateKey.PublicKey,
 		Version:            s.Version,
+		PhaseTimeout:       s.PhaseTimeout,
+	}
+	if s.ShareStore != nil {
+		opts.StoreShareFunc = s.ShareStore.Save
+	}
+	if s.StateStore != nil {
+		opts.SaveStateFunc = func(st *dkg.State) error {
+			return s.StateStore.Save(reqID, &InstanceState{
+				State:              st,
+				InitiatorPublicKey: encInitiatorPubKey,
+				CreatedAt:          createdAt,
+			})
+		}
+		opts.DeleteStateFunc = func() error {
+			return s.StateStore.Delete(reqID)
+		}
 	}
-	owner := dkg.New(&opts)
-	// wait for exchange msg
-	resp, err := owner.Init(reqID, init)
+	return &opts, bchan, nil
+}
+
+// RestoreInstances loads instances persisted at the state store, so ceremonies which were in progress
+// before operator restart can be finished. Expired instances are removed from the store.
+func (s *Switch) RestoreInstances() error {
+	if s.StateStore == nil {
+		return nil
+	}
+	states, err := s.StateStore.LoadAll()
 	if err != nil {
-		return nil, nil, err
+		return fmt.Errorf("failed to load instance states: %w", err)
+	}
+	for id, st := range states {
+		logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(id[:])))
+		if time.Now().After(st.CreatedAt.Add(MaxInstanceTime)) {
+			logger.Debug("removing expired instance state")
+			s.deleteState(id)
+			continue
+		}
+		inst, err := s.restoreInstance(id, st)
+		if err != nil {
+			logger.Error("failed to restore instance", zap.Error(err))
+			s.deleteState(id)
+			continue
+		}
+		s.Mtx.Lock()
+		s.Instances[id] = inst
+		s.InstanceInitTime[id] = st.CreatedAt
+		s.Mtx.Unlock()
+		logger.Info("♻️ Restored DKG instance")
 	}
-	if err := owner.Broadcast(resp); err != nil {
-		return nil, nil, err
+	return nil
+}
+
+// restoreInstance recreates an instance from its persisted state
+func (s *Switch) restoreInstance(reqID InstanceID, st *InstanceState) (Instance, error) {
+	if st.State == nil {
+		return nil, fmt.Errorf("missing instance state")
+	}
+	initiatorPubKey, err := crypto.ParseRSAPublicKey(st.InitiatorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse initiator public key: %w", err)
+	}
+	opts, bchan, err := s.ownerOpts(reqID, initiatorPubKey, st.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := dkg.Restore(opts, reqID, st.State)
+	if err != nil {
+		return nil, err
+	}
+	return newInstWrapper(owner, initiatorPubKey, bchan), nil
+}
+
+// deleteState removes instance state from the store
+func (s *Switch) deleteState(reqID InstanceID) {
+	if s.StateStore == nil {
+		return
+	}
+	if err := s.StateStore.Delete(reqID); err != nil {
+		s.Logger.Error("failed to delete instance state", zap.String("reqid", hex.EncodeToString(reqID[:])), zap.Error(err))
 	}
-	res := <-bchan
-	return &instWrapper{owner, initiatorPublicKey, bchan, owner.ErrorChan}, res, nil
 }
 
 // Sign creates a RSA signature for the message at operator before sending it to initiator
@@ -138,76 +341,423 @@ func (s *Switch) Decrypt(ciphertext []byte) ([]byte, error) {
 }
 
 // NewSwitch creates a new Switch
-func NewSwitch(pv *rsa.PrivateKey, logger *zap.Logger, ver, pkBytes []byte, id uint64) *Switch {
+func NewSwitch(pv *rsa.PrivateKey, logger *zap.Logger, ver, pkBytes []byte, id uint64, ethClient eip1271.ETHClient) *Switch {
 	return &Switch{
 		Logger:           logger,
 		Mtx:              sync.RWMutex{},
 		InstanceInitTime: make(map[InstanceID]time.Time, MaxInstances),
 		Instances:        make(map[InstanceID]Instance, MaxInstances),
+		Approvals:        make(map[InstanceID]*PendingCeremony),
 		PrivateKey:       pv,
 		Version:          ver,
+		Versions:         wire.SupportedVersions,
 		PubKeyBytes:      pkBytes,
 		OperatorID:       id,
+		EthClient:        ethClient,
+		StateStore:       NewMemoryStateStore(),
+		PhaseTimeout:     dkg.DefaultPhaseTimeout,
+	}
+}
+
+// checkInitiator checks that the initiator is allowed to start ceremonies, reshare and sign requests
+func (s *Switch) checkInitiator(pub *rsa.PublicKey) error {
+	if len(s.TrustedInitiators) == 0 || containsKey(s.TrustedInitiators, pub) {
+		return nil
+	}
+	return fmt.Errorf("initiator is not trusted by the operator")
+}
+
+// unmarshalInit decodes the init message and the owner signature of an init message signed by the owner
+func unmarshalInit(initMsg *wire.Transport) (*wire.Init, []byte, error) {
+	if initMsg.Type == wire.SignedInitMessageType {
+		signedInit := &wire.SignedInit{}
+		if err := signedInit.UnmarshalSSZ(initMsg.Data); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal signed init message: %s", err.Error())
+		}
+		return &signedInit.Init, signedInit.Signature, nil
+	}
+	init := &wire.Init{}
+	if err := init.UnmarshalSSZ(initMsg.Data); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal init message: %s", err.Error())
 	}
+	return init, nil, nil
+}
+
+// checkOwnerSignature verifies owner signature of the init message of the ceremony with the request ID. Init messages
+// without owner signature are accepted unless the operator requires it.
+func (s *Switch) checkOwnerSignature(reqID [24]byte, init *wire.Init, signature []byte) error {
+	if signature == nil {
+		if s.RequireOwnerSignature {
+			return fmt.Errorf("init message should be signed by the owner")
+		}
+		return nil
+	}
+	if s.EthClient == nil {
+		return fmt.Errorf("can't verify owner signature, ethereum client is not set")
+	}
+	if err := spec.VerifySignedInit(s.EthClient, reqID, &wire.SignedInit{Init: *init, Signature: signature}); err != nil {
+		return fmt.Errorf("failed to verify owner signature: %s", err.Error())
+	}
+	return nil
+}
+
+// checkVersion checks that the protocol version requested by initiator is supported
+func (s *Switch) checkVersion(v []byte) error {
+	if !s.Versions.Supports(string(v)) {
+		return fmt.Errorf("wrong version: remote %s, supported %s", v, s.Versions)
+	}
+	return nil
 }
 
 // InitInstance creates a LocalOwner instance and DKG public key message (Exchange)
 func (s *Switch) InitInstance(reqID [24]byte, initMsg *wire.Transport, initiatorPub, initiatorSignature []byte) ([]byte, error) {
-	if !bytes.Equal(initMsg.Version, s.Version) {
-		return nil, fmt.Errorf("wrong version: remote %s local %s", initMsg.Version, s.Version)
+	if err := s.checkVersion(initMsg.Version); err != nil {
+		return nil, err
 	}
 	logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(reqID[:])))
 	logger.Info("🚀 Initializing DKG instance")
-	init := &wire.Init{}
-	if err := init.UnmarshalSSZ(initMsg.Data); err != nil {
-		return nil, fmt.Errorf("init: failed to unmarshal init message: %s", err.Error())
+	init, ownerSignature, err := unmarshalInit(initMsg)
+	if err != nil {
+		return nil, fmt.Errorf("init: %s", err.Error())
 	}
 	if err := spec.ValidateInitMessage(init); err != nil {
 		return nil, err
 	}
 	// Check that incoming message signature is valid
+	initiatorPubKey, err := s.verifyInitiatorSignature(initMsg, initiatorPub, initiatorSignature)
+	if err != nil {
+		return nil, fmt.Errorf("init: %s", err.Error())
+	}
+	if err := s.checkInitiator(initiatorPubKey); err != nil {
+		return nil, fmt.Errorf("init: %s", err.Error())
+	}
+	if err := s.checkOwnerSignature(reqID, init, ownerSignature); err != nil {
+		return nil, fmt.Errorf("init: %s", err.Error())
+	}
+	if ownerSignature != nil {
+		logger.Info("✅ init message owner signature is successfully verified", zap.String("owner", common.Address(init.Owner).Hex()))
+	}
+	initBytes, err := initMsg.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("init: failed to marshal init message: %s", err.Error())
+	}
+	if resp, ok := s.processedInit(reqID, initBytes, initiatorPubKey); ok {
+		return resp, nil
+	}
+	if err := s.checkInstance(reqID); err != nil {
+		return nil, err
+	}
+	if s.Policy != nil {
+		if err := s.checkPolicy(reqID, init, initiatorPubKey, logger); err != nil {
+			return nil, fmt.Errorf("init: %w", err)
+		}
+	}
+	inst, resp, err := s.CreateInstance(reqID, init, initiatorPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("init: failed to create instance: %s", err.Error())
+	}
+	// the response is kept for initiator retrying the init message
+	if _, err := inst.ProcessOnce(initBytes, func() ([]byte, error) { return resp, nil }); err != nil {
+		return nil, err
+	}
+	s.storeInstance(reqID, inst)
+	return resp, nil
+}
+
+// processedInit returns the response to the init message which already created the instance with the request ID.
+// Initiator retrying the init message, which response was lost, gets the same exchange message. Init messages
+// which differ from the first one, or repeated after the ceremony moved on, are refused by checkInstance.
+func (s *Switch) processedInit(reqID [24]byte, initBytes []byte, initiatorPubKey *rsa.PublicKey) ([]byte, bool) {
+	s.Mtx.RLock()
+	inst, ok := s.Instances[reqID]
+	createdAt := s.InstanceInitTime[reqID]
+	s.Mtx.RUnlock()
+	if !ok || time.Now().After(createdAt.Add(MaxInstanceTime)) || !inst.GetLocalOwner().InitiatorPublicKey.Equal(initiatorPubKey) {
+		return nil, false
+	}
+	return inst.FirstResponse(initBytes)
+}
+
+// InitInstanceReshare creates a LocalOwner instance for resharing ceremony and DKG public key message (Exchange)
+func (s *Switch) InitInstanceReshare(reqID [24]byte, reshareMsg *wire.Transport, initiatorPub, initiatorSignature []byte) ([]byte, error) {
+	if err := s.checkVersion(reshareMsg.Version); err != nil {
+		return nil, err
+	}
+	logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(reqID[:])))
+	logger.Info("🚀 Initializing resharing instance")
+	reshare := &wire.ReshareMessage{}
+	if err := reshare.UnmarshalSSZ(reshareMsg.Data); err != nil {
+		return nil, fmt.Errorf("reshare: failed to unmarshal reshare message: %s", err.Error())
+	}
+	if reshare.SignedReshare == nil {
+		return nil, fmt.Errorf("reshare: missing signed reshare")
+	}
+	// Check that incoming message signature is valid
+	initiatorPubKey, err := s.verifyInitiatorSignature(reshareMsg, initiatorPub, initiatorSignature)
+	if err != nil {
+		return nil, fmt.Errorf("reshare: %s", err.Error())
+	}
+	if err := s.checkInitiator(initiatorPubKey); err != nil {
+		return nil, fmt.Errorf("reshare: %s", err.Error())
+	}
+	if len(reshare.SignedReshare.Signature) == 0 {
+		return s.initTolerantReshare(reqID, reshare, initiatorPubKey, logger)
+	}
+	if err := validateReshareMessage(reshare); err != nil {
+		return nil, fmt.Errorf("reshare: %s", err.Error())
+	}
+	// Check that resharing is authorized by the validator owner
+	if s.EthClient == nil {
+		return nil, fmt.Errorf("reshare: can't verify owner signature, ethereum client is not set")
+	}
+	if err := spec.VerifySignedReshare(s.EthClient, reshare.SignedReshare); err != nil {
+		return nil, fmt.Errorf("reshare: failed to verify owner signature: %s", err.Error())
+	}
+	logger.Info("✅ reshare message owner signature is successfully verified", zap.String("owner", common.Address(reshare.SignedReshare.Reshare.Owner).Hex()))
+	if err := s.checkInstance(reqID); err != nil {
+		return nil, err
+	}
+	if s.Policy != nil {
+		if err := s.checkResharePolicy(reqID, reshare, initiatorPubKey, logger); err != nil {
+			return nil, fmt.Errorf("reshare: %w", err)
+		}
+	}
+	inst, resp, err := s.CreateInstanceReshare(reqID, reshare, nil, initiatorPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("reshare: failed to create instance: %s", err.Error())
+	}
+	s.storeInstance(reqID, inst)
+	return resp, nil
+}
+
+// initTolerantReshare replaces a finished threshold tolerant ceremony which excluded some of its operators with resharing
+// its key shares to a registrable cluster of the remaining operators. Such reshare message isn't signed by the owner:
+// it is accepted only from the initiator of the ceremony under the same request ID and has to keep the ceremony parameters.
+// The operator policy isn't evaluated again, the ceremony already passed it.
+func (s *Switch) initTolerantReshare(reqID [24]byte, reshare *wire.ReshareMessage, initiatorPubKey *rsa.PublicKey, logger *zap.Logger) ([]byte, error) {
+	s.Mtx.RLock()
+	inst, ok := s.Instances[reqID]
+	createdAt := s.InstanceInitTime[reqID]
+	s.Mtx.RUnlock()
+	if !ok || time.Now().After(createdAt.Add(MaxInstanceTime)) {
+		return nil, fmt.Errorf("reshare: reshare message should be signed by the owner")
+	}
+	owner := inst.GetLocalOwner()
+	if !owner.InitiatorPublicKey.Equal(initiatorPubKey) {
+		return nil, fmt.Errorf("reshare: ceremony was started by another initiator")
+	}
+	init, result := owner.TolerantResult()
+	if init == nil {
+		return nil, fmt.Errorf("reshare: reshare message should be signed by the owner")
+	}
+	if err := validateTolerantReshareMessage(reshare, init, result); err != nil {
+		return nil, fmt.Errorf("reshare: %s", err.Error())
+	}
+	logger.Info("♻️ Resharing key shares of the threshold tolerant ceremony to the remaining operators")
+	newInst, resp, err := s.CreateInstanceReshare(reqID, reshare, init, initiatorPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("reshare: failed to create instance: %s", err.Error())
+	}
+	s.storeInstance(reqID, newInst)
+	return resp, nil
+}
+
+// SignBeaconMessage signs a beacon message requested by the validator owner with the key share of the validator
+// kept at the share store and returns the partial signature
+func (s *Switch) SignBeaconMessage(reqID [24]byte, signMsg *wire.Transport, initiatorPub, initiatorSignature []byte) ([]byte, error) {
+	if err := s.checkVersion(signMsg.Version); err != nil {
+		return nil, err
+	}
+	logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(reqID[:])))
+	if s.ShareStore == nil {
+		return nil, fmt.Errorf("sign: key shares aren't stored by the operator")
+	}
+	msg := &wire.BlsSignMessage{}
+	if err := msg.UnmarshalSSZ(signMsg.Data); err != nil {
+		return nil, fmt.Errorf("sign: failed to unmarshal BLS sign message: %s", err.Error())
+	}
+	if err := validateBlsSignMessage(msg); err != nil {
+		return nil, fmt.Errorf("sign: %s", err.Error())
+	}
+	req := &msg.SignedRequest.Request
+	logger.Info("✍️ Signing beacon message", zap.String("type", req.MessageType.String()), zap.String("validator", hex.EncodeToString(req.ValidatorPubKey)))
+	initiatorPubKey, err := s.verifyInitiatorSignature(signMsg, initiatorPub, initiatorSignature)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %s", err.Error())
+	}
+	if err := s.checkInitiator(initiatorPubKey); err != nil {
+		return nil, fmt.Errorf("sign: %s", err.Error())
+	}
+	// Check that signing is authorized by the validator owner
+	if s.EthClient == nil {
+		return nil, fmt.Errorf("sign: can't verify owner signature, ethereum client is not set")
+	}
+	if err := spec.VerifySignedBlsSignRequest(s.EthClient, msg.SignedRequest); err != nil {
+		return nil, fmt.Errorf("sign: failed to verify owner signature: %s", err.Error())
+	}
+	if s.Policy != nil {
+		if err := s.checkSignPolicy(reqID, req, initiatorPubKey, logger); err != nil {
+			return nil, fmt.Errorf("sign: %w", err)
+		}
+	}
+	var proof *wire.SignedProof
+	for i, op := range req.Operators {
+		if op.ID == s.OperatorID && bytes.Equal(op.PubKey, s.PubKeyBytes) {
+			proof = msg.Proofs[i]
+		}
+	}
+	if proof == nil {
+		return nil, fmt.Errorf("sign: operator %d isn't a part of the request", s.OperatorID)
+	}
+	ks, share, err := s.ShareStore.LoadBySharePubKey(req.ValidatorPubKey, proof.Proof.SharePubKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign: failed to load key share: %s", err.Error())
+	}
+	if !common.IsHexAddress(ks.Owner) || common.HexToAddress(ks.Owner) != common.Address(req.Owner) {
+		return nil, fmt.Errorf("sign: key share belongs to another owner")
+	}
+	signingRoot, err := spec.BlsSignRequestSigningRoot(req)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %s", err.Error())
+	}
+	resp := &wire.BlsSignResponse{
+		OperatorID:       s.OperatorID,
+		PartialSignature: share.SignByte(signingRoot[:]).Serialize(),
+	}
+	logger.Info("✅ beacon message is signed", zap.String("share request ID", ks.RequestID))
+	return s.MarshallAndSign(resp, wire.BlsSignResponseType, s.OperatorID, reqID)
+}
+
+// verifyInitiatorSignature checks initiator signature of the message starting a new instance
+func (s *Switch) verifyInitiatorSignature(msg *wire.Transport, initiatorPub, initiatorSignature []byte) (*rsa.PublicKey, error) {
 	initiatorPubKey, err := crypto.ParseRSAPublicKey(initiatorPub)
 	if err != nil {
-		return nil, fmt.Errorf("init: failed parse initiator public key: %s", err.Error())
+		return nil, fmt.Errorf("failed parse initiator public key: %s", err.Error())
 	}
-	marshalledWireMsg, err := initMsg.MarshalSSZ()
+	marshalledWireMsg, err := msg.MarshalSSZ()
 	if err != nil {
-		return nil, fmt.Errorf("init: failed to marshal transport message: %s", err.Error())
+		return nil, fmt.Errorf("failed to marshal transport message: %s", err.Error())
 	}
 	err = crypto.VerifyRSA(initiatorPubKey, marshalledWireMsg, initiatorSignature)
 	if err != nil {
-		return nil, fmt.Errorf("init: initiator signature isn't valid: %s", err.Error())
+		return nil, fmt.Errorf("initiator signature isn't valid: %s", err.Error())
 	}
 	s.Logger.Info("✅ init message signature is successfully verified", zap.String("from initiator", fmt.Sprintf("%x", initiatorPubKey.N.Bytes())))
+	return initiatorPubKey, nil
+}
+
+// checkInstance makes sure that a new instance can be created: max amount of instances isn't reached
+// and there is no running instance with the same ID
+func (s *Switch) checkInstance(reqID [24]byte) error {
 	s.Mtx.Lock()
+	defer s.Mtx.Unlock()
 	l := len(s.Instances)
 	if l >= MaxInstances {
 		cleaned := s.CleanInstances()
 		if l-cleaned >= MaxInstances {
-			s.Mtx.Unlock()
-			return nil, utils.ErrMaxInstances
+			return utils.ErrMaxInstances
 		}
 	}
 	_, ok := s.Instances[reqID]
 	if ok {
 		tm := s.InstanceInitTime[reqID]
 		if time.Now().Before(tm.Add(MaxInstanceTime)) {
-			s.Mtx.Unlock()
-			return nil, utils.ErrAlreadyExists
+			return utils.ErrAlreadyExists
 		}
 		delete(s.Instances, reqID)
 		delete(s.InstanceInitTime, reqID)
+		s.deleteState(reqID)
 	}
-	s.Mtx.Unlock()
-	inst, resp, err := s.CreateInstance(reqID, init, initiatorPubKey)
-	if err != nil {
-		return nil, fmt.Errorf("init: failed to create instance: %s", err.Error())
-	}
+	return nil
+}
+
+// storeInstance saves a created instance and its creation time
+func (s *Switch) storeInstance(reqID [24]byte, inst Instance) {
 	s.Mtx.Lock()
 	s.Instances[reqID] = inst
 	s.InstanceInitTime[reqID] = time.Now()
 	s.Mtx.Unlock()
-	return resp, nil
+}
+
+// validateReshareMessage checks reshare message and proofs of the previous ceremony
+func validateReshareMessage(reshare *wire.ReshareMessage) error {
+	if reshare.SignedReshare == nil {
+		return fmt.Errorf("missing signed reshare")
+	}
+	oldOperators := reshare.SignedReshare.Reshare.OldOperators
+	if len(reshare.Proofs) != len(oldOperators) {
+		return fmt.Errorf("proofs count doesn't match old operators count")
+	}
+	if _, err := crypto.WithdrawalCredentials(reshare.WithdrawalPrefix, reshare.WithdrawalCredentials); err != nil {
+		return err
+	}
+	if err := crypto.ValidateDepositAmount(reshare.WithdrawalPrefix, phase0.Gwei(reshare.Amount)); err != nil {
+		return err
+	}
+	if err := spec.ValidatePhaseTimeout(reshare.PhaseTimeout); err != nil {
+		return err
+	}
+	proofs := make(map[*wire.Operator]wire.SignedProof, len(oldOperators))
+	for i, op := range oldOperators {
+		proofs[op] = *reshare.Proofs[i]
+	}
+	return spec.ValidateReshareMessage(&reshare.SignedReshare.Reshare, proofs)
+}
+
+// validateTolerantReshareMessage checks reshare message finishing the threshold tolerant ceremony with the init message,
+// which resulted in the result of the operator
+func validateTolerantReshareMessage(reshare *wire.ReshareMessage, init *wire.Init, result *wire.Result) error {
+	oldOperators := reshare.SignedReshare.Reshare.OldOperators
+	if len(reshare.Proofs) != len(oldOperators) {
+		return fmt.Errorf("proofs count doesn't match old operators count")
+	}
+	if !bytes.Equal(reshare.WithdrawalCredentials, init.WithdrawalCredentials) || reshare.WithdrawalPrefix != init.WithdrawalPrefix ||
+		reshare.Fork != init.Fork || reshare.Amount != init.Amount {
+		return fmt.Errorf("deposit parameters don't match the ceremony")
+	}
+	if err := spec.ValidatePhaseTimeout(reshare.PhaseTimeout); err != nil {
+		return err
+	}
+	proofs := make(map[*wire.Operator]wire.SignedProof, len(oldOperators))
+	var own *wire.SignedProof
+	for i, op := range oldOperators {
+		proofs[op] = *reshare.Proofs[i]
+		if op.ID == result.OperatorID {
+			own = reshare.Proofs[i]
+		}
+	}
+	// the operator checks that its own key share of the ceremony is reshared
+	if own == nil {
+		return fmt.Errorf("operator isn't an old operator")
+	}
+	ownRoot, err := own.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	resultRoot, err := result.SignedProof.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if ownRoot != resultRoot {
+		return fmt.Errorf("proof of the operator doesn't match its ceremony result")
+	}
+	return spec.ValidateTolerantReshareMessage(init, &reshare.SignedReshare.Reshare, proofs)
+}
+
+// validateBlsSignMessage checks request to sign a beacon message and proofs of the ceremony
+func validateBlsSignMessage(msg *wire.BlsSignMessage) error {
+	if msg.SignedRequest == nil {
+		return fmt.Errorf("missing signed request")
+	}
+	operators := msg.SignedRequest.Request.Operators
+	if len(msg.Proofs) != len(operators) {
+		return fmt.Errorf("proofs count doesn't match operators count")
+	}
+	proofs := make(map[*wire.Operator]wire.SignedProof, len(operators))
+	for i, op := range operators {
+		proofs[op] = *msg.Proofs[i]
+	}
+	return spec.ValidateBlsSignRequest(&msg.SignedRequest.Request, proofs)
 }
 
 // CleanInstances removes all instances at Switch
@@ -217,6 +767,7 @@ func (s *Switch) CleanInstances() int {
 		if time.Now().After(instime.Add(MaxInstanceTime)) {
 			delete(s.Instances, id)
 			delete(s.InstanceInitTime, id)
+			s.deleteState(id)
 			count++
 		}
 	}
@@ -254,15 +805,23 @@ func (s *Switch) ProcessMessage(dkgMsg []byte) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("process message: failed to verify initiator signature: %s", err.Error())
 	}
-	for _, ts := range st.Messages {
-		err = inst.Process(ts)
-		if err != nil {
-			return nil, fmt.Errorf("process message: failed to process dkg message: %s", err.Error())
+	// initiator signature isn't deterministic, so a retried message is recognized by the signed messages
+	return inst.ProcessOnce(mltplMsgsBytes, func() ([]byte, error) {
+		for _, ts := range st.Messages {
+			err = inst.Process(ts)
+			if err != nil {
+				return nil, fmt.Errorf("process message: failed to process dkg message: %s", err.Error())
+			}
 		}
-	}
-	resp := inst.ReadResponse()
-
-	return resp, nil
+		// initiator sends all exchange messages it has at once, if some operators didn't respond to init message
+		// of a threshold tolerant ceremony DKG starts without them
+		if len(st.Messages) > 0 && st.Messages[0].Message.Type == wire.ExchangeMessageType {
+			if err := inst.StartWithReceivedExchanges(); err != nil {
+				return nil, fmt.Errorf("process message: failed to start dkg: %s", err.Error())
+			}
+		}
+		return inst.ReadResponse(), nil
+	})
 }
 
 func (s *Switch) MarshallAndSign(msg wire.SSZMarshaller, msgType wire.TransportType, operatorID uint64, id [24]byte) ([]byte, error) {
@@ -298,13 +857,31 @@ func (s *Switch) MarshallAndSign(msg wire.SSZMarshaller, msgType wire.TransportT
 
 func (s *Switch) Pong() ([]byte, error) {
 	pong := &wire.Pong{
-		ID:     s.OperatorID,
-		PubKey: s.PubKeyBytes,
+		ID:         s.OperatorID,
+		PubKey:     s.PubKeyBytes,
+		MinVersion: []byte(s.Versions.Min),
+		MaxVersion: []byte(s.Versions.Max),
+		Features:   s.features(),
 	}
 	return s.MarshallAndSign(pong, wire.PongMessageType, s.OperatorID, [24]byte{})
 }
 
+// features returns protocol features supported by the operator
+func (s *Switch) features() [][]byte {
+	features := [][]byte{[]byte(wire.FeatureMultipleValidators), []byte(wire.FeatureSignedExit), []byte(wire.FeatureThresholdTolerant)}
+	if s.EthClient != nil {
+		features = append(features, []byte(wire.FeatureReshare), []byte(wire.FeatureOwnerSignedInit))
+		if s.ShareStore != nil {
+			features = append(features, []byte(wire.FeatureBlsSign))
+		}
+	}
+	return features
+}
+
 func (s *Switch) SaveResultData(incMsg *wire.SignedTransport, outputPath string) error {
+	if err := s.checkVersion(incMsg.Message.Version); err != nil {
+		return err
+	}
 	resData := &wire.ResultData{}
 	err := resData.UnmarshalSSZ(incMsg.Message.Data)
 	if err != nil {
@@ -314,34 +891,40 @@ func (s *Switch) SaveResultData(incMsg *wire.SignedTransport, outputPath string)
 	if err != nil {
 		return err
 	}
-	// Assuming depJson, ksJson, and proofs can be singular instances based on your logic
-	var depJson *wire.DepositDataCLI
-	if len(resData.DepositData) != 0 {
-		err = json.Unmarshal(resData.DepositData, &depJson)
-		if err != nil {
-			return err
-		}
+	// Results hold deposit data, key shares and proofs of each validator created by the ceremony
+	var depositDataArr []*wire.DepositDataCLI
+	if err := json.Unmarshal(resData.DepositData, &depositDataArr); err != nil {
+		return err
 	}
-	var ksJson *wire.KeySharesCLI
-	err = json.Unmarshal(resData.KeysharesData, &ksJson)
-	if err != nil {
+	var keySharesArr []*wire.KeySharesCLI
+	if err := json.Unmarshal(resData.KeysharesData, &keySharesArr); err != nil {
 		return err
 	}
-	var proof []*wire.SignedProof
-	err = json.Unmarshal(resData.Proofs, &proof)
-	if err != nil {
+	var proofsArr [][]*wire.SignedProof
+	if err := json.Unmarshal(resData.Proofs, &proofsArr); err != nil {
 		return err
 	}
-	// Save results.
-	depositDataArr := []*wire.DepositDataCLI{depJson}
-	keySharesArr := []*wire.KeySharesCLI{ksJson}
-	proofsArr := [][]*wire.SignedProof{proof}
-	withdrawCreds, err := hex.DecodeString(depJson.WithdrawalCredentials)
+	if len(depositDataArr) == 0 || len(keySharesArr) == 0 {
+		return fmt.Errorf("no results to save")
+	}
+	for _, ks := range keySharesArr {
+		if ks == nil || len(ks.Shares) == 0 {
+			return fmt.Errorf("empty key shares at results")
+		}
+	}
+	// the first validator of the ceremony has the lowest nonce
+	first := keySharesArr[0].Shares[0]
+	for _, ks := range keySharesArr[1:] {
+		if ks.Shares[0].OwnerNonce < first.OwnerNonce {
+			first = ks.Shares[0]
+		}
+	}
+	withdrawCreds, err := hex.DecodeString(depositDataArr[0].WithdrawalCredentials)
 	if err != nil {
 		return fmt.Errorf("failed to decode withdrawal credentials: %s", err.Error())
 	}
-	withdrawPrefix, withdrawAddress := crypto.ParseWithdrawalCredentials(withdrawCreds)
-	if withdrawPrefix != crypto.ETH1WithdrawalPrefixByte {
+	withdrawPrefix, _ := crypto.ParseWithdrawalCredentials(withdrawCreds)
+	if withdrawPrefix != crypto.BLSWithdrawalPrefixByte && withdrawPrefix != crypto.ETH1WithdrawalPrefixByte && withdrawPrefix != crypto.CompoundingWithdrawalPrefixByte {
 		return fmt.Errorf("invalid withdrawal prefix: %x", withdrawPrefix)
 	}
 	return cli_utils.WriteResults(
@@ -349,11 +932,13 @@ func (s *Switch) SaveResultData(incMsg *wire.SignedTransport, outputPath string)
 		depositDataArr,
 		keySharesArr,
 		proofsArr,
+		nil,
 		true,
-		1,
-		common.HexToAddress(keySharesArr[0].Shares[0].OwnerAddress),
-		keySharesArr[0].Shares[0].OwnerNonce,
-		common.BytesToAddress(withdrawAddress),
+		len(depositDataArr),
+		common.HexToAddress(first.OwnerAddress),
+		first.OwnerNonce,
+		withdrawCreds,
+		depositDataArr[0].Amount,
 		outputPath,
 	)
 }
@@ -57,7 +57,7 @@ func TestCreateInstance(t *testing.T) {
 		privateKey, ops := generateOperatorsData(t, numOps)
 		tempDir, err := os.MkdirTemp("", "dkg")
 		require.NoError(t, err)
-		s, err := New(privateKey, logger, []byte("test.version"), 1, tempDir)
+		s, err := New(privateKey, logger, []byte("test.version"), 1, tempDir, nil)
 		require.NoError(t, err)
 		var reqID [24]byte
 		copy(reqID[:], "testRequestID1234567890") // Just a sample value
@@ -66,9 +66,11 @@ func TestCreateInstance(t *testing.T) {
 		priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
 		require.NoError(t, err)
 		init := &wire.Init{
-			Operators: ops,
-			Owner:     common.HexToAddress("0x0000000"),
-			Nonce:     1,
+			Operators:        ops,
+			Owner:            common.HexToAddress("0x0000000"),
+			Nonce:            1,
+			WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+			Amount:           uint64(crypto.MaxEffectiveBalanceInGwei),
 		}
 
 		inst, resp, err := s.State.CreateInstance(reqID, init, &priv.PublicKey)
@@ -105,7 +107,7 @@ func TestInitInstance(t *testing.T) {
 	require.NoError(t, err)
 	tempDir, err := os.MkdirTemp("", "dkg")
 	require.NoError(t, err)
-	swtch, err := New(privateKey, logger, []byte("test.version"), 1, tempDir)
+	swtch, err := New(privateKey, logger, []byte("test.version"), 1, tempDir, nil)
 	require.NoError(t, err)
 	var reqID [24]byte
 	copy(reqID[:], "testRequestID1234567890") // Just a sample value
@@ -124,17 +126,18 @@ func TestInitInstance(t *testing.T) {
 		Owner:                 common.HexToAddress("0x0000001"),
 		Nonce:                 1,
 		T:                     3,
-		WithdrawalCredentials: []byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		WithdrawalCredentials: []byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
 	}
 
 	initmsg, err := init.MarshalSSZ()
 	require.NoError(t, err)
-	version := "test.version"
 	initMessage := &wire.Transport{
 		Type:       wire.InitMessageType,
 		Identifier: reqID,
 		Data:       initmsg,
-		Version:    []byte(version),
+		Version:    []byte(wire.ProtocolVersion),
 	}
 	tsssz, err := initMessage.MarshalSSZ()
 	require.NoError(t, err)
@@ -147,7 +150,33 @@ func TestInitInstance(t *testing.T) {
 
 	require.Len(t, swtch.State.Instances, 1)
 
+	// retried init message gets the response to the first one
 	resp2, err2 := swtch.State.InitInstance(reqID, initMessage, encPubKey, sig)
+	require.NoError(t, err2)
+	require.Equal(t, resp, resp2)
+	require.Len(t, swtch.State.Instances, 1)
+
+	// another init message with the same ID is refused
+	otherInit := *init
+	otherInit.Nonce = 2
+	otherInitMsg, err := otherInit.MarshalSSZ()
+	require.NoError(t, err)
+	otherMessage := &wire.Transport{Type: wire.InitMessageType, Identifier: reqID, Data: otherInitMsg, Version: []byte(wire.ProtocolVersion)}
+	otherSSZ, err := otherMessage.MarshalSSZ()
+	require.NoError(t, err)
+	otherSig, err := crypto.SignRSA(priv, otherSSZ)
+	require.NoError(t, err)
+	resp2, err2 = swtch.State.InitInstance(reqID, otherMessage, encPubKey, otherSig)
+	require.Equal(t, err2, utils.ErrAlreadyExists)
+	require.Nil(t, resp2)
+
+	// the same init message from another initiator is refused
+	otherInitiator := singleOperatorKeys(t)
+	otherPubKey, err := crypto.EncodeRSAPublicKey(&otherInitiator.PublicKey)
+	require.NoError(t, err)
+	otherSig, err = crypto.SignRSA(otherInitiator, tsssz)
+	require.NoError(t, err)
+	resp2, err2 = swtch.State.InitInstance(reqID, initMessage, otherPubKey, otherSig)
 	require.Equal(t, err2, utils.ErrAlreadyExists)
 	require.Nil(t, resp2)
 
@@ -177,6 +206,33 @@ func TestInitInstance(t *testing.T) {
 
 }
 
+func TestCheckVersion(t *testing.T) {
+	s := &Switch{Versions: wire.VersionRange{Min: "v1.0.0", Max: "v1.1.0"}}
+	require.NoError(t, s.checkVersion([]byte("v1.0.0")))
+	require.NoError(t, s.checkVersion([]byte("v1.1.0")))
+	require.ErrorContains(t, s.checkVersion([]byte("v1.2.0")), "wrong version")
+	require.ErrorContains(t, s.checkVersion([]byte("test.version")), "wrong version")
+}
+
+func TestCheckInitiator(t *testing.T) {
+	trusted := singleOperatorKeys(t)
+	untrusted := singleOperatorKeys(t)
+	s := &Switch{}
+	require.NoError(t, s.checkInitiator(&untrusted.PublicKey))
+	s.TrustedInitiators = []*rsa.PublicKey{&trusted.PublicKey}
+	require.NoError(t, s.checkInitiator(&trusted.PublicKey))
+	require.ErrorContains(t, s.checkInitiator(&untrusted.PublicKey), "initiator is not trusted")
+}
+
+func TestCheckOwnerSignature(t *testing.T) {
+	init := &wire.Init{Owner: common.HexToAddress("0x0000001")}
+	s := &Switch{}
+	require.NoError(t, s.checkOwnerSignature([24]byte{}, init, nil))
+	require.ErrorContains(t, s.checkOwnerSignature([24]byte{}, init, make([]byte, 65)), "ethereum client is not set")
+	s.RequireOwnerSignature = true
+	require.ErrorContains(t, s.checkOwnerSignature([24]byte{}, init, nil), "init message should be signed by the owner")
+}
+
 func TestSwitch_cleanInstances(t *testing.T) {
 	privateKey, ops := generateOperatorsData(t, 4)
 	err := logging.SetGlobalLogger("info", "capital", "console", nil)
@@ -185,7 +241,7 @@ func TestSwitch_cleanInstances(t *testing.T) {
 	operatorPubKey := privateKey.Public().(*rsa.PublicKey)
 	pkBytes, err := crypto.EncodeRSAPublicKey(operatorPubKey)
 	require.NoError(t, err)
-	swtch := NewSwitch(privateKey, logger, []byte("test.version"), pkBytes, 1)
+	swtch := NewSwitch(privateKey, logger, []byte("test.version"), pkBytes, 1, nil)
 	var reqID [24]byte
 	copy(reqID[:], "testRequestID1234567890") // Just a sample value
 	_, pv, err := rsaencryption.GenerateKeys()
@@ -201,18 +257,19 @@ func TestSwitch_cleanInstances(t *testing.T) {
 		Operators:             ops,
 		Owner:                 common.HexToAddress("0x0000001"),
 		Nonce:                 1,
-		WithdrawalCredentials: []byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		WithdrawalCredentials: []byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
 		T:                     3,
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
 	}
 
 	initmsg, err := init.MarshalSSZ()
 	require.NoError(t, err)
-	version := "test.version"
 	initMessage := &wire.Transport{
 		Type:       wire.InitMessageType,
 		Identifier: reqID,
 		Data:       initmsg,
-		Version:    []byte(version),
+		Version:    []byte(wire.ProtocolVersion),
 	}
 	tsssz, err := initMessage.MarshalSSZ()
 	require.NoError(t, err)
@@ -230,3 +287,118 @@ func TestSwitch_cleanInstances(t *testing.T) {
 	require.Len(t, swtch.Instances, 0)
 
 }
+
+func TestProcessOnce(t *testing.T) {
+	logger := zap.L().Named("state-tests")
+	privateKey, ops := generateOperatorsData(t, 4)
+	s, err := New(privateKey, logger, []byte("test.version"), 1, t.TempDir(), nil)
+	require.NoError(t, err)
+	init := &wire.Init{
+		Operators:        ops,
+		Owner:            common.HexToAddress("0x0000000"),
+		Nonce:            1,
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Amount:           uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	inst, _, err := s.State.CreateInstance(crypto.NewID(), init, &singleOperatorKeys(t).PublicKey)
+	require.NoError(t, err)
+	calls := 0
+	process := func() ([]byte, error) {
+		calls++
+		return []byte(fmt.Sprintf("response %d", calls)), nil
+	}
+	t.Run("test retried message gets the first response", func(t *testing.T) {
+		resp, err := inst.ProcessOnce([]byte("msg"), process)
+		require.NoError(t, err)
+		require.Equal(t, []byte("response 1"), resp)
+		resp, err = inst.ProcessOnce([]byte("msg"), process)
+		require.NoError(t, err)
+		require.Equal(t, []byte("response 1"), resp)
+		require.Equal(t, 1, calls)
+		resp, ok := inst.FirstResponse([]byte("msg"))
+		require.True(t, ok)
+		require.Equal(t, []byte("response 1"), resp)
+	})
+	t.Run("test failed message processed again", func(t *testing.T) {
+		_, err := inst.ProcessOnce([]byte("failed msg"), func() ([]byte, error) {
+			return nil, fmt.Errorf("failed")
+		})
+		require.Error(t, err)
+		resp, err := inst.ProcessOnce([]byte("failed msg"), process)
+		require.NoError(t, err)
+		require.Equal(t, []byte("response 2"), resp)
+	})
+	t.Run("test first message isn't responded after the next ones", func(t *testing.T) {
+		_, ok := inst.FirstResponse([]byte("msg"))
+		require.False(t, ok)
+	})
+}
+
+func TestInitTolerantReshare(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("state-tests")
+	privateKey, ops := generateOperatorsData(t, 4)
+	tempDir, err := os.MkdirTemp("", "dkg")
+	require.NoError(t, err)
+	swtch, err := New(privateKey, logger, []byte("test.version"), 1, tempDir, nil)
+	require.NoError(t, err)
+	var reqID [24]byte
+	copy(reqID[:], "testRequestID1234567890")
+	initiatorKey := singleOperatorKeys(t)
+	signed := func(t *testing.T, key *rsa.PrivateKey, msgType wire.TransportType, msg wire.SSZMarshaller) (*wire.Transport, []byte, []byte) {
+		data, err := msg.MarshalSSZ()
+		require.NoError(t, err)
+		ts := &wire.Transport{Type: msgType, Identifier: reqID, Data: data, Version: []byte(wire.ProtocolVersion)}
+		tsssz, err := ts.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(key, tsssz)
+		require.NoError(t, err)
+		pub, err := crypto.EncodeRSAPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		return ts, pub, sig
+	}
+	// reshare message which isn't signed by the owner
+	reshare := &wire.ReshareMessage{
+		SignedReshare: &wire.SignedReshare{
+			Reshare: wire.Reshare{
+				ValidatorPubKey: make([]byte, 48),
+				OldOperators:    ops[:3],
+				NewOperators:    ops[:3],
+				OldT:            3,
+				NewT:            3,
+			},
+		},
+		WithdrawalCredentials: make([]byte, 20),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	t.Run("unsigned reshare without ceremony", func(t *testing.T) {
+		ts, pub, sig := signed(t, initiatorKey, wire.ReshareMessageType, reshare)
+		_, err := swtch.State.InitInstanceReshare(reqID, ts, pub, sig)
+		require.ErrorContains(t, err, "reshare message should be signed by the owner")
+	})
+	init := &wire.Init{
+		Operators:             ops,
+		Owner:                 common.HexToAddress("0x0000001"),
+		Nonce:                 1,
+		T:                     3,
+		WithdrawalCredentials: make([]byte, 20),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		ThresholdTolerant:     true,
+	}
+	ts, pub, sig := signed(t, initiatorKey, wire.InitMessageType, init)
+	_, err = swtch.State.InitInstance(reqID, ts, pub, sig)
+	require.NoError(t, err)
+	t.Run("unsigned reshare from another initiator", func(t *testing.T) {
+		ts, pub, sig := signed(t, singleOperatorKeys(t), wire.ReshareMessageType, reshare)
+		_, err := swtch.State.InitInstanceReshare(reqID, ts, pub, sig)
+		require.ErrorContains(t, err, "ceremony was started by another initiator")
+	})
+	t.Run("unsigned reshare of unfinished ceremony", func(t *testing.T) {
+		ts, pub, sig := signed(t, initiatorKey, wire.ReshareMessageType, reshare)
+		_, err := swtch.State.InitInstanceReshare(reqID, ts, pub, sig)
+		require.ErrorContains(t, err, "reshare message should be signed by the owner")
+	})
+}
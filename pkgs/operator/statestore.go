@@ -0,0 +1,138 @@
+package operator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+)
+
+// InstanceState is a persisted state of a DKG instance at operator
+type InstanceState struct {
+	*dkg.State
+	// encoded RSA public key of initiator which started the instance
+	InitiatorPublicKey []byte    `json:"initiator_public_key"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// StateStore persists states of DKG instances, so operator can restore them after restart
+type StateStore interface {
+	Save(id InstanceID, st *InstanceState) error
+	Delete(id InstanceID) error
+	LoadAll() (map[InstanceID]*InstanceState, error)
+}
+
+// FileStateStore keeps a JSON file per instance at a directory
+type FileStateStore struct {
+	Dir string
+}
+
+// NewFileStateStore creates a StateStore writing instance states to the directory
+func NewFileStateStore(dir string) *FileStateStore {
+	return &FileStateStore{Dir: dir}
+}
+
+// Save writes instance state to a temporary file and renames it, so a crash doesn't leave a partially written state
+func (s *FileStateStore) Save(id InstanceID, st *InstanceState) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmp := s.path(id) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(id))
+}
+
+// Delete removes instance state file
+func (s *FileStateStore) Delete(id InstanceID) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// LoadAll reads states of all instances at the directory
+func (s *FileStateStore) LoadAll() (map[InstanceID]*InstanceState, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[InstanceID]*InstanceState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[InstanceID]*InstanceState, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		idBytes, err := hex.DecodeString(strings.TrimSuffix(name, ".json"))
+		if err != nil || len(idBytes) != len(InstanceID{}) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.Dir, name))
+		if err != nil {
+			return nil, err
+		}
+		st := &InstanceState{}
+		if err := json.Unmarshal(data, st); err != nil {
+			return nil, fmt.Errorf("failed to parse instance state %s: %w", name, err)
+		}
+		var id InstanceID
+		copy(id[:], idBytes)
+		states[id] = st
+	}
+	return states, nil
+}
+
+func (s *FileStateStore) path(id InstanceID) string {
+	return filepath.Join(s.Dir, hex.EncodeToString(id[:])+".json")
+}
+
+// MemoryStateStore keeps instance states in memory, states don't survive restart. Used for tests.
+type MemoryStateStore struct {
+	mtx    sync.Mutex
+	states map[InstanceID]*InstanceState
+}
+
+// NewMemoryStateStore creates an empty in-memory StateStore
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: make(map[InstanceID]*InstanceState)}
+}
+
+func (s *MemoryStateStore) Save(id InstanceID, st *InstanceState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.states[id] = st
+	return nil
+}
+
+func (s *MemoryStateStore) Delete(id InstanceID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.states, id)
+	return nil
+}
+
+func (s *MemoryStateStore) LoadAll() (map[InstanceID]*InstanceState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	states := make(map[InstanceID]*InstanceState, len(s.states))
+	for id, st := range s.states {
+		states[id] = st
+	}
+	return states, nil
+}
@@ -0,0 +1,79 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv/logging"
+)
+
+func TestRestoreInstances(t *testing.T) {
+	err := logging.SetGlobalLogger("info", "capital", "console", nil)
+	require.NoError(t, err)
+	logger := zap.L().Named("state-tests")
+	privateKey, ops := generateOperatorsData(t, 4)
+	initiatorKey := singleOperatorKeys(t)
+	store := NewFileStateStore(t.TempDir())
+	newSwitch := func() *Switch {
+		s, err := New(privateKey, logger, []byte("test.version"), 1, t.TempDir(), nil)
+		require.NoError(t, err)
+		s.State.StateStore = store
+		require.NoError(t, s.State.RestoreInstances())
+		return s.State
+	}
+	init := &wire.Init{
+		Operators:             ops,
+		T:                     3,
+		Owner:                 common.HexToAddress("0x0000001"),
+		Nonce:                 1,
+		WithdrawalCredentials: common.HexToAddress("0x0000002").Bytes(),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	swtch := newSwitch()
+	reqID := crypto.NewID()
+	inst, _, err := swtch.CreateInstance(reqID, init, &initiatorKey.PublicKey)
+	require.NoError(t, err)
+	swtch.storeInstance(reqID, inst)
+	expiredID := crypto.NewID()
+	_, _, err = swtch.CreateInstance(expiredID, init, &initiatorKey.PublicKey)
+	require.NoError(t, err)
+	states, err := store.LoadAll()
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.Equal(t, dkg.ExchangePhase, states[reqID].Phase)
+	states[expiredID].CreatedAt = time.Now().Add(-MaxInstanceTime)
+	require.NoError(t, store.Save(expiredID, states[expiredID]))
+
+	t.Run("test instance restored after restart", func(t *testing.T) {
+		restarted := newSwitch()
+		require.Len(t, restarted.Instances, 1)
+		restored, ok := restarted.Instances[reqID]
+		require.True(t, ok)
+		require.True(t, restored.GetLocalOwner().InitiatorPublicKey.Equal(&initiatorKey.PublicKey))
+		require.Equal(t, states[reqID].CreatedAt.Unix(), restarted.InstanceInitTime[reqID].Unix())
+		// init message of a restored instance isn't accepted again
+		require.Equal(t, utils.ErrAlreadyExists, restarted.checkInstance(reqID))
+	})
+	t.Run("test expired instance state removed", func(t *testing.T) {
+		states, err := store.LoadAll()
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		require.Contains(t, states, InstanceID(reqID))
+	})
+	t.Run("test state removed with cleaned instance", func(t *testing.T) {
+		swtch.InstanceInitTime[reqID] = time.Now().Add(-MaxInstanceTime)
+		require.Equal(t, 1, swtch.CleanInstances())
+		states, err := store.LoadAll()
+		require.NoError(t, err)
+		require.Empty(t, states)
+	})
+}
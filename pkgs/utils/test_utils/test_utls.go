@@ -22,6 +22,7 @@ import (
 	"github.com/bloxapp/ssv-dkg/pkgs/operator"
 	"github.com/bloxapp/ssv-dkg/pkgs/utils"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec/testing/stubs"
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 )
@@ -48,7 +49,7 @@ func CreateTestOperatorFromFile(t *testing.T, id uint64, examplePath, version, o
 	operatorPubKey := priv.Public().(*rsa.PublicKey)
 	pkBytes, err := crypto.EncodeRSAPublicKey(operatorPubKey)
 	require.NoError(t, err)
-	swtch := operator.NewSwitch(priv, logger, []byte(version), pkBytes, id)
+	swtch := operator.NewSwitch(priv, logger, []byte(version), pkBytes, id, &stubs.Client{})
 	tempDir, err := os.MkdirTemp("", "dkg")
 	require.NoError(t, err)
 	s := &operator.Server{
@@ -81,7 +82,7 @@ func CreateTestOperator(t *testing.T, id uint64, version, operatorCert, operator
 	operatorPubKey := priv.Public().(*rsa.PublicKey)
 	pkBytes, err := crypto.EncodeRSAPublicKey(operatorPubKey)
 	require.NoError(t, err)
-	swtch := operator.NewSwitch(priv, logger, []byte(version), pkBytes, id)
+	swtch := operator.NewSwitch(priv, logger, []byte(version), pkBytes, id, &stubs.Client{})
 	tempDir, err := os.MkdirTemp("", "dkg")
 	require.NoError(t, err)
 	s := &operator.Server{
@@ -11,6 +11,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	e2m_core "github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -28,9 +32,10 @@ type ResultsValidatorDir struct {
 	DepositData []*wire.DepositDataCLI
 	KeyShares   *wire.KeySharesCLI
 	Proofs      []*wire.SignedProof
+	Exit        *phase0.SignedVoluntaryExit // pre-signed voluntary exit, nil if it wasn't requested
 }
 
-func ValidateResultsDir(dir string, validatorCount int, ownerAddress common.Address, ownerNonce uint64, withdrawAddress common.Address) error {
+func ValidateResultsDir(dir string, validatorCount int, ownerAddress common.Address, ownerNonce uint64, withdrawalCredentials []byte, amount phase0.Gwei) error {
 	if validatorCount < 1 {
 		return fmt.Errorf("validator count is less than 1")
 	}
@@ -77,6 +82,11 @@ func ValidateResultsDir(dir string, validatorCount int, ownerAddress common.Addr
 				return fmt.Errorf("validator public key does not match proof public key")
 			}
 		}
+		if validator.Exit != nil {
+			if err := verifyExit(validator); err != nil {
+				return err
+			}
+		}
 
 		// Verify that the validator data is equal to the aggregated data.
 		if validatorCount > 1 {
@@ -144,7 +154,7 @@ func ValidateResultsDir(dir string, validatorCount int, ownerAddress common.Addr
 		aggregatedKeyShares.Shares = append(aggregatedKeyShares.Shares, validator.KeyShares.Shares[0])
 		aggregatedProofs = append(aggregatedProofs, validator.Proofs)
 	}
-	return ValidateResults(aggregatedDepositData, aggregatedKeyShares, aggregatedProofs, validatorCount, ownerAddress, ownerNonce, withdrawAddress)
+	return ValidateResults(aggregatedDepositData, aggregatedKeyShares, aggregatedProofs, validatorCount, ownerAddress, ownerNonce, withdrawalCredentials, amount)
 }
 
 var regexpValidatorDir = regexp.MustCompile(`^(\d+)-0x([0-9a-f]{96})$`)
@@ -192,6 +202,12 @@ func OpenResultsDir(dir string) (*ResultsDir, error) {
 		if err := loadJSONFile(filepath.Join(validatorDir, "proofs.json"), &validator.Proofs); err != nil {
 			return nil, fmt.Errorf("failed to load proofs: %w", err)
 		}
+		exitFile := filepath.Join(validatorDir, fmt.Sprintf("exit-0x%s.json", validator.PublicKey))
+		if _, err := os.Stat(exitFile); err == nil {
+			if err := loadJSONFile(exitFile, &validator.Exit); err != nil {
+				return nil, fmt.Errorf("failed to load voluntary exit: %w", err)
+			}
+		}
 
 		results.Validators = append(results.Validators, validator)
 	}
@@ -214,6 +230,26 @@ func OpenResultsDir(dir string) (*ResultsDir, error) {
 	return &results, nil
 }
 
+// verifyExit checks signature of the pre-signed voluntary exit of the validator at the network of its deposit data
+func verifyExit(validator ResultsValidatorDir) error {
+	network := e2m_core.NetworkFromString(validator.DepositData[0].NetworkName)
+	if network == "" {
+		return fmt.Errorf("unsupported network of deposit data: %s", validator.DepositData[0].NetworkName)
+	}
+	forkVersion, err := crypto.ExitForkVersion(network)
+	if err != nil {
+		return err
+	}
+	pubKey, err := hex.DecodeString(validator.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode validator public key: %w", err)
+	}
+	if err := crypto.VerifyVoluntaryExit(pubKey, validator.Exit, forkVersion, network.GenesisValidatorsRoot()); err != nil {
+		return fmt.Errorf("failed to verify voluntary exit: %w", err)
+	}
+	return nil
+}
+
 func loadJSONFile(file string, v interface{}) error {
 	data, err := os.ReadFile(filepath.Clean(file))
 	if err != nil {
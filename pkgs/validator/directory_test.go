@@ -5,6 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 )
 
 func TestOpenResultsDir(t *testing.T) {
@@ -118,7 +120,7 @@ func TestValidateResultsDir(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.path, func(t *testing.T) {
-			err := ValidateResultsDir(test.path, test.validatorCount, test.ownerAddress, test.ownerNonce, test.withdrawAddress)
+			err := ValidateResultsDir(test.path, test.validatorCount, test.ownerAddress, test.ownerNonce, crypto.ETH1WithdrawalCredentials(test.withdrawAddress.Bytes()), crypto.MaxEffectiveBalanceInGwei)
 			if test.expectedErr != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), test.expectedErr)
@@ -23,7 +23,8 @@ func ValidateResults(
 	expectedValidatorCount int,
 	expectedOwnerAddress common.Address,
 	expectedOwnerNonce uint64,
-	expectedWithdrawAddress common.Address,
+	expectedWithdrawalCredentials []byte,
+	expectedAmount phase0.Gwei,
 ) error {
 	if expectedValidatorCount < 1 {
 		return fmt.Errorf("validator count is less than 1")
@@ -36,7 +37,10 @@ func ValidateResults(
 	if len(allDepositData) != expectedValidatorCount {
 		return fmt.Errorf("unexpected number of validators: %d", len(allDepositData))
 	}
-	if expectedWithdrawAddress == (common.Address{}) {
+	if len(expectedWithdrawalCredentials) != 32 {
+		return fmt.Errorf("withdrawal credentials length is not 32 bytes")
+	}
+	if prefix, withdrawAddress := crypto.ParseWithdrawalCredentials(expectedWithdrawalCredentials); prefix != crypto.BLSWithdrawalPrefixByte && common.BytesToAddress(withdrawAddress) == (common.Address{}) {
 		return fmt.Errorf("withdraw address is empty")
 	}
 	if err := checkValidatorsCorrectAtDeposits(allDepositData); err != nil {
@@ -62,7 +66,7 @@ func ValidateResults(
 		if depositData.PubKey != strings.TrimPrefix(keyshares.Payload.PublicKey, "0x") {
 			return fmt.Errorf("validator doesnt match: %s in deposit-data, %s in keyshares", depositData.PubKey, strings.TrimPrefix(keyshares.Payload.PublicKey, "0x"))
 		}
-		err := crypto.ValidateDepositDataCLI(depositData, expectedWithdrawAddress)
+		err := crypto.ValidateDepositDataCLICredentials(depositData, expectedWithdrawalCredentials, expectedAmount)
 		if err != nil {
 			return fmt.Errorf("err validating deposit data %w", err)
 		}
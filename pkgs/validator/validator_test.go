@@ -107,7 +107,7 @@ func TestDepositDataJSON(t *testing.T) {
 			err = json.Unmarshal(depositDataJson, &depositDataArray)
 			require.NoError(t, err)
 			require.Equal(t, len(depositDataArray), 1)
-			err = crypto.ValidateDepositDataCLI(depositDataArray[0], test.expectedWithdrawalCredentials)
+			err = crypto.ValidateDepositDataCLI(depositDataArray[0], crypto.ETH1WithdrawalPrefixByte, test.expectedWithdrawalCredentials, crypto.MaxEffectiveBalanceInGwei)
 			if test.expectedErr != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), test.expectedErr)
@@ -2,7 +2,6 @@ package wire
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/drand/kyber/share/dkg"
 	"go.uber.org/zap"
@@ -27,14 +26,17 @@ func (l *LogWrapper) Error(vals ...interface{}) {
 	l.Logger.Error(fmt.Sprint(vals...))
 }
 
+// Phaser signals the DKG protocol on its channel when the protocol should move to a next phase.
+// Phase must be sequential: DealPhase (start), ResponsePhase, JustifPhase and then FinishPhase.
+type Phaser interface {
+	dkg.Phaser
+	Start()
+}
+
 // NewDKGProtocol initializes and starts phases of the DKG protocol
-func NewDKGProtocol(dkgConfig *dkg.Config, b dkg.Board, logger *zap.Logger) (*dkg.Protocol, error) {
+func NewDKGProtocol(dkgConfig *dkg.Config, b dkg.Board, phaser Phaser, logger *zap.Logger) (*dkg.Protocol, error) {
 	dkgLogger := New(logger)
 	dkgConfig.Log = dkgLogger
-	// Phaser must signal on its channel when the protocol should move to a next
-	// phase. Phase must be sequential: DealPhase (start), ResponsePhase,
-	// JustifPhase and then FinishPhase.
-	phaser := dkg.NewTimePhaser(time.Second * 10)
 	ret, err := dkg.NewProtocol(
 		dkgConfig,
 		b,
@@ -1,5 +1,11 @@
 package wire
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // MakeErr creates an error message
 func MakeErr(err error) (reterr []byte) {
 	rawerr := &ErrSSZ{Error: []byte(err.Error())}
@@ -9,3 +15,63 @@ func MakeErr(err error) (reterr []byte) {
 	}
 	return reterr
 }
+
+// policyErrPrefix starts messages of ceremonies rejected by the operator policy
+const policyErrPrefix = "rejected by operator policy, rule "
+
+// Rules of the operator policy
+const (
+	PolicyRuleNetwork           = "network"
+	PolicyRuleOwner             = "owner"
+	PolicyRuleWithdrawalAddress = "withdrawal_address"
+	PolicyRuleOperators         = "operators"
+	PolicyRuleOwnerRateLimit    = "owner_rate_limit"
+	PolicyRuleInitiator         = "initiator"
+	PolicyRuleApproval          = "approval"
+)
+
+// ErrPendingApproval is returned by operators to init messages of ceremonies waiting for approval of the operator.
+// Initiator repeats the init message until the ceremony is approved.
+var ErrPendingApproval = errors.New("ceremony is pending approval of the operator")
+
+// IsPendingApproval checks that the error is caused by a ceremony waiting for approval of the operator
+func IsPendingApproval(err error) bool {
+	return err != nil && (errors.Is(err, ErrPendingApproval) || strings.Contains(err.Error(), ErrPendingApproval.Error()))
+}
+
+// PolicyError is a rejection of a ceremony by the operator policy. It's sent to initiator as a plain error message
+// and can be parsed back with ParsePolicyError.
+type PolicyError struct {
+	Rule   string // rule of the policy rejecting the ceremony
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s%s: %s", policyErrPrefix, e.Rule, e.Reason)
+}
+
+// ParsePolicyError returns the first rejection by an operator policy found at the error, nil if the error
+// isn't caused by a policy
+func ParsePolicyError(err error) *PolicyError {
+	if err == nil {
+		return nil
+	}
+	var policyErr *PolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr
+	}
+	msg := err.Error()
+	i := strings.Index(msg, policyErrPrefix)
+	if i < 0 {
+		return nil
+	}
+	msg = msg[i+len(policyErrPrefix):]
+	if end := strings.IndexByte(msg, '\n'); end >= 0 {
+		msg = msg[:end]
+	}
+	rule, reason, ok := strings.Cut(msg, ": ")
+	if !ok {
+		return nil
+	}
+	return &PolicyError{Rule: rule, Reason: reason}
+}
@@ -0,0 +1,21 @@
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicyError(t *testing.T) {
+	policyErr := &PolicyError{Rule: PolicyRuleOwner, Reason: "owner 0x0000000000000000000000000000000000000001 is not allowed"}
+	require.Equal(t, policyErr, ParsePolicyError(fmt.Errorf("init: %w", policyErr)))
+	// rejections sent by operators are plain error messages
+	parsedErr, err := ParseAsError(MakeErr(fmt.Errorf("operator 1, failed to initialize instance, err: %v", policyErr)))
+	require.NoError(t, err)
+	joined := errors.Join(fmt.Errorf("operator ID: 1, %w", parsedErr), errors.New("operator ID: 2, timeout"))
+	require.Equal(t, policyErr, ParsePolicyError(joined))
+	require.Nil(t, ParsePolicyError(errors.New("operator ID: 2, timeout")))
+	require.Nil(t, ParsePolicyError(nil))
+}
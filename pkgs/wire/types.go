@@ -13,7 +13,7 @@ type SSZMarshaller interface {
 }
 type MultipleSignedTransports struct {
 	Identifier [24]byte           `ssz-size:"24"` // this is kinda wasteful, maybe take it out of the msgs?
-	Messages   []*SignedTransport `ssz-max:"13"`  // max num of operators
+	Messages   []*SignedTransport `ssz-max:"39"`  // max num of old and new operators exchanges plus deals at resharing
 	Signature  []byte             `ssz-max:"2048"`
 }
 
@@ -36,6 +36,11 @@ const (
 	PingMessageType
 	PongMessageType
 	ResultMessageType
+	ReshareMessageType
+	BlsSignResponseType
+	MultipleKyberMessageType
+	MultipleOutputMessageType
+	SignedInitMessageType
 )
 
 func (t TransportType) String() string {
@@ -64,6 +69,16 @@ func (t TransportType) String() string {
 		return "PongMessageType"
 	case ResultMessageType:
 		return "ResultMessageType"
+	case ReshareMessageType:
+		return "ReshareMessageType"
+	case BlsSignResponseType:
+		return "BlsSignResponseType"
+	case MultipleKyberMessageType:
+		return "MultipleKyberMessageType"
+	case MultipleOutputMessageType:
+		return "MultipleOutputMessageType"
+	case SignedInitMessageType:
+		return "SignedInitMessageType"
 	default:
 		return "no type impl"
 	}
@@ -87,6 +102,11 @@ type KyberMessage struct {
 	Data []byte `ssz-max:"4096"`
 }
 
+// MultipleKyberMessages holds kyber messages of the DKG protocols of a multi-validator ceremony ordered by validator
+type MultipleKyberMessages struct {
+	Messages []*KyberMessage `ssz-max:"100"` // spec.MaxCeremonyValidators
+}
+
 type Operator struct {
 	ID     uint64
 	PubKey []byte `ssz-max:"2048"`
@@ -97,14 +117,51 @@ type Init struct {
 	Operators []*Operator `ssz-max:"13"`
 	// T is the threshold for signing
 	T uint64
-	// WithdrawalCredentials for deposit data
-	WithdrawalCredentials []byte `ssz-max:"32"`
+	// WithdrawalCredentials for deposit data: withdrawal address or BLS withdrawal public key for 0x00 prefix
+	WithdrawalCredentials []byte `ssz-max:"48"`
 	// Fork ethereum fork for signing
 	Fork [4]byte `ssz-size:"4"`
 	// Owner address
 	Owner [20]byte `ssz-size:"20"`
 	// Owner nonce
 	Nonce uint64
+	// WithdrawalPrefix is the type of withdrawal credentials for deposit data: 0x00 (BLS), 0x01 or 0x02 (compounding)
+	WithdrawalPrefix uint8
+	// Amount of the deposit in Gwei
+	Amount uint64
+	// SignExit requests operators to sign a voluntary exit of the validator with their shares
+	SignExit bool
+	// ExitEpoch is the earliest epoch the voluntary exit can be processed at
+	ExitEpoch uint64
+	// ExitValidatorIndex is the index the validator is expected to get at the beacon chain
+	ExitValidatorIndex uint64
+	// ExitForkVersion is the fork version of the voluntary exit signing domain
+	ExitForkVersion [4]byte `ssz-size:"4"`
+	// GenesisValidatorsRoot of the network for the voluntary exit signing domain
+	GenesisValidatorsRoot [32]byte `ssz-size:"32"`
+	// PhaseTimeout is the fallback timeout of DKG protocol phases in milliseconds, operators use their default if zero
+	PhaseTimeout uint64
+	// Validators is the number of validators created by the ceremony, one if zero. Owner nonce and voluntary exit
+	// validator index are incremented by one for each next validator.
+	Validators uint64
+	// ThresholdTolerant allows operators to start DKG without operators excluded by initiator after the init phase
+	ThresholdTolerant bool
+}
+
+// SignedInit is an init message authorized by the owner
+type SignedInit struct {
+	Init Init
+	// Signature is an ECDSA signature over hash tree root of InitSigningRoot of the init message
+	Signature []byte `ssz-max:"1536"` // 64 * 24
+}
+
+// InitSigningRoot binds the init message signed by the owner to the ceremony ID,
+// so the signature can't be replayed to start another ceremony with the same init message
+type InitSigningRoot struct {
+	// ID of the ceremony
+	ID [24]byte `ssz-size:"24"`
+	// InitRoot is hash tree root of the init message
+	InitRoot [32]byte `ssz-size:"32"`
 }
 
 type Reshare struct {
@@ -130,6 +187,81 @@ type SignedReshare struct {
 	Signature []byte `ssz-max:"1536"` // 64 * 24
 }
 
+// ReshareMessage is sent by initiator to old and new operators to start a resharing ceremony
+type ReshareMessage struct {
+	SignedReshare *SignedReshare
+	// Proofs of the previous ceremony, ordered as old operators at reshare message
+	Proofs []*SignedProof `ssz-max:"13"`
+	// WithdrawalCredentials for deposit data: withdrawal address or BLS withdrawal public key for 0x00 prefix
+	WithdrawalCredentials []byte `ssz-max:"48"`
+	// Fork ethereum fork for signing
+	Fork [4]byte `ssz-size:"4"`
+	// WithdrawalPrefix is the type of withdrawal credentials for deposit data: 0x00 (BLS), 0x01 or 0x02 (compounding)
+	WithdrawalPrefix uint8
+	// Amount of the deposit in Gwei
+	Amount uint64
+	// PhaseTimeout is the fallback timeout of DKG protocol phases in milliseconds, operators use their default if zero
+	PhaseTimeout uint64
+}
+
+// BeaconMessageType is a type of beacon chain message signed with key shares of a validator
+type BeaconMessageType uint64
+
+const (
+	VoluntaryExitBeaconMessage BeaconMessageType = iota
+	BLSToExecutionChangeBeaconMessage
+)
+
+func (t BeaconMessageType) String() string {
+	switch t {
+	case VoluntaryExitBeaconMessage:
+		return "VoluntaryExit"
+	case BLSToExecutionChangeBeaconMessage:
+		return "BLSToExecutionChange"
+	default:
+		return "no type impl"
+	}
+}
+
+// BlsSignRequest is a request of the validator owner to sign a beacon message with key shares kept by operators
+type BlsSignRequest struct {
+	// ValidatorPubKey public key corresponding to the shared private key
+	ValidatorPubKey []byte `ssz-size:"48"`
+	// Operators holding key shares of the validator
+	Operators []*Operator `ssz-max:"13"`
+	// Owner address
+	Owner [20]byte `ssz-size:"20"`
+	// MessageType is the type of the beacon message
+	MessageType BeaconMessageType
+	// Message is the SSZ encoded beacon message to sign
+	Message []byte `ssz-max:"128"`
+	// ForkVersion of the signing domain
+	ForkVersion [4]byte `ssz-size:"4"`
+	// GenesisValidatorsRoot of the network for the signing domain
+	GenesisValidatorsRoot [32]byte `ssz-size:"32"`
+}
+
+type SignedBlsSignRequest struct {
+	Request BlsSignRequest
+	// Signature is an ECDSA signature over hash tree root of the request
+	Signature []byte `ssz-max:"1536"` // 64 * 24
+}
+
+// BlsSignMessage is sent by initiator to operators to sign a beacon message with their key shares
+type BlsSignMessage struct {
+	SignedRequest *SignedBlsSignRequest
+	// Proofs of the ceremony which created the key shares, ordered as operators at the request
+	Proofs []*SignedProof `ssz-max:"13"`
+}
+
+// BlsSignResponse is a partial signature of the beacon message by an operator
+type BlsSignResponse struct {
+	// Operator ID
+	OperatorID uint64
+	// PartialSignature of the beacon message signing root
+	PartialSignature []byte `ssz-size:"96"`
+}
+
 // Result is the last message in every DKG which marks a specific node's end of process
 type Result struct {
 	// Operator ID
@@ -142,6 +274,13 @@ type Result struct {
 	OwnerNoncePartialSignature []byte `ssz-size:"96"`
 	// Signed proof for the ceremony
 	SignedProof SignedProof
+	// Partial signature of the voluntary exit, empty if it isn't requested
+	ExitPartialSignature []byte `ssz-max:"96"`
+}
+
+// MultipleResults holds results of the DKG protocols of a multi-validator ceremony ordered by validator
+type MultipleResults struct {
+	Results []*Result `ssz-max:"100"` // spec.MaxCeremonyValidators
 }
 
 // Proof for a DKG ceremony
@@ -178,16 +317,22 @@ type Ping struct {
 type Pong struct {
 	ID     uint64
 	PubKey []byte `ssz-max:"2048"`
+	// Range of protocol versions supported by the operator
+	MinVersion []byte `ssz-max:"64"`
+	MaxVersion []byte `ssz-max:"64"`
+	// Features supported by the operator
+	Features [][]byte `ssz-max:"32,64"`
 }
 
 type ResultData struct {
 	// Operators involved in the DKG
 	Operators []*Operator `ssz-max:"13"`
 	// Initiator public key
-	Identifier    [24]byte `ssz-size:"24"`
-	DepositData   []byte   `ssz-max:"8192"`
-	KeysharesData []byte   `ssz-max:"32768"`
-	Proofs        []byte   `ssz-max:"32768"`
+	Identifier [24]byte `ssz-size:"24"`
+	// JSON encoded deposit data, key shares and proofs of each validator created by the ceremony
+	DepositData   []byte `ssz-max:"262144"`
+	KeysharesData []byte `ssz-max:"4194304"`
+	Proofs        []byte `ssz-max:"2097152"`
 }
 
 // DepositDataCLI  is a deposit structure from the eth2 deposit CLI (https://github.com/ethereum/staking-deposit-cli).
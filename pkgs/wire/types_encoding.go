@@ -1,5 +1,5 @@
 // Code generated by fastssz. DO NOT EDIT.
-// Hash: 8561b624d417b4c2886869ef8647e0899e33e0f0810b9ae8f9d94c5e2014589e
+// Hash: 4285eb63c0ffed3cf6ca22b3e607aed2e67ff42c60afe26bd21338ca853dfa1f
 // Version: 0.1.3
 package wire
 
@@ -32,8 +32,8 @@ func (m *MultipleSignedTransports) MarshalSSZTo(buf []byte) (dst []byte, err err
 	offset += len(m.Signature)
 
 	// Field (1) 'Messages'
-	if size := len(m.Messages); size > 13 {
-		err = ssz.ErrListTooBigFn("MultipleSignedTransports.Messages", size, 13)
+	if size := len(m.Messages); size > 39 {
+		err = ssz.ErrListTooBigFn("MultipleSignedTransports.Messages", size, 39)
 		return
 	}
 	{
@@ -90,7 +90,7 @@ func (m *MultipleSignedTransports) UnmarshalSSZ(buf []byte) error {
 	// Field (1) 'Messages'
 	{
 		buf = tail[o1:o2]
-		num, err := ssz.DecodeDynamicLength(buf, 13)
+		num, err := ssz.DecodeDynamicLength(buf, 39)
 		if err != nil {
 			return err
 		}
@@ -155,7 +155,7 @@ func (m *MultipleSignedTransports) HashTreeRootWith(hh ssz.HashWalker) (err erro
 	{
 		subIndx := hh.Index()
 		num := uint64(len(m.Messages))
-		if num > 13 {
+		if num > 39 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
@@ -164,7 +164,7 @@ func (m *MultipleSignedTransports) HashTreeRootWith(hh ssz.HashWalker) (err erro
 				return
 			}
 		}
-		hh.MerkleizeWithMixin(subIndx, num, 13)
+		hh.MerkleizeWithMixin(subIndx, num, 39)
 	}
 
 	// Field (2) 'Signature'
@@ -732,6 +732,135 @@ func (k *KyberMessage) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(k)
 }
 
+// MarshalSSZ ssz marshals the MultipleKyberMessages object
+func (m *MultipleKyberMessages) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MultipleKyberMessages object to a target array
+func (m *MultipleKyberMessages) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Messages'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(m.Messages); ii++ {
+		offset += 4
+		offset += m.Messages[ii].SizeSSZ()
+	}
+
+	// Field (0) 'Messages'
+	if size := len(m.Messages); size > 100 {
+		err = ssz.ErrListTooBigFn("MultipleKyberMessages.Messages", size, 100)
+		return
+	}
+	{
+		offset = 4 * len(m.Messages)
+		for ii := 0; ii < len(m.Messages); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += m.Messages[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(m.Messages); ii++ {
+		if dst, err = m.Messages[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MultipleKyberMessages object
+func (m *MultipleKyberMessages) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Messages'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Messages'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DecodeDynamicLength(buf, 100)
+		if err != nil {
+			return err
+		}
+		m.Messages = make([]*KyberMessage, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if m.Messages[indx] == nil {
+				m.Messages[indx] = new(KyberMessage)
+			}
+			if err = m.Messages[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MultipleKyberMessages object
+func (m *MultipleKyberMessages) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Messages'
+	for ii := 0; ii < len(m.Messages); ii++ {
+		size += 4
+		size += m.Messages[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MultipleKyberMessages object
+func (m *MultipleKyberMessages) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MultipleKyberMessages object with a hasher
+func (m *MultipleKyberMessages) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Messages'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.Messages))
+		if num > 100 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range m.Messages {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 100)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the MultipleKyberMessages object
+func (m *MultipleKyberMessages) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(m)
+}
+
 // MarshalSSZ ssz marshals the Operator object
 func (o *Operator) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(o)
@@ -847,7 +976,7 @@ func (i *Init) MarshalSSZ() ([]byte, error) {
 // MarshalSSZTo ssz marshals the Init object to a target array
 func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(48)
+	offset := int(127)
 
 	// Offset (0) 'Operators'
 	dst = ssz.WriteOffset(dst, offset)
@@ -872,6 +1001,36 @@ func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	// Field (5) 'Nonce'
 	dst = ssz.MarshalUint64(dst, i.Nonce)
 
+	// Field (6) 'WithdrawalPrefix'
+	dst = ssz.MarshalUint8(dst, i.WithdrawalPrefix)
+
+	// Field (7) 'Amount'
+	dst = ssz.MarshalUint64(dst, i.Amount)
+
+	// Field (8) 'SignExit'
+	dst = ssz.MarshalBool(dst, i.SignExit)
+
+	// Field (9) 'ExitEpoch'
+	dst = ssz.MarshalUint64(dst, i.ExitEpoch)
+
+	// Field (10) 'ExitValidatorIndex'
+	dst = ssz.MarshalUint64(dst, i.ExitValidatorIndex)
+
+	// Field (11) 'ExitForkVersion'
+	dst = append(dst, i.ExitForkVersion[:]...)
+
+	// Field (12) 'GenesisValidatorsRoot'
+	dst = append(dst, i.GenesisValidatorsRoot[:]...)
+
+	// Field (13) 'PhaseTimeout'
+	dst = ssz.MarshalUint64(dst, i.PhaseTimeout)
+
+	// Field (14) 'Validators'
+	dst = ssz.MarshalUint64(dst, i.Validators)
+
+	// Field (15) 'ThresholdTolerant'
+	dst = ssz.MarshalBool(dst, i.ThresholdTolerant)
+
 	// Field (0) 'Operators'
 	if size := len(i.Operators); size > 13 {
 		err = ssz.ErrListTooBigFn("Init.Operators", size, 13)
@@ -891,8 +1050,8 @@ func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	}
 
 	// Field (2) 'WithdrawalCredentials'
-	if size := len(i.WithdrawalCredentials); size > 32 {
-		err = ssz.ErrBytesLengthFn("Init.WithdrawalCredentials", size, 32)
+	if size := len(i.WithdrawalCredentials); size > 48 {
+		err = ssz.ErrBytesLengthFn("Init.WithdrawalCredentials", size, 48)
 		return
 	}
 	dst = append(dst, i.WithdrawalCredentials...)
@@ -904,7 +1063,7 @@ func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (i *Init) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 48 {
+	if size < 127 {
 		return ssz.ErrSize
 	}
 
@@ -916,7 +1075,7 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 48 {
+	if o0 < 127 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
@@ -937,6 +1096,36 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 	// Field (5) 'Nonce'
 	i.Nonce = ssz.UnmarshallUint64(buf[40:48])
 
+	// Field (6) 'WithdrawalPrefix'
+	i.WithdrawalPrefix = ssz.UnmarshallUint8(buf[48:49])
+
+	// Field (7) 'Amount'
+	i.Amount = ssz.UnmarshallUint64(buf[49:57])
+
+	// Field (8) 'SignExit'
+	i.SignExit = ssz.UnmarshalBool(buf[57:58])
+
+	// Field (9) 'ExitEpoch'
+	i.ExitEpoch = ssz.UnmarshallUint64(buf[58:66])
+
+	// Field (10) 'ExitValidatorIndex'
+	i.ExitValidatorIndex = ssz.UnmarshallUint64(buf[66:74])
+
+	// Field (11) 'ExitForkVersion'
+	copy(i.ExitForkVersion[:], buf[74:78])
+
+	// Field (12) 'GenesisValidatorsRoot'
+	copy(i.GenesisValidatorsRoot[:], buf[78:110])
+
+	// Field (13) 'PhaseTimeout'
+	i.PhaseTimeout = ssz.UnmarshallUint64(buf[110:118])
+
+	// Field (14) 'Validators'
+	i.Validators = ssz.UnmarshallUint64(buf[118:126])
+
+	// Field (15) 'ThresholdTolerant'
+	i.ThresholdTolerant = ssz.UnmarshalBool(buf[126:127])
+
 	// Field (0) 'Operators'
 	{
 		buf = tail[o0:o2]
@@ -962,7 +1151,7 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 	// Field (2) 'WithdrawalCredentials'
 	{
 		buf = tail[o2:]
-		if len(buf) > 32 {
+		if len(buf) > 48 {
 			return ssz.ErrBytesLength
 		}
 		if cap(i.WithdrawalCredentials) == 0 {
@@ -975,7 +1164,7 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 
 // SizeSSZ returns the ssz encoded size in bytes for the Init object
 func (i *Init) SizeSSZ() (size int) {
-	size = 48
+	size = 127
 
 	// Field (0) 'Operators'
 	for ii := 0; ii < len(i.Operators); ii++ {
@@ -1021,12 +1210,12 @@ func (i *Init) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	{
 		elemIndx := hh.Index()
 		byteLen := uint64(len(i.WithdrawalCredentials))
-		if byteLen > 32 {
+		if byteLen > 48 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
 		hh.Append(i.WithdrawalCredentials)
-		hh.MerkleizeWithMixin(elemIndx, byteLen, (32+31)/32)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (48+31)/32)
 	}
 
 	// Field (3) 'Fork'
@@ -1038,6 +1227,36 @@ func (i *Init) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	// Field (5) 'Nonce'
 	hh.PutUint64(i.Nonce)
 
+	// Field (6) 'WithdrawalPrefix'
+	hh.PutUint8(i.WithdrawalPrefix)
+
+	// Field (7) 'Amount'
+	hh.PutUint64(i.Amount)
+
+	// Field (8) 'SignExit'
+	hh.PutBool(i.SignExit)
+
+	// Field (9) 'ExitEpoch'
+	hh.PutUint64(i.ExitEpoch)
+
+	// Field (10) 'ExitValidatorIndex'
+	hh.PutUint64(i.ExitValidatorIndex)
+
+	// Field (11) 'ExitForkVersion'
+	hh.PutBytes(i.ExitForkVersion[:])
+
+	// Field (12) 'GenesisValidatorsRoot'
+	hh.PutBytes(i.GenesisValidatorsRoot[:])
+
+	// Field (13) 'PhaseTimeout'
+	hh.PutUint64(i.PhaseTimeout)
+
+	// Field (14) 'Validators'
+	hh.PutUint64(i.Validators)
+
+	// Field (15) 'ThresholdTolerant'
+	hh.PutBool(i.ThresholdTolerant)
+
 	hh.Merkleize(indx)
 	return
 }
@@ -1047,132 +1266,325 @@ func (i *Init) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(i)
 }
 
-// MarshalSSZ ssz marshals the Reshare object
-func (r *Reshare) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(r)
+// MarshalSSZ ssz marshals the SignedInit object
+func (s *SignedInit) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
 }
 
-// MarshalSSZTo ssz marshals the Reshare object to a target array
-func (r *Reshare) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the SignedInit object to a target array
+func (s *SignedInit) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(100)
-
-	// Field (0) 'ValidatorPubKey'
-	if size := len(r.ValidatorPubKey); size != 48 {
-		err = ssz.ErrBytesLengthFn("Reshare.ValidatorPubKey", size, 48)
-		return
-	}
-	dst = append(dst, r.ValidatorPubKey...)
+	offset := int(8)
 
-	// Offset (1) 'OldOperators'
+	// Offset (0) 'Init'
 	dst = ssz.WriteOffset(dst, offset)
-	for ii := 0; ii < len(r.OldOperators); ii++ {
-		offset += 4
-		offset += r.OldOperators[ii].SizeSSZ()
-	}
+	offset += s.Init.SizeSSZ()
 
-	// Offset (2) 'NewOperators'
+	// Offset (1) 'Signature'
 	dst = ssz.WriteOffset(dst, offset)
-	for ii := 0; ii < len(r.NewOperators); ii++ {
-		offset += 4
-		offset += r.NewOperators[ii].SizeSSZ()
-	}
-
-	// Field (3) 'OldT'
-	dst = ssz.MarshalUint64(dst, r.OldT)
-
-	// Field (4) 'NewT'
-	dst = ssz.MarshalUint64(dst, r.NewT)
-
-	// Field (5) 'Owner'
-	dst = append(dst, r.Owner[:]...)
-
-	// Field (6) 'Nonce'
-	dst = ssz.MarshalUint64(dst, r.Nonce)
+	offset += len(s.Signature)
 
-	// Field (1) 'OldOperators'
-	if size := len(r.OldOperators); size > 13 {
-		err = ssz.ErrListTooBigFn("Reshare.OldOperators", size, 13)
+	// Field (0) 'Init'
+	if dst, err = s.Init.MarshalSSZTo(dst); err != nil {
 		return
 	}
-	{
-		offset = 4 * len(r.OldOperators)
-		for ii := 0; ii < len(r.OldOperators); ii++ {
-			dst = ssz.WriteOffset(dst, offset)
-			offset += r.OldOperators[ii].SizeSSZ()
-		}
-	}
-	for ii := 0; ii < len(r.OldOperators); ii++ {
-		if dst, err = r.OldOperators[ii].MarshalSSZTo(dst); err != nil {
-			return
-		}
-	}
 
-	// Field (2) 'NewOperators'
-	if size := len(r.NewOperators); size > 13 {
-		err = ssz.ErrListTooBigFn("Reshare.NewOperators", size, 13)
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size > 1536 {
+		err = ssz.ErrBytesLengthFn("SignedInit.Signature", size, 1536)
 		return
 	}
-	{
-		offset = 4 * len(r.NewOperators)
-		for ii := 0; ii < len(r.NewOperators); ii++ {
-			dst = ssz.WriteOffset(dst, offset)
-			offset += r.NewOperators[ii].SizeSSZ()
-		}
-	}
-	for ii := 0; ii < len(r.NewOperators); ii++ {
-		if dst, err = r.NewOperators[ii].MarshalSSZTo(dst); err != nil {
-			return
-		}
-	}
+	dst = append(dst, s.Signature...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the Reshare object
-func (r *Reshare) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the SignedInit object
+func (s *SignedInit) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 100 {
+	if size < 8 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o1, o2 uint64
-
-	// Field (0) 'ValidatorPubKey'
-	if cap(r.ValidatorPubKey) == 0 {
-		r.ValidatorPubKey = make([]byte, 0, len(buf[0:48]))
-	}
-	r.ValidatorPubKey = append(r.ValidatorPubKey, buf[0:48]...)
+	var o0, o1 uint64
 
-	// Offset (1) 'OldOperators'
-	if o1 = ssz.ReadOffset(buf[48:52]); o1 > size {
+	// Offset (0) 'Init'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
 		return ssz.ErrOffset
 	}
 
-	if o1 < 100 {
+	if o0 < 8 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Offset (2) 'NewOperators'
-	if o2 = ssz.ReadOffset(buf[52:56]); o2 > size || o1 > o2 {
+	// Offset (1) 'Signature'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
 		return ssz.ErrOffset
 	}
 
-	// Field (3) 'OldT'
-	r.OldT = ssz.UnmarshallUint64(buf[56:64])
+	// Field (0) 'Init'
+	{
+		buf = tail[o0:o1]
+		if err = s.Init.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
 
-	// Field (4) 'NewT'
-	r.NewT = ssz.UnmarshallUint64(buf[64:72])
+	// Field (1) 'Signature'
+	{
+		buf = tail[o1:]
+		if len(buf) > 1536 {
+			return ssz.ErrBytesLength
+		}
+		if cap(s.Signature) == 0 {
+			s.Signature = make([]byte, 0, len(buf))
+		}
+		s.Signature = append(s.Signature, buf...)
+	}
+	return err
+}
 
-	// Field (5) 'Owner'
-	copy(r.Owner[:], buf[72:92])
+// SizeSSZ returns the ssz encoded size in bytes for the SignedInit object
+func (s *SignedInit) SizeSSZ() (size int) {
+	size = 8
 
-	// Field (6) 'Nonce'
-	r.Nonce = ssz.UnmarshallUint64(buf[92:100])
+	// Field (0) 'Init'
+	size += s.Init.SizeSSZ()
 
-	// Field (1) 'OldOperators'
+	// Field (1) 'Signature'
+	size += len(s.Signature)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedInit object
+func (s *SignedInit) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedInit object with a hasher
+func (s *SignedInit) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Init'
+	if err = s.Init.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(s.Signature))
+		if byteLen > 1536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(s.Signature)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1536+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the SignedInit object
+func (s *SignedInit) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(s)
+}
+
+// MarshalSSZ ssz marshals the InitSigningRoot object
+func (i *InitSigningRoot) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(i)
+}
+
+// MarshalSSZTo ssz marshals the InitSigningRoot object to a target array
+func (i *InitSigningRoot) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'ID'
+	dst = append(dst, i.ID[:]...)
+
+	// Field (1) 'InitRoot'
+	dst = append(dst, i.InitRoot[:]...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the InitSigningRoot object
+func (i *InitSigningRoot) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 56 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'ID'
+	copy(i.ID[:], buf[0:24])
+
+	// Field (1) 'InitRoot'
+	copy(i.InitRoot[:], buf[24:56])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the InitSigningRoot object
+func (i *InitSigningRoot) SizeSSZ() (size int) {
+	size = 56
+	return
+}
+
+// HashTreeRoot ssz hashes the InitSigningRoot object
+func (i *InitSigningRoot) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(i)
+}
+
+// HashTreeRootWith ssz hashes the InitSigningRoot object with a hasher
+func (i *InitSigningRoot) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ID'
+	hh.PutBytes(i.ID[:])
+
+	// Field (1) 'InitRoot'
+	hh.PutBytes(i.InitRoot[:])
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the InitSigningRoot object
+func (i *InitSigningRoot) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(i)
+}
+
+// MarshalSSZ ssz marshals the Reshare object
+func (r *Reshare) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
+}
+
+// MarshalSSZTo ssz marshals the Reshare object to a target array
+func (r *Reshare) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(100)
+
+	// Field (0) 'ValidatorPubKey'
+	if size := len(r.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Reshare.ValidatorPubKey", size, 48)
+		return
+	}
+	dst = append(dst, r.ValidatorPubKey...)
+
+	// Offset (1) 'OldOperators'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(r.OldOperators); ii++ {
+		offset += 4
+		offset += r.OldOperators[ii].SizeSSZ()
+	}
+
+	// Offset (2) 'NewOperators'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(r.NewOperators); ii++ {
+		offset += 4
+		offset += r.NewOperators[ii].SizeSSZ()
+	}
+
+	// Field (3) 'OldT'
+	dst = ssz.MarshalUint64(dst, r.OldT)
+
+	// Field (4) 'NewT'
+	dst = ssz.MarshalUint64(dst, r.NewT)
+
+	// Field (5) 'Owner'
+	dst = append(dst, r.Owner[:]...)
+
+	// Field (6) 'Nonce'
+	dst = ssz.MarshalUint64(dst, r.Nonce)
+
+	// Field (1) 'OldOperators'
+	if size := len(r.OldOperators); size > 13 {
+		err = ssz.ErrListTooBigFn("Reshare.OldOperators", size, 13)
+		return
+	}
+	{
+		offset = 4 * len(r.OldOperators)
+		for ii := 0; ii < len(r.OldOperators); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += r.OldOperators[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(r.OldOperators); ii++ {
+		if dst, err = r.OldOperators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (2) 'NewOperators'
+	if size := len(r.NewOperators); size > 13 {
+		err = ssz.ErrListTooBigFn("Reshare.NewOperators", size, 13)
+		return
+	}
+	{
+		offset = 4 * len(r.NewOperators)
+		for ii := 0; ii < len(r.NewOperators); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += r.NewOperators[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(r.NewOperators); ii++ {
+		if dst, err = r.NewOperators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Reshare object
+func (r *Reshare) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 100 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o2 uint64
+
+	// Field (0) 'ValidatorPubKey'
+	if cap(r.ValidatorPubKey) == 0 {
+		r.ValidatorPubKey = make([]byte, 0, len(buf[0:48]))
+	}
+	r.ValidatorPubKey = append(r.ValidatorPubKey, buf[0:48]...)
+
+	// Offset (1) 'OldOperators'
+	if o1 = ssz.ReadOffset(buf[48:52]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 100 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'NewOperators'
+	if o2 = ssz.ReadOffset(buf[52:56]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'OldT'
+	r.OldT = ssz.UnmarshallUint64(buf[56:64])
+
+	// Field (4) 'NewT'
+	r.NewT = ssz.UnmarshallUint64(buf[64:72])
+
+	// Field (5) 'Owner'
+	copy(r.Owner[:], buf[72:92])
+
+	// Field (6) 'Nonce'
+	r.Nonce = ssz.UnmarshallUint64(buf[92:100])
+
+	// Field (1) 'OldOperators'
 	{
 		buf = tail[o1:o2]
 		num, err := ssz.DecodeDynamicLength(buf, 13)
@@ -1383,55 +1795,902 @@ func (s *SignedReshare) UnmarshalSSZ(buf []byte) error {
 		}
 		s.Signature = append(s.Signature, buf...)
 	}
-	return err
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SignedReshare object
+func (s *SignedReshare) SizeSSZ() (size int) {
+	size = 8
+
+	// Field (0) 'Reshare'
+	size += s.Reshare.SizeSSZ()
+
+	// Field (1) 'Signature'
+	size += len(s.Signature)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedReshare object
+func (s *SignedReshare) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedReshare object with a hasher
+func (s *SignedReshare) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Reshare'
+	if err = s.Reshare.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(s.Signature))
+		if byteLen > 1536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(s.Signature)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1536+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the SignedReshare object
+func (s *SignedReshare) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(s)
+}
+
+// MarshalSSZ ssz marshals the ReshareMessage object
+func (r *ReshareMessage) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
+}
+
+// MarshalSSZTo ssz marshals the ReshareMessage object to a target array
+func (r *ReshareMessage) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(33)
+
+	// Offset (0) 'SignedReshare'
+	dst = ssz.WriteOffset(dst, offset)
+	if r.SignedReshare == nil {
+		r.SignedReshare = new(SignedReshare)
+	}
+	offset += r.SignedReshare.SizeSSZ()
+
+	// Offset (1) 'Proofs'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(r.Proofs); ii++ {
+		offset += 4
+		offset += r.Proofs[ii].SizeSSZ()
+	}
+
+	// Offset (2) 'WithdrawalCredentials'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.WithdrawalCredentials)
+
+	// Field (3) 'Fork'
+	dst = append(dst, r.Fork[:]...)
+
+	// Field (4) 'WithdrawalPrefix'
+	dst = ssz.MarshalUint8(dst, r.WithdrawalPrefix)
+
+	// Field (5) 'Amount'
+	dst = ssz.MarshalUint64(dst, r.Amount)
+
+	// Field (6) 'PhaseTimeout'
+	dst = ssz.MarshalUint64(dst, r.PhaseTimeout)
+
+	// Field (0) 'SignedReshare'
+	if dst, err = r.SignedReshare.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'Proofs'
+	if size := len(r.Proofs); size > 13 {
+		err = ssz.ErrListTooBigFn("ReshareMessage.Proofs", size, 13)
+		return
+	}
+	{
+		offset = 4 * len(r.Proofs)
+		for ii := 0; ii < len(r.Proofs); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += r.Proofs[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(r.Proofs); ii++ {
+		if dst, err = r.Proofs[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (2) 'WithdrawalCredentials'
+	if size := len(r.WithdrawalCredentials); size > 48 {
+		err = ssz.ErrBytesLengthFn("ReshareMessage.WithdrawalCredentials", size, 48)
+		return
+	}
+	dst = append(dst, r.WithdrawalCredentials...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the ReshareMessage object
+func (r *ReshareMessage) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 33 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1, o2 uint64
+
+	// Offset (0) 'SignedReshare'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 33 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'Proofs'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (2) 'WithdrawalCredentials'
+	if o2 = ssz.ReadOffset(buf[8:12]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'Fork'
+	copy(r.Fork[:], buf[12:16])
+
+	// Field (4) 'WithdrawalPrefix'
+	r.WithdrawalPrefix = ssz.UnmarshallUint8(buf[16:17])
+
+	// Field (5) 'Amount'
+	r.Amount = ssz.UnmarshallUint64(buf[17:25])
+
+	// Field (6) 'PhaseTimeout'
+	r.PhaseTimeout = ssz.UnmarshallUint64(buf[25:33])
+
+	// Field (0) 'SignedReshare'
+	{
+		buf = tail[o0:o1]
+		if r.SignedReshare == nil {
+			r.SignedReshare = new(SignedReshare)
+		}
+		if err = r.SignedReshare.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (1) 'Proofs'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DecodeDynamicLength(buf, 13)
+		if err != nil {
+			return err
+		}
+		r.Proofs = make([]*SignedProof, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if r.Proofs[indx] == nil {
+				r.Proofs[indx] = new(SignedProof)
+			}
+			if err = r.Proofs[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (2) 'WithdrawalCredentials'
+	{
+		buf = tail[o2:]
+		if len(buf) > 48 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.WithdrawalCredentials) == 0 {
+			r.WithdrawalCredentials = make([]byte, 0, len(buf))
+		}
+		r.WithdrawalCredentials = append(r.WithdrawalCredentials, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ReshareMessage object
+func (r *ReshareMessage) SizeSSZ() (size int) {
+	size = 33
+
+	// Field (0) 'SignedReshare'
+	if r.SignedReshare == nil {
+		r.SignedReshare = new(SignedReshare)
+	}
+	size += r.SignedReshare.SizeSSZ()
+
+	// Field (1) 'Proofs'
+	for ii := 0; ii < len(r.Proofs); ii++ {
+		size += 4
+		size += r.Proofs[ii].SizeSSZ()
+	}
+
+	// Field (2) 'WithdrawalCredentials'
+	size += len(r.WithdrawalCredentials)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the ReshareMessage object
+func (r *ReshareMessage) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(r)
+}
+
+// HashTreeRootWith ssz hashes the ReshareMessage object with a hasher
+func (r *ReshareMessage) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'SignedReshare'
+	if err = r.SignedReshare.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Proofs'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(r.Proofs))
+		if num > 13 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range r.Proofs {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 13)
+	}
+
+	// Field (2) 'WithdrawalCredentials'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.WithdrawalCredentials))
+		if byteLen > 48 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(r.WithdrawalCredentials)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (48+31)/32)
+	}
+
+	// Field (3) 'Fork'
+	hh.PutBytes(r.Fork[:])
+
+	// Field (4) 'WithdrawalPrefix'
+	hh.PutUint8(r.WithdrawalPrefix)
+
+	// Field (5) 'Amount'
+	hh.PutUint64(r.Amount)
+
+	// Field (6) 'PhaseTimeout'
+	hh.PutUint64(r.PhaseTimeout)
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the ReshareMessage object
+func (r *ReshareMessage) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(r)
+}
+
+// MarshalSSZ ssz marshals the BlsSignRequest object
+func (b *BlsSignRequest) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlsSignRequest object to a target array
+func (b *BlsSignRequest) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(120)
+
+	// Field (0) 'ValidatorPubKey'
+	if size := len(b.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("BlsSignRequest.ValidatorPubKey", size, 48)
+		return
+	}
+	dst = append(dst, b.ValidatorPubKey...)
+
+	// Offset (1) 'Operators'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Operators); ii++ {
+		offset += 4
+		offset += b.Operators[ii].SizeSSZ()
+	}
+
+	// Field (2) 'Owner'
+	dst = append(dst, b.Owner[:]...)
+
+	// Field (3) 'MessageType'
+	dst = ssz.MarshalUint64(dst, uint64(b.MessageType))
+
+	// Offset (4) 'Message'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(b.Message)
+
+	// Field (5) 'ForkVersion'
+	dst = append(dst, b.ForkVersion[:]...)
+
+	// Field (6) 'GenesisValidatorsRoot'
+	dst = append(dst, b.GenesisValidatorsRoot[:]...)
+
+	// Field (1) 'Operators'
+	if size := len(b.Operators); size > 13 {
+		err = ssz.ErrListTooBigFn("BlsSignRequest.Operators", size, 13)
+		return
+	}
+	{
+		offset = 4 * len(b.Operators)
+		for ii := 0; ii < len(b.Operators); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.Operators[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.Operators); ii++ {
+		if dst, err = b.Operators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (4) 'Message'
+	if size := len(b.Message); size > 128 {
+		err = ssz.ErrBytesLengthFn("BlsSignRequest.Message", size, 128)
+		return
+	}
+	dst = append(dst, b.Message...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BlsSignRequest object
+func (b *BlsSignRequest) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 120 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o4 uint64
+
+	// Field (0) 'ValidatorPubKey'
+	if cap(b.ValidatorPubKey) == 0 {
+		b.ValidatorPubKey = make([]byte, 0, len(buf[0:48]))
+	}
+	b.ValidatorPubKey = append(b.ValidatorPubKey, buf[0:48]...)
+
+	// Offset (1) 'Operators'
+	if o1 = ssz.ReadOffset(buf[48:52]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 120 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'Owner'
+	copy(b.Owner[:], buf[52:72])
+
+	// Field (3) 'MessageType'
+	b.MessageType = BeaconMessageType(ssz.UnmarshallUint64(buf[72:80]))
+
+	// Offset (4) 'Message'
+	if o4 = ssz.ReadOffset(buf[80:84]); o4 > size || o1 > o4 {
+		return ssz.ErrOffset
+	}
+
+	// Field (5) 'ForkVersion'
+	copy(b.ForkVersion[:], buf[84:88])
+
+	// Field (6) 'GenesisValidatorsRoot'
+	copy(b.GenesisValidatorsRoot[:], buf[88:120])
+
+	// Field (1) 'Operators'
+	{
+		buf = tail[o1:o4]
+		num, err := ssz.DecodeDynamicLength(buf, 13)
+		if err != nil {
+			return err
+		}
+		b.Operators = make([]*Operator, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.Operators[indx] == nil {
+				b.Operators[indx] = new(Operator)
+			}
+			if err = b.Operators[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (4) 'Message'
+	{
+		buf = tail[o4:]
+		if len(buf) > 128 {
+			return ssz.ErrBytesLength
+		}
+		if cap(b.Message) == 0 {
+			b.Message = make([]byte, 0, len(buf))
+		}
+		b.Message = append(b.Message, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlsSignRequest object
+func (b *BlsSignRequest) SizeSSZ() (size int) {
+	size = 120
+
+	// Field (1) 'Operators'
+	for ii := 0; ii < len(b.Operators); ii++ {
+		size += 4
+		size += b.Operators[ii].SizeSSZ()
+	}
+
+	// Field (4) 'Message'
+	size += len(b.Message)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BlsSignRequest object
+func (b *BlsSignRequest) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlsSignRequest object with a hasher
+func (b *BlsSignRequest) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ValidatorPubKey'
+	if size := len(b.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("BlsSignRequest.ValidatorPubKey", size, 48)
+		return
+	}
+	hh.PutBytes(b.ValidatorPubKey)
+
+	// Field (1) 'Operators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Operators))
+		if num > 13 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Operators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 13)
+	}
+
+	// Field (2) 'Owner'
+	hh.PutBytes(b.Owner[:])
+
+	// Field (3) 'MessageType'
+	hh.PutUint64(uint64(b.MessageType))
+
+	// Field (4) 'Message'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(b.Message))
+		if byteLen > 128 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(b.Message)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (128+31)/32)
+	}
+
+	// Field (5) 'ForkVersion'
+	hh.PutBytes(b.ForkVersion[:])
+
+	// Field (6) 'GenesisValidatorsRoot'
+	hh.PutBytes(b.GenesisValidatorsRoot[:])
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the BlsSignRequest object
+func (b *BlsSignRequest) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(b)
+}
+
+// MarshalSSZ ssz marshals the SignedBlsSignRequest object
+func (s *SignedBlsSignRequest) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SignedBlsSignRequest object to a target array
+func (s *SignedBlsSignRequest) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(8)
+
+	// Offset (0) 'Request'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += s.Request.SizeSSZ()
+
+	// Offset (1) 'Signature'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Signature)
+
+	// Field (0) 'Request'
+	if dst, err = s.Request.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size > 1536 {
+		err = ssz.ErrBytesLengthFn("SignedBlsSignRequest.Signature", size, 1536)
+		return
+	}
+	dst = append(dst, s.Signature...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SignedBlsSignRequest object
+func (s *SignedBlsSignRequest) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 8 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1 uint64
+
+	// Offset (0) 'Request'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 8 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'Signature'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Field (0) 'Request'
+	{
+		buf = tail[o0:o1]
+		if err = s.Request.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (1) 'Signature'
+	{
+		buf = tail[o1:]
+		if len(buf) > 1536 {
+			return ssz.ErrBytesLength
+		}
+		if cap(s.Signature) == 0 {
+			s.Signature = make([]byte, 0, len(buf))
+		}
+		s.Signature = append(s.Signature, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SignedBlsSignRequest object
+func (s *SignedBlsSignRequest) SizeSSZ() (size int) {
+	size = 8
+
+	// Field (0) 'Request'
+	size += s.Request.SizeSSZ()
+
+	// Field (1) 'Signature'
+	size += len(s.Signature)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedBlsSignRequest object
+func (s *SignedBlsSignRequest) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedBlsSignRequest object with a hasher
+func (s *SignedBlsSignRequest) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Request'
+	if err = s.Request.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(s.Signature))
+		if byteLen > 1536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(s.Signature)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (1536+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the SignedBlsSignRequest object
+func (s *SignedBlsSignRequest) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(s)
+}
+
+// MarshalSSZ ssz marshals the BlsSignMessage object
+func (b *BlsSignMessage) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlsSignMessage object to a target array
+func (b *BlsSignMessage) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(8)
+
+	// Offset (0) 'SignedRequest'
+	dst = ssz.WriteOffset(dst, offset)
+	if b.SignedRequest == nil {
+		b.SignedRequest = new(SignedBlsSignRequest)
+	}
+	offset += b.SignedRequest.SizeSSZ()
+
+	// Offset (1) 'Proofs'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Proofs); ii++ {
+		offset += 4
+		offset += b.Proofs[ii].SizeSSZ()
+	}
+
+	// Field (0) 'SignedRequest'
+	if dst, err = b.SignedRequest.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'Proofs'
+	if size := len(b.Proofs); size > 13 {
+		err = ssz.ErrListTooBigFn("BlsSignMessage.Proofs", size, 13)
+		return
+	}
+	{
+		offset = 4 * len(b.Proofs)
+		for ii := 0; ii < len(b.Proofs); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.Proofs[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.Proofs); ii++ {
+		if dst, err = b.Proofs[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BlsSignMessage object
+func (b *BlsSignMessage) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 8 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1 uint64
+
+	// Offset (0) 'SignedRequest'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 8 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'Proofs'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Field (0) 'SignedRequest'
+	{
+		buf = tail[o0:o1]
+		if b.SignedRequest == nil {
+			b.SignedRequest = new(SignedBlsSignRequest)
+		}
+		if err = b.SignedRequest.UnmarshalSSZ(buf); err != nil {
+			return err
+		}
+	}
+
+	// Field (1) 'Proofs'
+	{
+		buf = tail[o1:]
+		num, err := ssz.DecodeDynamicLength(buf, 13)
+		if err != nil {
+			return err
+		}
+		b.Proofs = make([]*SignedProof, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.Proofs[indx] == nil {
+				b.Proofs[indx] = new(SignedProof)
+			}
+			if err = b.Proofs[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlsSignMessage object
+func (b *BlsSignMessage) SizeSSZ() (size int) {
+	size = 8
+
+	// Field (0) 'SignedRequest'
+	if b.SignedRequest == nil {
+		b.SignedRequest = new(SignedBlsSignRequest)
+	}
+	size += b.SignedRequest.SizeSSZ()
+
+	// Field (1) 'Proofs'
+	for ii := 0; ii < len(b.Proofs); ii++ {
+		size += 4
+		size += b.Proofs[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BlsSignMessage object
+func (b *BlsSignMessage) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BlsSignMessage object with a hasher
+func (b *BlsSignMessage) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'SignedRequest'
+	if err = b.SignedRequest.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Proofs'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Proofs))
+		if num > 13 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Proofs {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 13)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the BlsSignMessage object
+func (b *BlsSignMessage) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(b)
+}
+
+// MarshalSSZ ssz marshals the BlsSignResponse object
+func (b *BlsSignResponse) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BlsSignResponse object to a target array
+func (b *BlsSignResponse) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'OperatorID'
+	dst = ssz.MarshalUint64(dst, b.OperatorID)
+
+	// Field (1) 'PartialSignature'
+	if size := len(b.PartialSignature); size != 96 {
+		err = ssz.ErrBytesLengthFn("BlsSignResponse.PartialSignature", size, 96)
+		return
+	}
+	dst = append(dst, b.PartialSignature...)
+
+	return
 }
 
-// SizeSSZ returns the ssz encoded size in bytes for the SignedReshare object
-func (s *SignedReshare) SizeSSZ() (size int) {
-	size = 8
+// UnmarshalSSZ ssz unmarshals the BlsSignResponse object
+func (b *BlsSignResponse) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 104 {
+		return ssz.ErrSize
+	}
 
-	// Field (0) 'Reshare'
-	size += s.Reshare.SizeSSZ()
+	// Field (0) 'OperatorID'
+	b.OperatorID = ssz.UnmarshallUint64(buf[0:8])
 
-	// Field (1) 'Signature'
-	size += len(s.Signature)
+	// Field (1) 'PartialSignature'
+	if cap(b.PartialSignature) == 0 {
+		b.PartialSignature = make([]byte, 0, len(buf[8:104]))
+	}
+	b.PartialSignature = append(b.PartialSignature, buf[8:104]...)
 
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BlsSignResponse object
+func (b *BlsSignResponse) SizeSSZ() (size int) {
+	size = 104
 	return
 }
 
-// HashTreeRoot ssz hashes the SignedReshare object
-func (s *SignedReshare) HashTreeRoot() ([32]byte, error) {
-	return ssz.HashWithDefaultHasher(s)
+// HashTreeRoot ssz hashes the BlsSignResponse object
+func (b *BlsSignResponse) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
 }
 
-// HashTreeRootWith ssz hashes the SignedReshare object with a hasher
-func (s *SignedReshare) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+// HashTreeRootWith ssz hashes the BlsSignResponse object with a hasher
+func (b *BlsSignResponse) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'Reshare'
-	if err = s.Reshare.HashTreeRootWith(hh); err != nil {
-		return
-	}
+	// Field (0) 'OperatorID'
+	hh.PutUint64(b.OperatorID)
 
-	// Field (1) 'Signature'
-	{
-		elemIndx := hh.Index()
-		byteLen := uint64(len(s.Signature))
-		if byteLen > 1536 {
-			err = ssz.ErrIncorrectListSize
-			return
-		}
-		hh.Append(s.Signature)
-		hh.MerkleizeWithMixin(elemIndx, byteLen, (1536+31)/32)
+	// Field (1) 'PartialSignature'
+	if size := len(b.PartialSignature); size != 96 {
+		err = ssz.ErrBytesLengthFn("BlsSignResponse.PartialSignature", size, 96)
+		return
 	}
+	hh.PutBytes(b.PartialSignature)
 
 	hh.Merkleize(indx)
 	return
 }
 
-// GetTree ssz hashes the SignedReshare object
-func (s *SignedReshare) GetTree() (*ssz.Node, error) {
-	return ssz.ProofTree(s)
+// GetTree ssz hashes the BlsSignResponse object
+func (b *BlsSignResponse) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(b)
 }
 
 // MarshalSSZ ssz marshals the Result object
@@ -1442,7 +2701,7 @@ func (r *Result) MarshalSSZ() ([]byte, error) {
 // MarshalSSZTo ssz marshals the Result object to a target array
 func (r *Result) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(228)
+	offset := int(232)
 
 	// Field (0) 'OperatorID'
 	dst = ssz.MarshalUint64(dst, r.OperatorID)
@@ -1468,11 +2727,22 @@ func (r *Result) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = ssz.WriteOffset(dst, offset)
 	offset += r.SignedProof.SizeSSZ()
 
+	// Offset (5) 'ExitPartialSignature'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.ExitPartialSignature)
+
 	// Field (4) 'SignedProof'
 	if dst, err = r.SignedProof.MarshalSSZTo(dst); err != nil {
 		return
 	}
 
+	// Field (5) 'ExitPartialSignature'
+	if size := len(r.ExitPartialSignature); size > 96 {
+		err = ssz.ErrBytesLengthFn("Result.ExitPartialSignature", size, 96)
+		return
+	}
+	dst = append(dst, r.ExitPartialSignature...)
+
 	return
 }
 
@@ -1480,12 +2750,12 @@ func (r *Result) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (r *Result) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 228 {
+	if size < 232 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o4 uint64
+	var o4, o5 uint64
 
 	// Field (0) 'OperatorID'
 	r.OperatorID = ssz.UnmarshallUint64(buf[0:8])
@@ -1510,27 +2780,47 @@ func (r *Result) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
-	if o4 < 228 {
+	if o4 < 232 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
+	// Offset (5) 'ExitPartialSignature'
+	if o5 = ssz.ReadOffset(buf[228:232]); o5 > size || o4 > o5 {
+		return ssz.ErrOffset
+	}
+
 	// Field (4) 'SignedProof'
 	{
-		buf = tail[o4:]
+		buf = tail[o4:o5]
 		if err = r.SignedProof.UnmarshalSSZ(buf); err != nil {
 			return err
 		}
 	}
+
+	// Field (5) 'ExitPartialSignature'
+	{
+		buf = tail[o5:]
+		if len(buf) > 96 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.ExitPartialSignature) == 0 {
+			r.ExitPartialSignature = make([]byte, 0, len(buf))
+		}
+		r.ExitPartialSignature = append(r.ExitPartialSignature, buf...)
+	}
 	return err
 }
 
 // SizeSSZ returns the ssz encoded size in bytes for the Result object
 func (r *Result) SizeSSZ() (size int) {
-	size = 228
+	size = 232
 
 	// Field (4) 'SignedProof'
 	size += r.SignedProof.SizeSSZ()
 
+	// Field (5) 'ExitPartialSignature'
+	size += len(r.ExitPartialSignature)
+
 	return
 }
 
@@ -1568,6 +2858,18 @@ func (r *Result) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 		return
 	}
 
+	// Field (5) 'ExitPartialSignature'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.ExitPartialSignature))
+		if byteLen > 96 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(r.ExitPartialSignature)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (96+31)/32)
+	}
+
 	hh.Merkleize(indx)
 	return
 }
@@ -1577,6 +2879,135 @@ func (r *Result) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(r)
 }
 
+// MarshalSSZ ssz marshals the MultipleResults object
+func (m *MultipleResults) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MultipleResults object to a target array
+func (m *MultipleResults) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Results'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(m.Results); ii++ {
+		offset += 4
+		offset += m.Results[ii].SizeSSZ()
+	}
+
+	// Field (0) 'Results'
+	if size := len(m.Results); size > 100 {
+		err = ssz.ErrListTooBigFn("MultipleResults.Results", size, 100)
+		return
+	}
+	{
+		offset = 4 * len(m.Results)
+		for ii := 0; ii < len(m.Results); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += m.Results[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(m.Results); ii++ {
+		if dst, err = m.Results[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MultipleResults object
+func (m *MultipleResults) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Results'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Results'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DecodeDynamicLength(buf, 100)
+		if err != nil {
+			return err
+		}
+		m.Results = make([]*Result, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if m.Results[indx] == nil {
+				m.Results[indx] = new(Result)
+			}
+			if err = m.Results[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MultipleResults object
+func (m *MultipleResults) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Results'
+	for ii := 0; ii < len(m.Results); ii++ {
+		size += 4
+		size += m.Results[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MultipleResults object
+func (m *MultipleResults) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MultipleResults object with a hasher
+func (m *MultipleResults) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Results'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.Results))
+		if num > 100 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range m.Results {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 100)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the MultipleResults object
+func (m *MultipleResults) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(m)
+}
+
 // MarshalSSZ ssz marshals the Proof object
 func (p *Proof) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(p)
@@ -2159,7 +3590,7 @@ func (p *Pong) MarshalSSZ() ([]byte, error) {
 // MarshalSSZTo ssz marshals the Pong object to a target array
 func (p *Pong) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(12)
+	offset := int(24)
 
 	// Field (0) 'ID'
 	dst = ssz.MarshalUint64(dst, p.ID)
@@ -2168,6 +3599,21 @@ func (p *Pong) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = ssz.WriteOffset(dst, offset)
 	offset += len(p.PubKey)
 
+	// Offset (2) 'MinVersion'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.MinVersion)
+
+	// Offset (3) 'MaxVersion'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(p.MaxVersion)
+
+	// Offset (4) 'Features'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(p.Features); ii++ {
+		offset += 4
+		offset += len(p.Features[ii])
+	}
+
 	// Field (1) 'PubKey'
 	if size := len(p.PubKey); size > 2048 {
 		err = ssz.ErrBytesLengthFn("Pong.PubKey", size, 2048)
@@ -2175,6 +3621,40 @@ func (p *Pong) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	}
 	dst = append(dst, p.PubKey...)
 
+	// Field (2) 'MinVersion'
+	if size := len(p.MinVersion); size > 64 {
+		err = ssz.ErrBytesLengthFn("Pong.MinVersion", size, 64)
+		return
+	}
+	dst = append(dst, p.MinVersion...)
+
+	// Field (3) 'MaxVersion'
+	if size := len(p.MaxVersion); size > 64 {
+		err = ssz.ErrBytesLengthFn("Pong.MaxVersion", size, 64)
+		return
+	}
+	dst = append(dst, p.MaxVersion...)
+
+	// Field (4) 'Features'
+	if size := len(p.Features); size > 32 {
+		err = ssz.ErrListTooBigFn("Pong.Features", size, 32)
+		return
+	}
+	{
+		offset = 4 * len(p.Features)
+		for ii := 0; ii < len(p.Features); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += len(p.Features[ii])
+		}
+	}
+	for ii := 0; ii < len(p.Features); ii++ {
+		if size := len(p.Features[ii]); size > 64 {
+			err = ssz.ErrBytesLengthFn("Pong.Features[ii]", size, 64)
+			return
+		}
+		dst = append(dst, p.Features[ii]...)
+	}
+
 	return
 }
 
@@ -2182,12 +3662,12 @@ func (p *Pong) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (p *Pong) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 12 {
+	if size < 24 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o1 uint64
+	var o1, o2, o3, o4 uint64
 
 	// Field (0) 'ID'
 	p.ID = ssz.UnmarshallUint64(buf[0:8])
@@ -2197,13 +3677,28 @@ func (p *Pong) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
-	if o1 < 12 {
+	if o1 < 24 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
+	// Offset (2) 'MinVersion'
+	if o2 = ssz.ReadOffset(buf[12:16]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (3) 'MaxVersion'
+	if o3 = ssz.ReadOffset(buf[16:20]); o3 > size || o2 > o3 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (4) 'Features'
+	if o4 = ssz.ReadOffset(buf[20:24]); o4 > size || o3 > o4 {
+		return ssz.ErrOffset
+	}
+
 	// Field (1) 'PubKey'
 	{
-		buf = tail[o1:]
+		buf = tail[o1:o2]
 		if len(buf) > 2048 {
 			return ssz.ErrBytesLength
 		}
@@ -2212,16 +3707,75 @@ func (p *Pong) UnmarshalSSZ(buf []byte) error {
 		}
 		p.PubKey = append(p.PubKey, buf...)
 	}
+
+	// Field (2) 'MinVersion'
+	{
+		buf = tail[o2:o3]
+		if len(buf) > 64 {
+			return ssz.ErrBytesLength
+		}
+		if cap(p.MinVersion) == 0 {
+			p.MinVersion = make([]byte, 0, len(buf))
+		}
+		p.MinVersion = append(p.MinVersion, buf...)
+	}
+
+	// Field (3) 'MaxVersion'
+	{
+		buf = tail[o3:o4]
+		if len(buf) > 64 {
+			return ssz.ErrBytesLength
+		}
+		if cap(p.MaxVersion) == 0 {
+			p.MaxVersion = make([]byte, 0, len(buf))
+		}
+		p.MaxVersion = append(p.MaxVersion, buf...)
+	}
+
+	// Field (4) 'Features'
+	{
+		buf = tail[o4:]
+		num, err := ssz.DecodeDynamicLength(buf, 32)
+		if err != nil {
+			return err
+		}
+		p.Features = make([][]byte, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if len(buf) > 64 {
+				return ssz.ErrBytesLength
+			}
+			if cap(p.Features[indx]) == 0 {
+				p.Features[indx] = make([]byte, 0, len(buf))
+			}
+			p.Features[indx] = append(p.Features[indx], buf...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
 	return err
 }
 
 // SizeSSZ returns the ssz encoded size in bytes for the Pong object
 func (p *Pong) SizeSSZ() (size int) {
-	size = 12
+	size = 24
 
 	// Field (1) 'PubKey'
 	size += len(p.PubKey)
 
+	// Field (2) 'MinVersion'
+	size += len(p.MinVersion)
+
+	// Field (3) 'MaxVersion'
+	size += len(p.MaxVersion)
+
+	// Field (4) 'Features'
+	for ii := 0; ii < len(p.Features); ii++ {
+		size += 4
+		size += len(p.Features[ii])
+	}
+
 	return
 }
 
@@ -2249,6 +3803,53 @@ func (p *Pong) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 		hh.MerkleizeWithMixin(elemIndx, byteLen, (2048+31)/32)
 	}
 
+	// Field (2) 'MinVersion'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(p.MinVersion))
+		if byteLen > 64 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(p.MinVersion)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (64+31)/32)
+	}
+
+	// Field (3) 'MaxVersion'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(p.MaxVersion))
+		if byteLen > 64 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(p.MaxVersion)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (64+31)/32)
+	}
+
+	// Field (4) 'Features'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(p.Features))
+		if num > 32 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range p.Features {
+			{
+				elemIndx := hh.Index()
+				byteLen := uint64(len(elem))
+				if byteLen > 64 {
+					err = ssz.ErrIncorrectListSize
+					return
+				}
+				hh.AppendBytes32(elem)
+				hh.MerkleizeWithMixin(elemIndx, byteLen, (64+31)/32)
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 32)
+	}
+
 	hh.Merkleize(indx)
 	return
 }
@@ -2309,22 +3910,22 @@ func (r *ResultData) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	}
 
 	// Field (2) 'DepositData'
-	if size := len(r.DepositData); size > 8192 {
-		err = ssz.ErrBytesLengthFn("ResultData.DepositData", size, 8192)
+	if size := len(r.DepositData); size > 262144 {
+		err = ssz.ErrBytesLengthFn("ResultData.DepositData", size, 262144)
 		return
 	}
 	dst = append(dst, r.DepositData...)
 
 	// Field (3) 'KeysharesData'
-	if size := len(r.KeysharesData); size > 32768 {
-		err = ssz.ErrBytesLengthFn("ResultData.KeysharesData", size, 32768)
+	if size := len(r.KeysharesData); size > 4194304 {
+		err = ssz.ErrBytesLengthFn("ResultData.KeysharesData", size, 4194304)
 		return
 	}
 	dst = append(dst, r.KeysharesData...)
 
 	// Field (4) 'Proofs'
-	if size := len(r.Proofs); size > 32768 {
-		err = ssz.ErrBytesLengthFn("ResultData.Proofs", size, 32768)
+	if size := len(r.Proofs); size > 2097152 {
+		err = ssz.ErrBytesLengthFn("ResultData.Proofs", size, 2097152)
 		return
 	}
 	dst = append(dst, r.Proofs...)
@@ -2395,7 +3996,7 @@ func (r *ResultData) UnmarshalSSZ(buf []byte) error {
 	// Field (2) 'DepositData'
 	{
 		buf = tail[o2:o3]
-		if len(buf) > 8192 {
+		if len(buf) > 262144 {
 			return ssz.ErrBytesLength
 		}
 		if cap(r.DepositData) == 0 {
@@ -2407,7 +4008,7 @@ func (r *ResultData) UnmarshalSSZ(buf []byte) error {
 	// Field (3) 'KeysharesData'
 	{
 		buf = tail[o3:o4]
-		if len(buf) > 32768 {
+		if len(buf) > 4194304 {
 			return ssz.ErrBytesLength
 		}
 		if cap(r.KeysharesData) == 0 {
@@ -2419,7 +4020,7 @@ func (r *ResultData) UnmarshalSSZ(buf []byte) error {
 	// Field (4) 'Proofs'
 	{
 		buf = tail[o4:]
-		if len(buf) > 32768 {
+		if len(buf) > 2097152 {
 			return ssz.ErrBytesLength
 		}
 		if cap(r.Proofs) == 0 {
@@ -2484,36 +4085,36 @@ func (r *ResultData) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	{
 		elemIndx := hh.Index()
 		byteLen := uint64(len(r.DepositData))
-		if byteLen > 8192 {
+		if byteLen > 262144 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
 		hh.Append(r.DepositData)
-		hh.MerkleizeWithMixin(elemIndx, byteLen, (8192+31)/32)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (262144+31)/32)
 	}
 
 	// Field (3) 'KeysharesData'
 	{
 		elemIndx := hh.Index()
 		byteLen := uint64(len(r.KeysharesData))
-		if byteLen > 32768 {
+		if byteLen > 4194304 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
 		hh.Append(r.KeysharesData)
-		hh.MerkleizeWithMixin(elemIndx, byteLen, (32768+31)/32)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (4194304+31)/32)
 	}
 
 	// Field (4) 'Proofs'
 	{
 		elemIndx := hh.Index()
 		byteLen := uint64(len(r.Proofs))
-		if byteLen > 32768 {
+		if byteLen > 2097152 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
 		hh.Append(r.Proofs)
-		hh.MerkleizeWithMixin(elemIndx, byteLen, (32768+31)/32)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (2097152+31)/32)
 	}
 
 	hh.Merkleize(indx)
@@ -0,0 +1,94 @@
+package wire
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+// ProtocolVersion is the latest version of the protocol between initiator and operators. Unlike the release version
+// it changes only when messages or their processing change, so releases speaking the same protocol are compatible.
+// v2.0.0: init message carries the threshold tolerant flag, operators exchange response bundles before deciding on
+// qualified dealers and owner signatures of init messages cover the ceremony ID. v1 peers can't take part in a ceremony.
+const ProtocolVersion = "v2.0.0"
+
+// SupportedVersions is the range of protocol versions supported by this release
+var SupportedVersions = VersionRange{Min: "v2.0.0", Max: ProtocolVersion}
+
+// Features of the protocol an operator may not support
+const (
+	FeatureMultipleValidators = "multiple_validators" // several validators created by one ceremony
+	FeatureSignedExit         = "signed_exit"         // voluntary exits pre-signed during the ceremony
+	FeatureReshare            = "reshare"             // resharing of existing validators, requires ethereum client
+	FeatureBlsSign            = "bls_sign"            // signing of beacon messages with stored key shares
+	FeatureOwnerSignedInit    = "owner_signed_init"   // init messages signed by the owner, requires ethereum client
+	FeatureThresholdTolerant  = "threshold_tolerant"  // DKG started without operators excluded by initiator
+)
+
+// VersionRange is an inclusive range of semver protocol versions
+type VersionRange struct {
+	Min string
+	Max string
+}
+
+// Validate checks that the range bounds are semver versions and the range isn't empty
+func (r VersionRange) Validate() error {
+	minVersion, err := version.NewSemver(r.Min)
+	if err != nil {
+		return fmt.Errorf("invalid min protocol version %s: %w", r.Min, err)
+	}
+	maxVersion, err := version.NewSemver(r.Max)
+	if err != nil {
+		return fmt.Errorf("invalid max protocol version %s: %w", r.Max, err)
+	}
+	if maxVersion.LessThan(minVersion) {
+		return fmt.Errorf("max protocol version %s is lower than min protocol version %s", r.Max, r.Min)
+	}
+	return nil
+}
+
+// Supports checks that the protocol version is in the range
+func (r VersionRange) Supports(v string) bool {
+	ver, err := version.NewSemver(v)
+	if err != nil {
+		return false
+	}
+	minVersion, err := version.NewSemver(r.Min)
+	if err != nil {
+		return false
+	}
+	maxVersion, err := version.NewSemver(r.Max)
+	if err != nil {
+		return false
+	}
+	return !ver.LessThan(minVersion) && !ver.GreaterThan(maxVersion)
+}
+
+// String returns the range as min-max
+func (r VersionRange) String() string {
+	return r.Min + "-" + r.Max
+}
+
+// NegotiateVersion returns the highest protocol version supported by all ranges
+func NegotiateVersion(ranges ...VersionRange) (string, error) {
+	var highest *version.Version
+	var highestStr string
+	for _, r := range ranges {
+		if err := r.Validate(); err != nil {
+			return "", err
+		}
+		maxVersion, _ := version.NewSemver(r.Max)
+		if highest == nil || maxVersion.LessThan(highest) {
+			highest, highestStr = maxVersion, r.Max
+		}
+	}
+	if highest == nil {
+		return "", fmt.Errorf("no protocol versions to negotiate")
+	}
+	for _, r := range ranges {
+		if !r.Supports(highestStr) {
+			return "", fmt.Errorf("no common protocol version, %s isn't in range %s", highestStr, r)
+		}
+	}
+	return highestStr, nil
+}
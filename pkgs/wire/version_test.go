@@ -0,0 +1,108 @@
+package wire
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionRange(t *testing.T) {
+	r := VersionRange{Min: "v1.0.0", Max: "v1.2.0"}
+	require.NoError(t, r.Validate())
+	require.True(t, r.Supports("v1.0.0"))
+	require.True(t, r.Supports("v1.1.3"))
+	require.True(t, r.Supports("1.2.0"))
+	require.False(t, r.Supports("v1.2.1"))
+	require.False(t, r.Supports("v0.9.0"))
+	require.False(t, r.Supports("test.version"))
+	require.ErrorContains(t, VersionRange{Min: "v1.1.0", Max: "v1.0.0"}.Validate(), "is lower than min protocol version")
+	require.ErrorContains(t, VersionRange{Min: "v1.0.0", Max: "test.version"}.Validate(), "invalid max protocol version")
+	require.NoError(t, SupportedVersions.Validate())
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	t.Run("highest common version", func(t *testing.T) {
+		v, err := NegotiateVersion(
+			VersionRange{Min: "v1.0.0", Max: "v1.3.0"},
+			VersionRange{Min: "v1.1.0", Max: "v1.2.0"},
+			VersionRange{Min: "v1.0.0", Max: "v1.4.0"},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "v1.2.0", v)
+	})
+	t.Run("no common version", func(t *testing.T) {
+		_, err := NegotiateVersion(
+			VersionRange{Min: "v1.0.0", Max: "v1.1.0"},
+			VersionRange{Min: "v1.2.0", Max: "v1.3.0"},
+		)
+		require.ErrorContains(t, err, "no common protocol version")
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := NegotiateVersion(SupportedVersions, VersionRange{})
+		require.ErrorContains(t, err, "invalid min protocol version")
+	})
+}
+
+// encodings pins the SSZ encoding of messages exchanged before the protocol version is negotiated to the protocol
+// version: changing the encoding of Init or Pong requires a new ProtocolVersion with its pinned encoding.
+var encodings = map[string]struct {
+	initSize, pongSize int
+	init, pong         string // sha256 of the encoded fixture messages
+}{
+	"v2.0.0": {
+		initSize: 127,
+		pongSize: 24,
+		init:     "d8802a3cd95e403eb4ecfdc0087e713bee8eb21e262755505da1ed27b821dec4",
+		pong:     "59c04ccc69da5a122c0bfe3015db97161f0d32c0e95fe7c4a5e6b6016d4c0a87",
+	},
+}
+
+func fixtureInit() *Init {
+	return &Init{
+		Operators:             []*Operator{{ID: 1, PubKey: []byte{1}}, {ID: 2, PubKey: []byte{2}}, {ID: 3, PubKey: []byte{3}}, {ID: 4, PubKey: []byte{4}}},
+		T:                     3,
+		WithdrawalCredentials: bytes.Repeat([]byte{0xaa}, 20),
+		Fork:                  [4]byte{0, 0, 0, 1},
+		Owner:                 [20]byte{0xbb},
+		Nonce:                 5,
+		WithdrawalPrefix:      1,
+		Amount:                32000000000,
+		SignExit:              true,
+		ExitEpoch:             6,
+		ExitValidatorIndex:    7,
+		ExitForkVersion:       [4]byte{3, 0, 0, 0},
+		GenesisValidatorsRoot: [32]byte{0xcc},
+		PhaseTimeout:          8,
+		Validators:            1,
+		ThresholdTolerant:     true,
+	}
+}
+
+func fixturePong() *Pong {
+	return &Pong{
+		ID:         1,
+		PubKey:     []byte("operator public key"),
+		MinVersion: []byte("v1.0.0"),
+		MaxVersion: []byte("v2.0.0"),
+		Features:   [][]byte{[]byte(FeatureReshare), []byte(FeatureThresholdTolerant)},
+	}
+}
+
+func TestProtocolEncoding(t *testing.T) {
+	enc, ok := encodings[ProtocolVersion]
+	require.True(t, ok, "encoding of protocol version %s isn't pinned", ProtocolVersion)
+	require.Equal(t, enc.initSize, (&Init{}).SizeSSZ(), "init encoding changed without a protocol version bump")
+	require.Equal(t, enc.pongSize, (&Pong{}).SizeSSZ(), "pong encoding changed without a protocol version bump")
+
+	initBytes, err := fixtureInit().MarshalSSZ()
+	require.NoError(t, err)
+	initHash := sha256.Sum256(initBytes)
+	require.Equal(t, enc.init, hex.EncodeToString(initHash[:]), "init encoding changed without a protocol version bump")
+	pongBytes, err := fixturePong().MarshalSSZ()
+	require.NoError(t, err)
+	pongHash := sha256.Sum256(pongBytes)
+	require.Equal(t, enc.pong, hex.EncodeToString(pongHash[:]), "pong encoding changed without a protocol version bump")
+}
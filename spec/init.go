@@ -3,10 +3,39 @@ package spec
 import (
 	"bytes"
 	"fmt"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
 )
 
+// MaxPhaseTimeout is the maximum fallback timeout of a DKG protocol phase requested by initiator
+const MaxPhaseTimeout = time.Minute
+
+// MaxCeremonyValidators is the maximum number of validators created by one DKG ceremony
+const MaxCeremonyValidators = 100
+
+// InitSigningRoot returns the root signed by the owner to authorize the init message of the ceremony with the ID
+func InitSigningRoot(id [24]byte, init *wire.Init) ([32]byte, error) {
+	initRoot, err := init.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return (&wire.InitSigningRoot{ID: id, InitRoot: initRoot}).HashTreeRoot()
+}
+
+// VerifySignedInit returns nil if owner signature over the init message of the ceremony with the ID is valid
+func VerifySignedInit(client eip1271.ETHClient, id [24]byte, signedInit *wire.SignedInit) error {
+	hash, err := InitSigningRoot(id, &signedInit.Init)
+	if err != nil {
+		return err
+	}
+	return verifyOwnerSignature(client, signedInit.Init.Owner, hash, signedInit.Signature, "init")
+}
+
 // ValidateInitMessage returns nil if init message is valid
 func ValidateInitMessage(init *wire.Init) error {
 	if !UniqueAndOrderedOperators(init.Operators) {
@@ -15,10 +44,62 @@ func ValidateInitMessage(init *wire.Init) error {
 	if !ValidThresholdSet(init.T, init.Operators) {
 		return fmt.Errorf("threshold set is invalid")
 	}
+	if _, err := crypto.WithdrawalCredentials(init.WithdrawalPrefix, init.WithdrawalCredentials); err != nil {
+		return fmt.Errorf("withdrawal credentials are invalid: %w", err)
+	}
+	if err := crypto.ValidateDepositAmount(init.WithdrawalPrefix, phase0.Gwei(init.Amount)); err != nil {
+		return err
+	}
+	if init.SignExit && init.GenesisValidatorsRoot == ([32]byte{}) {
+		return fmt.Errorf("voluntary exit genesis validators root is not set")
+	}
+	if err := ValidatePhaseTimeout(init.PhaseTimeout); err != nil {
+		return err
+	}
+	if init.Validators > MaxCeremonyValidators {
+		return fmt.Errorf("amount of validators %d exceeds maximum %d", init.Validators, MaxCeremonyValidators)
+	}
+	if init.ThresholdTolerant && init.Validators > 1 {
+		return fmt.Errorf("threshold tolerant ceremony creates one validator only")
+	}
 
 	return nil
 }
 
+// ValidatePhaseTimeout returns nil if the phase timeout in milliseconds requested by initiator doesn't exceed MaxPhaseTimeout
+func ValidatePhaseTimeout(timeout uint64) error {
+	if time.Duration(timeout)*time.Millisecond > MaxPhaseTimeout {
+		return fmt.Errorf("phase timeout %dms exceeds maximum %s", timeout, MaxPhaseTimeout)
+	}
+	return nil
+}
+
+// CeremonyValidators returns the number of validators created by the ceremony
+func CeremonyValidators(init *wire.Init) int {
+	if init.Validators == 0 {
+		return 1
+	}
+	return int(init.Validators)
+}
+
+// ValidatorInit returns parameters of the validator at the index of a multi-validator ceremony: owner nonce
+// and voluntary exit validator index are incremented by the index, the rest is the same for all validators
+func ValidatorInit(init *wire.Init, index int) *wire.Init {
+	v := *init
+	v.Nonce += uint64(index)
+	v.ExitValidatorIndex += uint64(index)
+	v.Validators = 1
+	return &v
+}
+
+// VoluntaryExit returns the voluntary exit requested by the init message
+func VoluntaryExit(init *wire.Init) *phase0.VoluntaryExit {
+	return &phase0.VoluntaryExit{
+		Epoch:          phase0.Epoch(init.ExitEpoch),
+		ValidatorIndex: phase0.ValidatorIndex(init.ExitValidatorIndex),
+	}
+}
+
 // ValidThresholdSet returns true if the number of operators and threshold is valid
 func ValidThresholdSet(t uint64, operators []*wire.Operator) bool {
 	if len(operators) == 4 && t == 3 { // 2f+1 = 3
@@ -36,6 +117,17 @@ func ValidThresholdSet(t uint64, operators []*wire.Operator) bool {
 	return false
 }
 
+// RegistrableClusterSize returns the largest cluster size not exceeding n which can be registered
+// at the ssv.network, zero if there is no such size
+func RegistrableClusterSize(n int) int {
+	for _, size := range []int{13, 10, 7, 4} {
+		if size <= n {
+			return size
+		}
+	}
+	return 0
+}
+
 // UniqueAndOrderedOperators returns true if array of operators are unique and ordered (no duplicate IDs)
 func UniqueAndOrderedOperators(operators []*wire.Operator) bool {
 	highestID := uint64(0)
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 
@@ -14,6 +15,60 @@ func ValidateReshareMessage(
 	reshare *wire.Reshare,
 	proofs map[*wire.Operator]wire.SignedProof,
 ) error {
+	if err := validateReshareOperators(reshare, proofs); err != nil {
+		return err
+	}
+	if !ValidThresholdSet(reshare.OldT, reshare.OldOperators) {
+		return fmt.Errorf("old threshold set is invalid")
+	}
+	if !ValidThresholdSet(reshare.NewT, reshare.NewOperators) {
+		return fmt.Errorf("new threshold set is invalid")
+	}
+
+	return nil
+}
+
+// ValidateTolerantReshareMessage returns nil if re-share message redistributes key shares created by a threshold tolerant
+// ceremony which excluded some of its operators to a registrable cluster of the remaining ones. Such re-share isn't signed
+// by the owner, so it has to keep parameters of the ceremony: old operators are operators of the ceremony with its threshold,
+// new operators are a subset of old operators, owner and nonce are the same.
+func ValidateTolerantReshareMessage(
+	init *wire.Init,
+	reshare *wire.Reshare,
+	proofs map[*wire.Operator]wire.SignedProof,
+) error {
+	if !init.ThresholdTolerant {
+		return fmt.Errorf("ceremony isn't threshold tolerant")
+	}
+	if err := validateReshareOperators(reshare, proofs); err != nil {
+		return err
+	}
+	for _, op := range reshare.OldOperators {
+		if !containsOperator(init.Operators, op) {
+			return fmt.Errorf("old operator %d isn't an operator of the ceremony", op.ID)
+		}
+	}
+	if reshare.OldT != init.T || uint64(len(reshare.OldOperators)) < reshare.OldT {
+		return fmt.Errorf("old threshold doesn't match the ceremony")
+	}
+	for _, op := range reshare.NewOperators {
+		if !containsOperator(reshare.OldOperators, op) {
+			return fmt.Errorf("new operator %d isn't an old operator", op.ID)
+		}
+	}
+	if !ValidThresholdSet(reshare.NewT, reshare.NewOperators) {
+		return fmt.Errorf("new threshold set is invalid")
+	}
+	if reshare.Owner != init.Owner || reshare.Nonce != init.Nonce {
+		return fmt.Errorf("owner and nonce don't match the ceremony")
+	}
+
+	return nil
+}
+
+// validateReshareOperators checks that old and new operators are unique, ordered and differ from each other,
+// and that proofs of the old operators are valid
+func validateReshareOperators(reshare *wire.Reshare, proofs map[*wire.Operator]wire.SignedProof) error {
 	if !UniqueAndOrderedOperators(reshare.OldOperators) {
 		return fmt.Errorf("old operators are not unique and ordered")
 	}
@@ -32,19 +87,34 @@ func ValidateReshareMessage(
 	if EqualOperators(reshare.OldOperators, reshare.NewOperators) {
 		return fmt.Errorf("old and new operators are the same")
 	}
-	if !ValidThresholdSet(reshare.OldT, reshare.OldOperators) {
-		return fmt.Errorf("old threshold set is invalid")
-	}
-	if !ValidThresholdSet(reshare.NewT, reshare.NewOperators) {
-		return fmt.Errorf("new threshold set is invalid")
-	}
-
 	return nil
 }
 
+// containsOperator returns true if operators contain the operator with the same ID and public key
+func containsOperator(operators []*wire.Operator, op *wire.Operator) bool {
+	found := GetOperator(operators, op.ID)
+	return found != nil && bytes.Equal(found.PubKey, op.PubKey)
+}
+
 func OrderOperators(in []*wire.Operator) []*wire.Operator {
 	sort.Slice(in, func(i, j int) bool {
 		return in[i].ID < in[j].ID
 	})
 	return in
 }
+
+// ReshareDealers returns old operators dealing their key shares at resharing. Old operators staying in the new
+// cluster deal alone if there are at least old threshold of them, so operators leaving the cluster aren't needed,
+// e.g. when one of them went offline. Otherwise all old operators deal.
+func ReshareDealers(reshare *wire.Reshare) []*wire.Operator {
+	var staying []*wire.Operator
+	for _, op := range reshare.OldOperators {
+		if GetOperator(reshare.NewOperators, op.ID) != nil {
+			staying = append(staying, op)
+		}
+	}
+	if uint64(len(staying)) >= reshare.OldT {
+		return staying
+	}
+	return reshare.OldOperators
+}
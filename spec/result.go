@@ -18,6 +18,8 @@ import (
 func ValidateResults(
 	operators []*wire.Operator,
 	withdrawalCredentials []byte,
+	withdrawalPrefix byte,
+	amount phase0.Gwei,
 	validatorPK []byte,
 	fork [4]byte,
 	ownerAddress [20]byte,
@@ -42,7 +44,7 @@ func ValidateResults(
 	sigsPartialDeposit := make([]*bls.Sign, 0, len(results))
 	sigsPartialOwnerNonce := make([]*bls.Sign, 0, len(results))
 	for _, result := range results {
-		if err := ValidateResult(operators, ownerAddress, requestID, withdrawalCredentials, validatorPK, fork, nonce, result); err != nil {
+		if err := ValidateResult(operators, ownerAddress, requestID, withdrawalCredentials, withdrawalPrefix, amount, validatorPK, fork, nonce, result); err != nil {
 			return nil, nil, nil, err
 		}
 		pub, deposit, ownerNonce, err := GetPartialSigsFromResult(result)
@@ -66,10 +68,14 @@ func ValidateResults(
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	withdrawal, err := crypto.WithdrawalCredentials(withdrawalPrefix, withdrawalCredentials)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	depositData := &phase0.DepositData{
 		PublicKey:             phase0.BLSPubKey(validatorRecoveredPK.Serialize()),
-		Amount:                crypto.MaxEffectiveBalanceInGwei,
-		WithdrawalCredentials: crypto.ETH1WithdrawalCredentials(withdrawalCredentials),
+		Amount:                amount,
+		WithdrawalCredentials: withdrawal,
 		Signature:             phase0.BLSSignature(masterDepositSig.Serialize()),
 	}
 	err = crypto.VerifyDepositData(network, depositData)
@@ -90,6 +96,8 @@ func ValidateResult(
 	ownerAddress [20]byte,
 	requestID [24]byte,
 	withdrawalCredentials []byte,
+	withdrawalPrefix byte,
+	amount phase0.Gwei,
 	validatorPK []byte,
 	fork [4]byte,
 	nonce uint64,
@@ -108,6 +116,8 @@ func ValidateResult(
 
 	if err := VerifyPartialSignatures(
 		withdrawalCredentials,
+		withdrawalPrefix,
+		amount,
 		fork,
 		ownerAddress,
 		nonce,
@@ -129,6 +139,50 @@ func ValidateResult(
 	return nil
 }
 
+// ValidateExitResults verifies partial voluntary exit signatures of results and returns the voluntary exit
+// signed by the validator with the reconstructed master signature
+func ValidateExitResults(
+	exit *phase0.VoluntaryExit,
+	forkVersion [4]byte,
+	genesisValidatorsRoot [32]byte,
+	validatorPK []byte,
+	results []*wire.Result,
+) (*phase0.SignedVoluntaryExit, error) {
+	signingRoot, err := crypto.ComputeVoluntaryExitSigningRoot(exit, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint64, 0, len(results))
+	sigsPartialExit := make([]*bls.Sign, 0, len(results))
+	for _, result := range results {
+		pk, err := BLSPKEncode(result.SignedProof.Proof.SharePubKey)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := BLSSignatureEncode(result.ExitPartialSignature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exit partial signature of operator %d: %v", result.OperatorID, err)
+		}
+		if err := crypto.VerifyPartialSigs([]*bls.Sign{sig}, []*bls.PublicKey{pk}, signingRoot[:]); err != nil {
+			return nil, fmt.Errorf("failed to verify exit partial signature of operator %d", result.OperatorID)
+		}
+		ids = append(ids, result.OperatorID)
+		sigsPartialExit = append(sigsPartialExit, sig)
+	}
+	masterExitSig, err := crypto.RecoverBLSSignature(ids, sigsPartialExit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover master signature from shares: %v", err)
+	}
+	signedExit := &phase0.SignedVoluntaryExit{
+		Message:   exit,
+		Signature: phase0.BLSSignature(masterExitSig.Serialize()),
+	}
+	if err := crypto.VerifyVoluntaryExit(validatorPK, signedExit, forkVersion, genesisValidatorsRoot); err != nil {
+		return nil, fmt.Errorf("failed to verify master voluntary exit signature: %v", err)
+	}
+	return signedExit, nil
+}
+
 // RecoverValidatorPKFromResults returns validator PK recovered from results
 func RecoverValidatorPKFromResults(results []*wire.Result) ([]byte, error) {
 	ids := make([]uint64, len(results))
@@ -153,6 +207,8 @@ func RecoverValidatorPKFromResults(results []*wire.Result) ([]byte, error) {
 
 func VerifyPartialSignatures(
 	withdrawalCredentials []byte,
+	withdrawalPrefix byte,
+	amount phase0.Gwei,
 	fork [4]byte,
 	ownerAddress [20]byte,
 	nonce uint64,
@@ -175,6 +231,8 @@ func VerifyPartialSignatures(
 
 	if err := VerifyPartialDepositDataSignatures(
 		withdrawalCredentials,
+		withdrawalPrefix,
+		amount,
 		fork,
 		result.SignedProof.Proof.ValidatorPubKey,
 		[]*bls.Sign{depositSig},
@@ -209,6 +267,8 @@ func VerifyPartialNonceSignatures(
 
 func VerifyPartialDepositDataSignatures(
 	withdrawalCredentials []byte,
+	withdrawalPrefix byte,
+	amount phase0.Gwei,
 	fork [4]byte,
 	validatorPubKey []byte,
 	sigs []*bls.Sign,
@@ -219,10 +279,15 @@ func VerifyPartialDepositDataSignatures(
 		return err
 	}
 
+	withdrawal, err := crypto.WithdrawalCredentials(withdrawalPrefix, withdrawalCredentials)
+	if err != nil {
+		return err
+	}
+
 	shareRoot, err := crypto.ComputeDepositMessageSigningRoot(network, &phase0.DepositMessage{
 		PublicKey:             phase0.BLSPubKey(validatorPubKey),
-		Amount:                crypto.MaxEffectiveBalanceInGwei,
-		WithdrawalCredentials: crypto.ETH1WithdrawalCredentials(withdrawalCredentials)})
+		Amount:                amount,
+		WithdrawalCredentials: withdrawal})
 	if err != nil {
 		return fmt.Errorf("failed to compute deposit data root")
 	}
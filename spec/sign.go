@@ -0,0 +1,144 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
+)
+
+// ValidateBlsSignRequest returns nil if the request to sign a beacon message is valid and proofs of the ceremony
+// prove that operators of the request hold key shares of the validator created for the owner
+func ValidateBlsSignRequest(
+	req *wire.BlsSignRequest,
+	proofs map[*wire.Operator]wire.SignedProof,
+) error {
+	if !UniqueAndOrderedOperators(req.Operators) {
+		return fmt.Errorf("operators are not unique and ordered")
+	}
+	if !ValidThresholdSet(signingThreshold(req.Operators), req.Operators) {
+		return fmt.Errorf("threshold set is invalid")
+	}
+	if len(proofs) != len(req.Operators) {
+		return fmt.Errorf("missing operator proofs")
+	}
+	for operator, proof := range proofs {
+		if GetOperator(req.Operators, operator.ID) == nil {
+			return fmt.Errorf("missing operator proofs")
+		}
+		if err := ValidateCeremonyProof(req.Owner, req.ValidatorPubKey, operator, proof); err != nil {
+			return err
+		}
+	}
+	_, err := BlsSignRequestSigningRoot(req)
+	return err
+}
+
+// BlsSignRequestSigningRoot decodes the beacon message of the request and returns its signing root.
+// Only messages which can't be slashed are signed.
+func BlsSignRequestSigningRoot(req *wire.BlsSignRequest) (phase0.Root, error) {
+	switch req.MessageType {
+	case wire.VoluntaryExitBeaconMessage:
+		exit := &phase0.VoluntaryExit{}
+		if err := exit.UnmarshalSSZ(req.Message); err != nil {
+			return phase0.Root{}, fmt.Errorf("failed to unmarshal voluntary exit: %w", err)
+		}
+		return crypto.ComputeVoluntaryExitSigningRoot(exit, req.ForkVersion, req.GenesisValidatorsRoot)
+	case wire.BLSToExecutionChangeBeaconMessage:
+		change := &capella.BLSToExecutionChange{}
+		if err := change.UnmarshalSSZ(req.Message); err != nil {
+			return phase0.Root{}, fmt.Errorf("failed to unmarshal BLS to execution change: %w", err)
+		}
+		// the key shares should be of the withdrawal key of the change
+		if !bytes.Equal(change.FromBLSPubkey[:], req.ValidatorPubKey) {
+			return phase0.Root{}, fmt.Errorf("BLS to execution change is not from the validator public key")
+		}
+		return crypto.ComputeBLSToExecutionChangeSigningRoot(change, req.ForkVersion, req.GenesisValidatorsRoot)
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported beacon message type %d", req.MessageType)
+	}
+}
+
+// VerifySignedBlsSignRequest returns nil if owner signature over the request to sign a beacon message is valid
+func VerifySignedBlsSignRequest(client eip1271.ETHClient, signedRequest *wire.SignedBlsSignRequest) error {
+	hash, err := signedRequest.Request.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	return verifyOwnerSignature(client, signedRequest.Request.Owner, hash, signedRequest.Signature, "BLS sign request")
+}
+
+// ValidateBlsSignResponses verifies partial signatures of operators against their share public keys at proofs,
+// ordered as operators of the request, and returns the reconstructed master signature of the beacon message
+func ValidateBlsSignResponses(
+	req *wire.BlsSignRequest,
+	proofs []*wire.SignedProof,
+	responses []*wire.BlsSignResponse,
+) (phase0.BLSSignature, error) {
+	signingRoot, err := BlsSignRequestSigningRoot(req)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	if uint64(len(responses)) < signingThreshold(req.Operators) {
+		return phase0.BLSSignature{}, fmt.Errorf("not enough partial signatures: %d, threshold %d", len(responses), signingThreshold(req.Operators))
+	}
+	ids := make([]uint64, 0, len(responses))
+	sigsPartial := make([]*bls.Sign, 0, len(responses))
+	signed := make(map[uint64]struct{}, len(responses))
+	for _, resp := range responses {
+		proof := operatorProof(req.Operators, proofs, resp.OperatorID)
+		if proof == nil {
+			return phase0.BLSSignature{}, fmt.Errorf("operator %d isn't a part of the request", resp.OperatorID)
+		}
+		if _, ok := signed[resp.OperatorID]; ok {
+			return phase0.BLSSignature{}, fmt.Errorf("duplicate partial signature of operator %d", resp.OperatorID)
+		}
+		signed[resp.OperatorID] = struct{}{}
+		pk, err := BLSPKEncode(proof.Proof.SharePubKey)
+		if err != nil {
+			return phase0.BLSSignature{}, err
+		}
+		sig, err := BLSSignatureEncode(resp.PartialSignature)
+		if err != nil {
+			return phase0.BLSSignature{}, fmt.Errorf("failed to decode partial signature of operator %d: %v", resp.OperatorID, err)
+		}
+		if err := crypto.VerifyPartialSigs([]*bls.Sign{sig}, []*bls.PublicKey{pk}, signingRoot[:]); err != nil {
+			return phase0.BLSSignature{}, fmt.Errorf("failed to verify partial signature of operator %d", resp.OperatorID)
+		}
+		ids = append(ids, resp.OperatorID)
+		sigsPartial = append(sigsPartial, sig)
+	}
+	masterSig, err := crypto.RecoverBLSSignature(ids, sigsPartial)
+	if err != nil {
+		return phase0.BLSSignature{}, fmt.Errorf("failed to recover master signature from shares: %v", err)
+	}
+	validatorPK, err := BLSPKEncode(req.ValidatorPubKey)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+	if !masterSig.VerifyByte(validatorPK, signingRoot[:]) {
+		return phase0.BLSSignature{}, fmt.Errorf("failed to verify master signature of the beacon message")
+	}
+	return phase0.BLSSignature(masterSig.Serialize()), nil
+}
+
+// signingThreshold returns the threshold of a cluster of operators
+func signingThreshold(operators []*wire.Operator) uint64 {
+	return uint64(len(operators) - (len(operators)-1)/3)
+}
+
+// operatorProof returns the proof of the operator, proofs are ordered as operators
+func operatorProof(operators []*wire.Operator, proofs []*wire.SignedProof, id uint64) *wire.SignedProof {
+	for i, op := range operators {
+		if op.ID == id && i < len(proofs) {
+			return proofs[i]
+		}
+	}
+	return nil
+}
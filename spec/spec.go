@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	eth_crypto "github.com/ethereum/go-ethereum/crypto"
@@ -29,18 +30,30 @@ func RunDKG(init *wire.Init) ([]*wire.Result, error) {
 	_, _, _, err := ValidateResults(
 		init.Operators,
 		init.WithdrawalCredentials,
+		init.WithdrawalPrefix,
+		phase0.Gwei(init.Amount),
 		results[0].SignedProof.Proof.ValidatorPubKey,
 		init.Fork,
 		init.Owner,
 		init.Nonce,
 		id,
 		results)
-	return results, err
+	if err != nil {
+		return nil, err
+	}
+	if init.SignExit {
+		if _, err := ValidateExitResults(VoluntaryExit(init), init.ExitForkVersion, init.GenesisValidatorsRoot, results[0].SignedProof.Proof.ValidatorPubKey, results); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
 }
 
 func RunReshare(
 	validatorPK []byte,
 	withdrawalCredentials []byte,
+	withdrawalPrefix byte,
+	amount phase0.Gwei,
 	fork [4]byte,
 	signedReshare *wire.SignedReshare,
 	proofs map[*wire.Operator]wire.SignedProof,
@@ -63,6 +76,8 @@ func RunReshare(
 	_, _, _, err := ValidateResults(
 		signedReshare.Reshare.NewOperators,
 		withdrawalCredentials,
+		withdrawalPrefix,
+		amount,
 		validatorPK,
 		fork,
 		signedReshare.Reshare.Owner,
@@ -74,39 +89,44 @@ func RunReshare(
 
 // VerifySignedReshare returns nil if signature over re-share message is valid
 func VerifySignedReshare(client eip1271.ETHClient, signedReshare *wire.SignedReshare) error {
-	isEOASignature, err := IsEOAAccount(client, signedReshare.Reshare.Owner)
+	hash, err := signedReshare.Reshare.HashTreeRoot()
 	if err != nil {
 		return err
 	}
+	return verifyOwnerSignature(client, signedReshare.Reshare.Owner, hash, signedReshare.Signature, "reshare")
+}
 
-	hash, err := signedReshare.Reshare.HashTreeRoot()
+// verifyOwnerSignature returns nil if the signature over hash is made by the owner: an ECDSA signature of an EOA owner
+// or a signature accepted by EIP-1271 isValidSignature of a smart contract wallet
+func verifyOwnerSignature(client eip1271.ETHClient, owner common.Address, hash [32]byte, signature []byte, name string) error {
+	isEOASignature, err := IsEOAAccount(client, owner)
 	if err != nil {
 		return err
 	}
 
 	if isEOASignature {
-		pk, err := eth_crypto.SigToPub(hash[:], signedReshare.Signature)
+		pk, err := eth_crypto.SigToPub(hash[:], signature)
 		if err != nil {
 			return err
 		}
 
 		address := eth_crypto.PubkeyToAddress(*pk)
 
-		if common.Address(signedReshare.Reshare.Owner).Cmp(address) != 0 {
-			return fmt.Errorf("invalid signed reshare signature")
+		if owner.Cmp(address) != 0 {
+			return fmt.Errorf("invalid signed %s signature", name)
 		}
 	} else {
 		// EIP 1271 signature
 		// gnosis implementation https://github.com/safe-global/safe-smart-account/blob/2278f7ccd502878feb5cec21dd6255b82df374b5/contracts/Safe.sol#L265
 		// https://github.com/safe-global/safe-smart-account/blob/main/docs/signatures.md
 		// ... verify via contract call
-		signerVerification, err := eip1271.NewEip1271(signedReshare.Reshare.Owner, client)
+		signerVerification, err := eip1271.NewEip1271(owner, client)
 		if err != nil {
 			return err
 		}
 		res, err := signerVerification.IsValidSignature(&bind.CallOpts{
 			Context: context.Background(),
-		}, hash[:], signedReshare.Signature)
+		}, hash[:], signature)
 		if err != nil {
 			return err
 		}
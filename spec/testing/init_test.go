@@ -3,11 +3,15 @@ package testing
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 	"github.com/bloxapp/ssv-dkg/spec"
 	"github.com/bloxapp/ssv-dkg/spec/testing/fixtures"
+	"github.com/bloxapp/ssv-dkg/spec/testing/stubs"
 )
 
 func TestValidateInitMessage(t *testing.T) {
@@ -19,9 +23,166 @@ func TestValidateInitMessage(t *testing.T) {
 			Fork:                  fixtures.TestFork,
 			Owner:                 fixtures.TestOwnerAddress,
 			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
 		}))
 	})
 
+	t.Run("valid compounding", func(t *testing.T) {
+		require.NoError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.CompoundingWithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceElectraInGwei),
+		}))
+	})
+
+	t.Run("valid BLS withdrawal public key", func(t *testing.T) {
+		require.NoError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalPubKey,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.BLSWithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		}))
+	})
+
+	t.Run("valid voluntary exit", func(t *testing.T) {
+		require.NoError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			SignExit:              true,
+			ExitEpoch:             256,
+			ExitValidatorIndex:    1000,
+			ExitForkVersion:       [4]byte{0x04, 0x01, 0x70, 0x00},
+			GenesisValidatorsRoot: [32]byte{1, 2, 3},
+		}))
+	})
+
+	t.Run("voluntary exit without genesis validators root", func(t *testing.T) {
+		require.EqualError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			SignExit:              true,
+			ExitEpoch:             256,
+			ExitValidatorIndex:    1000,
+			ExitForkVersion:       [4]byte{0x04, 0x01, 0x70, 0x00},
+		}), "voluntary exit genesis validators root is not set")
+	})
+
+	t.Run("valid phase timeout", func(t *testing.T) {
+		require.NoError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			PhaseTimeout:          uint64(spec.MaxPhaseTimeout.Milliseconds()),
+		}))
+	})
+
+	t.Run("phase timeout exceeds maximum", func(t *testing.T) {
+		require.EqualError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			PhaseTimeout:          uint64(spec.MaxPhaseTimeout.Milliseconds()) + 1,
+		}), "phase timeout 60001ms exceeds maximum 1m0s")
+	})
+
+	t.Run("amount of validators exceeds maximum", func(t *testing.T) {
+		require.EqualError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			Validators:            spec.MaxCeremonyValidators + 1,
+		}), "amount of validators 101 exceeds maximum 100")
+	})
+
+	t.Run("address with BLS withdrawal prefix", func(t *testing.T) {
+		require.EqualError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.BLSWithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		}), "withdrawal credentials are invalid: incorrect withdrawal public key length 20")
+	})
+
+	t.Run("invalid withdrawal prefix", func(t *testing.T) {
+		require.EqualError(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      3,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		}), "withdrawal credentials are invalid: unsupported withdrawal prefix 0x3")
+	})
+
+	t.Run("amount above max effective balance", func(t *testing.T) {
+		require.ErrorContains(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Amount:                uint64(crypto.MaxEffectiveBalanceElectraInGwei),
+		}), "deposit amount 2048000000000 is out of range")
+	})
+
+	t.Run("amount below min deposit", func(t *testing.T) {
+		require.ErrorContains(t, spec.ValidateInitMessage(&wire.Init{
+			Operators:             fixtures.GenerateOperators(4),
+			T:                     3,
+			WithdrawalCredentials: fixtures.TestWithdrawalCred,
+			Fork:                  fixtures.TestFork,
+			Owner:                 fixtures.TestOwnerAddress,
+			Nonce:                 0,
+			WithdrawalPrefix:      crypto.CompoundingWithdrawalPrefixByte,
+			Amount:                1000,
+		}), "deposit amount 1000 is out of range")
+	})
+
 	t.Run("disordered operators", func(t *testing.T) {
 		require.EqualError(t, spec.ValidateInitMessage(&wire.Init{
 			Operators: []*wire.Operator{
@@ -113,3 +274,76 @@ func TestValidateInitMessage(t *testing.T) {
 		}), "threshold set is invalid")
 	})
 }
+
+func TestValidatorInit(t *testing.T) {
+	init := &wire.Init{
+		Operators:          fixtures.GenerateOperators(4),
+		T:                  3,
+		Owner:              fixtures.TestOwnerAddress,
+		Nonce:              5,
+		SignExit:           true,
+		ExitValidatorIndex: 1000,
+		Validators:         3,
+	}
+	require.Equal(t, 3, spec.CeremonyValidators(init))
+	v := spec.ValidatorInit(init, 2)
+	require.EqualValues(t, 7, v.Nonce)
+	require.EqualValues(t, 1002, v.ExitValidatorIndex)
+	require.Equal(t, 1, spec.CeremonyValidators(v))
+	// the init message of the ceremony isn't changed
+	require.EqualValues(t, 5, init.Nonce)
+	require.Equal(t, 1, spec.CeremonyValidators(&wire.Init{}))
+}
+
+func TestVerifySignedInit(t *testing.T) {
+	sk, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	init := wire.Init{
+		Operators:             fixtures.GenerateOperators(4),
+		T:                     3,
+		WithdrawalCredentials: fixtures.TestWithdrawalCred,
+		Fork:                  fixtures.TestFork,
+		Owner:                 eth_crypto.PubkeyToAddress(sk.PublicKey),
+		Nonce:                 0,
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+	}
+	id := crypto.NewID()
+	hash, err := spec.InitSigningRoot(id, &init)
+	require.NoError(t, err)
+	sig, err := eth_crypto.Sign(hash[:], sk)
+	require.NoError(t, err)
+	stubClient := &stubs.Client{CodeAtMap: map[common.Address]bool{}}
+
+	t.Run("valid EOA signature", func(t *testing.T) {
+		require.NoError(t, spec.VerifySignedInit(stubClient, id, &wire.SignedInit{Init: init, Signature: sig}))
+	})
+
+	t.Run("signed init replayed with another ID", func(t *testing.T) {
+		require.EqualError(t, spec.VerifySignedInit(stubClient, crypto.NewID(), &wire.SignedInit{Init: init, Signature: sig}),
+			"invalid signed init signature")
+	})
+
+	t.Run("signature of init message hash only", func(t *testing.T) {
+		initHash, err := init.HashTreeRoot()
+		require.NoError(t, err)
+		initSig, err := eth_crypto.Sign(initHash[:], sk)
+		require.NoError(t, err)
+		require.EqualError(t, spec.VerifySignedInit(stubClient, id, &wire.SignedInit{Init: init, Signature: initSig}),
+			"invalid signed init signature")
+	})
+
+	t.Run("signed init of another owner", func(t *testing.T) {
+		otherInit := init
+		otherInit.Owner = fixtures.TestOwnerAddress
+		require.EqualError(t, spec.VerifySignedInit(stubClient, id, &wire.SignedInit{Init: otherInit, Signature: sig}),
+			"invalid signed init signature")
+	})
+
+	t.Run("signed init with another nonce", func(t *testing.T) {
+		otherInit := init
+		otherInit.Nonce = 1
+		require.EqualError(t, spec.VerifySignedInit(stubClient, id, &wire.SignedInit{Init: otherInit, Signature: sig}),
+			"invalid signed init signature")
+	})
+}
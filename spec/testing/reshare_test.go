@@ -336,3 +336,84 @@ func TestValidateReshare(t *testing.T) {
 		), "new threshold set is invalid")
 	})
 }
+
+func TestValidateTolerantReshare(t *testing.T) {
+	init := &wire.Init{
+		Operators:         fixtures.GenerateOperators(7),
+		T:                 5,
+		Owner:             fixtures.TestOwnerAddress,
+		Nonce:             1,
+		ThresholdTolerant: true,
+	}
+	// operator 7 was excluded from the ceremony
+	qualifiedProofs := map[*wire.Operator]wire.SignedProof{
+		fixtures.GenerateOperators(7)[0]: fixtures.TestOperator1Proof7Operators,
+		fixtures.GenerateOperators(7)[1]: fixtures.TestOperator2Proof7Operators,
+		fixtures.GenerateOperators(7)[2]: fixtures.TestOperator3Proof7Operators,
+		fixtures.GenerateOperators(7)[3]: fixtures.TestOperator4Proof7Operators,
+		fixtures.GenerateOperators(7)[4]: fixtures.TestOperator5Proof7Operators,
+		fixtures.GenerateOperators(7)[5]: fixtures.TestOperator6Proof7Operators,
+	}
+	reshare := func() *wire.Reshare {
+		return &wire.Reshare{
+			ValidatorPubKey: fixtures.ShareSK(fixtures.TestValidator7Operators).GetPublicKey().Serialize(),
+			OldOperators:    fixtures.GenerateOperators(7)[:6],
+			NewOperators:    fixtures.GenerateOperators(7)[:4],
+			OldT:            5,
+			NewT:            3,
+			Owner:           fixtures.TestOwnerAddress,
+			Nonce:           1,
+		}
+	}
+
+	t.Run("valid 6 qualified of 7 operators", func(t *testing.T) {
+		require.NoError(t, spec.ValidateTolerantReshareMessage(init, reshare(), qualifiedProofs))
+	})
+
+	t.Run("ceremony isn't threshold tolerant", func(t *testing.T) {
+		notTolerant := *init
+		notTolerant.ThresholdTolerant = false
+		require.EqualError(t, spec.ValidateTolerantReshareMessage(&notTolerant, reshare(), qualifiedProofs), "ceremony isn't threshold tolerant")
+	})
+
+	t.Run("old threshold doesn't match", func(t *testing.T) {
+		r := reshare()
+		r.OldT = 4
+		require.EqualError(t, spec.ValidateTolerantReshareMessage(init, r, qualifiedProofs), "old threshold doesn't match the ceremony")
+	})
+
+	t.Run("old operator isn't an operator of the ceremony", func(t *testing.T) {
+		other := *init
+		other.Operators = fixtures.GenerateOperators(4)
+		require.EqualError(t, spec.ValidateTolerantReshareMessage(&other, reshare(), qualifiedProofs), "old operator 5 isn't an operator of the ceremony")
+	})
+
+	t.Run("new operator isn't an old operator", func(t *testing.T) {
+		r := reshare()
+		r.NewOperators = []*wire.Operator{
+			fixtures.GenerateOperators(7)[0],
+			fixtures.GenerateOperators(7)[1],
+			fixtures.GenerateOperators(7)[2],
+			fixtures.GenerateOperators(7)[6],
+		}
+		require.EqualError(t, spec.ValidateTolerantReshareMessage(init, r, qualifiedProofs), "new operator 7 isn't an old operator")
+	})
+
+	t.Run("invalid new threshold", func(t *testing.T) {
+		r := reshare()
+		r.NewOperators = fixtures.GenerateOperators(7)[:5]
+		require.EqualError(t, spec.ValidateTolerantReshareMessage(init, r, qualifiedProofs), "new threshold set is invalid")
+	})
+
+	t.Run("nonce doesn't match", func(t *testing.T) {
+		r := reshare()
+		r.Nonce = 2
+		require.EqualError(t, spec.ValidateTolerantReshareMessage(init, r, qualifiedProofs), "owner and nonce don't match the ceremony")
+	})
+}
+
+func TestRegistrableClusterSize(t *testing.T) {
+	for n, size := range map[int]int{0: 0, 3: 0, 4: 4, 6: 4, 7: 7, 9: 7, 10: 10, 12: 10, 13: 13, 20: 13} {
+		require.Equal(t, size, spec.RegistrableClusterSize(n), "operators %d", n)
+	}
+}
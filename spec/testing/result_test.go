@@ -3,6 +3,7 @@ package testing
 import (
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
@@ -16,6 +17,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(4),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -30,6 +33,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(7),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator7Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -44,6 +49,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(10),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator10Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -58,6 +65,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(13),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator13Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -85,6 +94,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(4),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -100,6 +111,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(4),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -122,6 +135,8 @@ func TestValidateResults(t *testing.T) {
 		_, _, _, err := spec.ValidateResults(
 			fixtures.GenerateOperators(4),
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestOwnerAddress,
@@ -140,6 +155,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -159,6 +176,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator7Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -178,6 +197,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator10Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -197,6 +218,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator13Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -216,6 +239,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -229,12 +254,56 @@ func TestValidateResult(t *testing.T) {
 		), "operator not found")
 	})
 
+	t.Run("deposit signed with other withdrawal prefix", func(t *testing.T) {
+		require.ErrorContains(t, spec.ValidateResult(
+			fixtures.GenerateOperators(4),
+			fixtures.TestOwnerAddress,
+			fixtures.TestRequestID,
+			fixtures.TestWithdrawalCred,
+			crypto.CompoundingWithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
+			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
+			fixtures.TestFork,
+			fixtures.TestNonce,
+			&wire.Result{
+				OperatorID:                 1,
+				RequestID:                  fixtures.TestRequestID,
+				DepositPartialSignature:    fixtures.DecodeHexNoError(fixtures.TestOperator1DepositSignature4Operators),
+				OwnerNoncePartialSignature: fixtures.DecodeHexNoError(fixtures.TestOperator1NonceSignature4Operators),
+				SignedProof:                fixtures.TestOperator1Proof4Operators,
+			},
+		), "failed to verify deposit partial signatures")
+	})
+
+	t.Run("deposit signed with other amount", func(t *testing.T) {
+		require.ErrorContains(t, spec.ValidateResult(
+			fixtures.GenerateOperators(4),
+			fixtures.TestOwnerAddress,
+			fixtures.TestRequestID,
+			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MinDepositAmountInGwei,
+			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
+			fixtures.TestFork,
+			fixtures.TestNonce,
+			&wire.Result{
+				OperatorID:                 1,
+				RequestID:                  fixtures.TestRequestID,
+				DepositPartialSignature:    fixtures.DecodeHexNoError(fixtures.TestOperator1DepositSignature4Operators),
+				OwnerNoncePartialSignature: fixtures.DecodeHexNoError(fixtures.TestOperator1NonceSignature4Operators),
+				SignedProof:                fixtures.TestOperator1Proof4Operators,
+			},
+		), "failed to verify deposit partial signatures")
+	})
+
 	t.Run("invalid request ID", func(t *testing.T) {
 		require.EqualError(t, spec.ValidateResult(
 			fixtures.GenerateOperators(4),
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -254,6 +323,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -273,6 +344,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -292,6 +365,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -317,6 +392,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -343,6 +420,8 @@ func TestValidateResult(t *testing.T) {
 			fixtures.TestOwnerAddress,
 			fixtures.TestRequestID,
 			fixtures.TestWithdrawalCred,
+			crypto.ETH1WithdrawalPrefixByte,
+			crypto.MaxEffectiveBalanceInGwei,
 			fixtures.ShareSK(fixtures.TestValidator7Operators).GetPublicKey().Serialize(),
 			fixtures.TestFork,
 			fixtures.TestNonce,
@@ -356,3 +435,61 @@ func TestValidateResult(t *testing.T) {
 		), "invalid proof validator pubkey")
 	})
 }
+
+func TestValidateExitResults(t *testing.T) {
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+	forkVersion := [4]byte{0x04, 0x01, 0x70, 0x00}
+	genesisValidatorsRoot := [32]byte{1, 2, 3}
+	validatorPK := fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize()
+	shares := []string{
+		fixtures.TestValidator4OperatorsShare1,
+		fixtures.TestValidator4OperatorsShare2,
+		fixtures.TestValidator4OperatorsShare3,
+		fixtures.TestValidator4OperatorsShare4,
+	}
+	signedResults := func() []*wire.Result {
+		root, err := crypto.ComputeVoluntaryExitSigningRoot(exit, forkVersion, genesisValidatorsRoot)
+		require.NoError(t, err)
+		results := fixtures.Results4Operators()
+		for i, result := range results {
+			result.ExitPartialSignature = fixtures.ShareSK(shares[i]).SignByte(root[:]).Serialize()
+		}
+		return results
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		signedExit, err := spec.ValidateExitResults(exit, forkVersion, genesisValidatorsRoot, validatorPK, signedResults())
+		require.NoError(t, err)
+		require.Equal(t, exit, signedExit.Message)
+		require.NoError(t, crypto.VerifyVoluntaryExit(validatorPK, signedExit, forkVersion, genesisValidatorsRoot))
+	})
+
+	t.Run("valid threshold of results", func(t *testing.T) {
+		_, err := spec.ValidateExitResults(exit, forkVersion, genesisValidatorsRoot, validatorPK, signedResults()[1:])
+		require.NoError(t, err)
+	})
+
+	t.Run("missing partial signature", func(t *testing.T) {
+		results := signedResults()
+		results[2].ExitPartialSignature = nil
+		_, err := spec.ValidateExitResults(exit, forkVersion, genesisValidatorsRoot, validatorPK, results)
+		require.ErrorContains(t, err, "failed to decode exit partial signature of operator 3")
+	})
+
+	t.Run("partial signature of another operator", func(t *testing.T) {
+		results := signedResults()
+		results[1].ExitPartialSignature = results[0].ExitPartialSignature
+		_, err := spec.ValidateExitResults(exit, forkVersion, genesisValidatorsRoot, validatorPK, results)
+		require.EqualError(t, err, "failed to verify exit partial signature of operator 2")
+	})
+
+	t.Run("different exit", func(t *testing.T) {
+		_, err := spec.ValidateExitResults(&phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1001}, forkVersion, genesisValidatorsRoot, validatorPK, signedResults())
+		require.EqualError(t, err, "failed to verify exit partial signature of operator 1")
+	})
+
+	t.Run("different genesis validators root", func(t *testing.T) {
+		_, err := spec.ValidateExitResults(exit, forkVersion, [32]byte{}, validatorPK, signedResults())
+		require.EqualError(t, err, "failed to verify exit partial signature of operator 1")
+	})
+}
@@ -0,0 +1,182 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+	"github.com/bloxapp/ssv-dkg/spec"
+	"github.com/bloxapp/ssv-dkg/spec/testing/fixtures"
+	"github.com/bloxapp/ssv-dkg/spec/testing/stubs"
+)
+
+func blsSignRequest4Operators(t *testing.T, msgType wire.BeaconMessageType, msg wire.SSZMarshaller) *wire.BlsSignRequest {
+	data, err := msg.MarshalSSZ()
+	require.NoError(t, err)
+	return &wire.BlsSignRequest{
+		ValidatorPubKey:       fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
+		Operators:             fixtures.GenerateOperators(4),
+		Owner:                 fixtures.TestOwnerAddress,
+		MessageType:           msgType,
+		Message:               data,
+		ForkVersion:           [4]byte{0x04, 0x01, 0x70, 0x00},
+		GenesisValidatorsRoot: [32]byte{1, 2, 3},
+	}
+}
+
+func proofs4Operators() map[*wire.Operator]wire.SignedProof {
+	return map[*wire.Operator]wire.SignedProof{
+		fixtures.GenerateOperators(4)[0]: fixtures.TestOperator1Proof4Operators,
+		fixtures.GenerateOperators(4)[1]: fixtures.TestOperator2Proof4Operators,
+		fixtures.GenerateOperators(4)[2]: fixtures.TestOperator3Proof4Operators,
+		fixtures.GenerateOperators(4)[3]: fixtures.TestOperator4Proof4Operators,
+	}
+}
+
+func TestValidateBlsSignRequest(t *testing.T) {
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+
+	t.Run("valid voluntary exit", func(t *testing.T) {
+		require.NoError(t, spec.ValidateBlsSignRequest(blsSignRequest4Operators(t, wire.VoluntaryExitBeaconMessage, exit), proofs4Operators()))
+	})
+
+	t.Run("valid BLS to execution change", func(t *testing.T) {
+		change := &capella.BLSToExecutionChange{ValidatorIndex: 1000, ToExecutionAddress: [20]byte{1}}
+		copy(change.FromBLSPubkey[:], fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize())
+		require.NoError(t, spec.ValidateBlsSignRequest(blsSignRequest4Operators(t, wire.BLSToExecutionChangeBeaconMessage, change), proofs4Operators()))
+	})
+
+	t.Run("BLS to execution change from another key", func(t *testing.T) {
+		change := &capella.BLSToExecutionChange{ValidatorIndex: 1000, ToExecutionAddress: [20]byte{1}}
+		copy(change.FromBLSPubkey[:], fixtures.ShareSK(fixtures.TestValidator4OperatorsShare1).GetPublicKey().Serialize())
+		require.EqualError(t, spec.ValidateBlsSignRequest(blsSignRequest4Operators(t, wire.BLSToExecutionChangeBeaconMessage, change), proofs4Operators()),
+			"BLS to execution change is not from the validator public key")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		require.EqualError(t, spec.ValidateBlsSignRequest(blsSignRequest4Operators(t, 5, exit), proofs4Operators()),
+			"unsupported beacon message type 5")
+	})
+
+	t.Run("message of another type", func(t *testing.T) {
+		require.ErrorContains(t, spec.ValidateBlsSignRequest(blsSignRequest4Operators(t, wire.BLSToExecutionChangeBeaconMessage, exit), proofs4Operators()),
+			"failed to unmarshal BLS to execution change")
+	})
+
+	t.Run("another owner", func(t *testing.T) {
+		req := blsSignRequest4Operators(t, wire.VoluntaryExitBeaconMessage, exit)
+		req.Owner = [20]byte{1}
+		require.EqualError(t, spec.ValidateBlsSignRequest(req, proofs4Operators()), "invalid owner address")
+	})
+
+	t.Run("missing proof", func(t *testing.T) {
+		proofs := proofs4Operators()
+		for op := range proofs {
+			if op.ID == 2 {
+				delete(proofs, op)
+			}
+		}
+		require.EqualError(t, spec.ValidateBlsSignRequest(blsSignRequest4Operators(t, wire.VoluntaryExitBeaconMessage, exit), proofs), "missing operator proofs")
+	})
+
+	t.Run("non 3f+1 operators", func(t *testing.T) {
+		req := blsSignRequest4Operators(t, wire.VoluntaryExitBeaconMessage, exit)
+		req.Operators = fixtures.GenerateOperators(4)[:3]
+		require.EqualError(t, spec.ValidateBlsSignRequest(req, proofs4Operators()), "threshold set is invalid")
+	})
+}
+
+func TestVerifySignedBlsSignRequest(t *testing.T) {
+	sk, err := eth_crypto.GenerateKey()
+	require.NoError(t, err)
+	req := blsSignRequest4Operators(t, wire.VoluntaryExitBeaconMessage, &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000})
+	req.Owner = eth_crypto.PubkeyToAddress(sk.PublicKey)
+	hash, err := req.HashTreeRoot()
+	require.NoError(t, err)
+	sig, err := eth_crypto.Sign(hash[:], sk)
+	require.NoError(t, err)
+	stubClient := &stubs.Client{CodeAtMap: map[common.Address]bool{}}
+
+	t.Run("valid EOA signature", func(t *testing.T) {
+		require.NoError(t, spec.VerifySignedBlsSignRequest(stubClient, &wire.SignedBlsSignRequest{Request: *req, Signature: sig}))
+	})
+
+	t.Run("signed request of another validator", func(t *testing.T) {
+		otherReq := *req
+		otherReq.ValidatorPubKey = fixtures.ShareSK(fixtures.TestValidator4OperatorsShare1).GetPublicKey().Serialize()
+		require.EqualError(t, spec.VerifySignedBlsSignRequest(stubClient, &wire.SignedBlsSignRequest{Request: otherReq, Signature: sig}),
+			"invalid signed BLS sign request signature")
+	})
+}
+
+func TestValidateBlsSignResponses(t *testing.T) {
+	exit := &phase0.VoluntaryExit{Epoch: 256, ValidatorIndex: 1000}
+	req := blsSignRequest4Operators(t, wire.VoluntaryExitBeaconMessage, exit)
+	proofs := []*wire.SignedProof{
+		&fixtures.TestOperator1Proof4Operators,
+		&fixtures.TestOperator2Proof4Operators,
+		&fixtures.TestOperator3Proof4Operators,
+		&fixtures.TestOperator4Proof4Operators,
+	}
+	shares := []string{
+		fixtures.TestValidator4OperatorsShare1,
+		fixtures.TestValidator4OperatorsShare2,
+		fixtures.TestValidator4OperatorsShare3,
+		fixtures.TestValidator4OperatorsShare4,
+	}
+	responses := func() []*wire.BlsSignResponse {
+		root, err := spec.BlsSignRequestSigningRoot(req)
+		require.NoError(t, err)
+		res := make([]*wire.BlsSignResponse, len(shares))
+		for i, share := range shares {
+			res[i] = &wire.BlsSignResponse{
+				OperatorID:       uint64(i + 1),
+				PartialSignature: fixtures.ShareSK(share).SignByte(root[:]).Serialize(),
+			}
+		}
+		return res
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		sig, err := spec.ValidateBlsSignResponses(req, proofs, responses())
+		require.NoError(t, err)
+		validatorPK := fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize()
+		require.NoError(t, crypto.VerifyVoluntaryExit(validatorPK, &phase0.SignedVoluntaryExit{Message: exit, Signature: sig}, req.ForkVersion, req.GenesisValidatorsRoot))
+	})
+
+	t.Run("valid threshold of responses", func(t *testing.T) {
+		_, err := spec.ValidateBlsSignResponses(req, proofs, responses()[1:])
+		require.NoError(t, err)
+	})
+
+	t.Run("not enough responses", func(t *testing.T) {
+		_, err := spec.ValidateBlsSignResponses(req, proofs, responses()[2:])
+		require.EqualError(t, err, "not enough partial signatures: 2, threshold 3")
+	})
+
+	t.Run("duplicate response", func(t *testing.T) {
+		res := responses()
+		_, err := spec.ValidateBlsSignResponses(req, proofs, append(res[1:], res[1]))
+		require.EqualError(t, err, "duplicate partial signature of operator 2")
+	})
+
+	t.Run("partial signature of another operator", func(t *testing.T) {
+		res := responses()
+		res[1].PartialSignature = res[0].PartialSignature
+		_, err := spec.ValidateBlsSignResponses(req, proofs, res)
+		require.EqualError(t, err, "failed to verify partial signature of operator 2")
+	})
+
+	t.Run("unknown operator", func(t *testing.T) {
+		res := responses()
+		res[0].OperatorID = 5
+		_, err := spec.ValidateBlsSignResponses(req, proofs, res)
+		require.EqualError(t, err, "operator 5 isn't a part of the request")
+	})
+}
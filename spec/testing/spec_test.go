@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"crypto/ecdsa"
 	"testing"
 
 	"github.com/ethereum/go-ethereum"
@@ -150,4 +151,66 @@ func TestVerifySignedReshare(t *testing.T) {
 			Signature: sig,
 		}), "signature invalid")
 	})
+
+	t.Run("valid Safe multisig signature", func(t *testing.T) {
+		var owners []*ecdsa.PrivateKey
+		safe := &stubs.Safe{Threshold: 2}
+		for i := 0; i < 3; i++ {
+			sk, err := eth_crypto.GenerateKey()
+			require.NoError(t, err)
+			owners = append(owners, sk)
+			safe.Owners = append(safe.Owners, eth_crypto.PubkeyToAddress(sk.PublicKey))
+		}
+		stubClient := stubs.NewSafeClient(map[common.Address]*stubs.Safe{fixtures.TestOwnerAddress: safe})
+
+		reshare := wire.Reshare{
+			ValidatorPubKey: fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
+			OldOperators:    fixtures.GenerateOperators(4),
+			NewOperators:    fixtures.GenerateOperators(7),
+			OldT:            3,
+			NewT:            5,
+			Owner:           fixtures.TestOwnerAddress,
+		}
+		hash, err := reshare.HashTreeRoot()
+		require.NoError(t, err)
+
+		sig, err := stubs.SignSafe(hash[:], owners[1], owners[2])
+		require.NoError(t, err)
+
+		require.NoError(t, spec.VerifySignedReshare(stubClient, &wire.SignedReshare{
+			Reshare:   reshare,
+			Signature: sig,
+		}))
+	})
+
+	t.Run("not enough Safe multisig owners", func(t *testing.T) {
+		var owners []*ecdsa.PrivateKey
+		safe := &stubs.Safe{Threshold: 2}
+		for i := 0; i < 3; i++ {
+			sk, err := eth_crypto.GenerateKey()
+			require.NoError(t, err)
+			owners = append(owners, sk)
+			safe.Owners = append(safe.Owners, eth_crypto.PubkeyToAddress(sk.PublicKey))
+		}
+		stubClient := stubs.NewSafeClient(map[common.Address]*stubs.Safe{fixtures.TestOwnerAddress: safe})
+
+		reshare := wire.Reshare{
+			ValidatorPubKey: fixtures.ShareSK(fixtures.TestValidator4Operators).GetPublicKey().Serialize(),
+			OldOperators:    fixtures.GenerateOperators(4),
+			NewOperators:    fixtures.GenerateOperators(7),
+			OldT:            3,
+			NewT:            5,
+			Owner:           fixtures.TestOwnerAddress,
+		}
+		hash, err := reshare.HashTreeRoot()
+		require.NoError(t, err)
+
+		sig, err := stubs.SignSafe(hash[:], owners[0])
+		require.NoError(t, err)
+
+		require.EqualError(t, spec.VerifySignedReshare(stubClient, &wire.SignedReshare{
+			Reshare:   reshare,
+			Signature: sig,
+		}), "signature invalid")
+	})
 }
@@ -0,0 +1,119 @@
+package stubs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/bloxapp/ssv-dkg/spec/eip1271"
+)
+
+// Safe is a local stand-in for a Safe multisig wallet https://github.com/safe-global/safe-smart-account
+// implementing EIP-1271. A signature is valid if it contains ECDSA signatures of at least Threshold owners
+// concatenated in increasing owners' addresses order, see SignSafe.
+type Safe struct {
+	Owners    []common.Address
+	Threshold int
+}
+
+// NewSafeClient creates a stub ETH client with Safe wallets deployed at given addresses.
+// All other addresses are treated as EOA accounts.
+func NewSafeClient(safes map[common.Address]*Safe) *Client {
+	codeAt := make(map[common.Address]bool, len(safes))
+	for address := range safes {
+		codeAt[address] = true
+	}
+	return &Client{
+		CodeAtMap: codeAt,
+		CallContractF: func(call ethereum.CallMsg) ([]byte, error) {
+			if call.To == nil {
+				return nil, fmt.Errorf("contract address is empty")
+			}
+			safe, ok := safes[*call.To]
+			if !ok {
+				return nil, fmt.Errorf("no contract at address %s", call.To.Hex())
+			}
+			return safe.isValidSignature(call.Data)
+		},
+	}
+}
+
+// SignSafe creates a Safe signature: ECDSA signatures of the owners ordered by owners' addresses
+func SignSafe(hash []byte, owners ...*ecdsa.PrivateKey) ([]byte, error) {
+	sort.Slice(owners, func(i, j int) bool {
+		a := eth_crypto.PubkeyToAddress(owners[i].PublicKey)
+		b := eth_crypto.PubkeyToAddress(owners[j].PublicKey)
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	var sigs []byte
+	for _, sk := range owners {
+		sig, err := eth_crypto.Sign(hash, sk)
+		if err != nil {
+			return nil, err
+		}
+		// Safe expects v to be 27 or 28 for ECDSA signatures
+		sig[64] += 27
+		sigs = append(sigs, sig...)
+	}
+	return sigs, nil
+}
+
+func (s *Safe) isValidSignature(data []byte) ([]byte, error) {
+	parsed, err := eip1271.Eip1271MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method := parsed.Methods["isValidSignature"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, fmt.Errorf("unknown method")
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	hash := args[0].([]byte)
+	sig := args[1].([]byte)
+	if s.checkSignatures(hash, sig) {
+		return method.Outputs.Pack(eip1271.MagicValue)
+	}
+	return method.Outputs.Pack(eip1271.InvalidSigValue)
+}
+
+func (s *Safe) checkSignatures(hash, sig []byte) bool {
+	if len(sig)%65 != 0 || len(sig)/65 < s.Threshold {
+		return false
+	}
+	var last common.Address
+	for i := 0; i < len(sig); i += 65 {
+		ecdsaSig := make([]byte, 65)
+		copy(ecdsaSig, sig[i:i+65])
+		if ecdsaSig[64] >= 27 {
+			ecdsaSig[64] -= 27
+		}
+		pk, err := eth_crypto.SigToPub(hash, ecdsaSig)
+		if err != nil {
+			return false
+		}
+		signer := eth_crypto.PubkeyToAddress(*pk)
+		// owners should be unique and ordered
+		if bytes.Compare(signer[:], last[:]) <= 0 || !s.isOwner(signer) {
+			return false
+		}
+		last = signer
+	}
+	return true
+}
+
+func (s *Safe) isOwner(address common.Address) bool {
+	for _, owner := range s.Owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}